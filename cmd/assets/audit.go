@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/internal/audit"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+)
+
+// AUDIT command - review the local log of changes
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Review changes made through the CLI and MCP server",
+	Long: `Every create, update, delete, apply and remove call made through the CLI or the
+MCP server appends a record to a local audit log: the actor, profile, workspace,
+command or MCP tool and session, operation, target IDs, a hash of the payload and
+the result.
+
+The log is audit/audit.jsonl in the cache directory unless ATLASSIAN_ASSETS_AUDIT_LOG
+names another file ("off" disables it). It is rotated once it reaches
+ATLASSIAN_ASSETS_AUDIT_MAX_SIZE_MB (default 10), keeping
+ATLASSIAN_ASSETS_AUDIT_MAX_FILES older files (default 5).`,
+}
+
+// AUDIT LIST subcommand
+var auditListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List audited changes",
+	Long: `List the audit records, oldest first, including those in rotated files.
+--since takes a duration back from now (90m, 24h, 7d) or a date (2025-08-30 or
+RFC 3339).`,
+	Example: `  # Changes of the last day
+  assets audit list --since 24h
+
+  # Deletions of the last week
+  assets audit list --since 7d --operation delete_object
+
+  # Everything that touched an object
+  assets audit list --target 1234`,
+	RunE: runAuditListCmd,
+}
+
+var (
+	auditSince     string
+	auditOperation string
+	auditTarget    string
+	auditLimit     int
+)
+
+func init() {
+	auditListCmd.Flags().StringVar(&auditSince, "since", "", "Only changes since a duration ago (24h, 7d) or a date")
+	auditListCmd.Flags().StringVar(&auditOperation, "operation", "", "Only this operation (create_object, update_object, delete_object, ...)")
+	auditListCmd.Flags().StringVar(&auditTarget, "target", "", "Only changes that touched this ID")
+	auditListCmd.Flags().IntVar(&auditLimit, "limit", 0, "Show only the most recent N records (0 for all)")
+
+	auditCmd.AddCommand(auditListCmd)
+}
+
+func runAuditListCmd(cmd *cobra.Command, args []string) error {
+	since, err := audit.ParseSince(auditSince, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// The log is local, so no credentials are needed
+	cfg, err := config.LoadLocalConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	path := cfg.GetAuditPath()
+	if path == "" {
+		return fmt.Errorf("the audit log is disabled (ATLASSIAN_ASSETS_AUDIT_LOG=off)")
+	}
+
+	all, err := audit.Open(path, cfg.AuditMaxSizeMB, cfg.AuditMaxFiles).Records(since)
+	if err != nil {
+		return err
+	}
+
+	records := make([]*audit.Record, 0, len(all))
+	failed := 0
+	for _, record := range all {
+		if auditOperation != "" && record.Operation != auditOperation {
+			continue
+		}
+		if auditTarget != "" && !contains(record.Targets, auditTarget) {
+			continue
+		}
+		if record.Result != audit.ResultSuccess {
+			failed++
+		}
+		records = append(records, record)
+	}
+	if auditLimit > 0 && len(records) > auditLimit {
+		records = records[len(records)-auditLimit:]
+	}
+
+	data := map[string]interface{}{
+		"records": records,
+		"total":   len(records),
+		"file":    path,
+	}
+	if !since.IsZero() {
+		data["since"] = since.UTC().Format(time.RFC3339)
+	}
+	response := common.NewSuccessResponse(data)
+
+	hintVars := map[string]interface{}{
+		"success":     true,
+		"has_results": len(records) > 0,
+		"no_results":  len(records) == 0,
+		"has_errors":  failed > 0,
+	}
+	return outputResult(addNextStepHints(response, "audit_list", hintVars))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
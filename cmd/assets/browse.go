@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
 )
 
 // BROWSE command - high-level schema browsing tools
@@ -49,7 +51,7 @@ func runBrowseHierarchyCmd(cmd *cobra.Command, args []string) error {
 	// This is a "glue" command - it calls the fundamental schema types command
 	// then processes the output with structured logic instead of complex jq
 	
-	client, err := getClient()
+	client, err := getReadClient()
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
@@ -139,20 +141,19 @@ func init() {
 }
 
 func runBrowseAttributesCmd(cmd *cobra.Command, args []string) error {
-	client, err := getClient()
+	client, err := getReadClient()
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 	defer client.Close()
 
-	ctx := context.Background()
 	typeIDs := strings.Split(browseTypes, ",")
 	
 	comparison := make(map[string]interface{})
 	
 	for _, typeID := range typeIDs {
 		typeID = strings.TrimSpace(typeID)
-		response, err := client.GetObjectTypeAttributes(ctx, typeID)
+		response, err := foundation.GetObjectTypeAttributes(client, common.GetObjectTypeAttributesParams{ObjectTypeID: typeID})
 		if err != nil {
 			continue
 		}
@@ -0,0 +1,472 @@
+// Package commontest provides an in-memory ClientInterface for testing the
+// foundation and composite layers without an Atlassian instance.
+package commontest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+)
+
+// MockClient serves canned workspace data with the same response shapes as the real
+// client and records every mutation it receives
+type MockClient struct {
+	Schemas       []*models.ObjectSchemaScheme
+	ObjectTypes   map[string][]*models.ObjectTypeScheme          // Object types by schema ID
+	Attributes    map[string][]*models.ObjectTypeAttributeScheme // Attributes by object type ID
+	Objects       map[string]*models.ObjectScheme                // Objects by ID
+	SearchResults []*models.ObjectScheme                         // Returned by every search
+	Users         []*models.UserScheme                           // Jira users matched by SearchUsers
+	Groups        []*models.GroupDetailScheme                    // Jira groups matched by SearchGroups
+	Statuses      []*models.ObjectTypeAssetAttributeStatusScheme // Status types of every schema
+	Icons         []*models.IconScheme                           // Global icons
+	History       map[string][]*models.ObjectHistoryScheme       // Change history by object ID, newest first
+	AllowDelete   bool
+	Config        *config.Config
+
+	// Failures makes the named method return an error response with the given message
+	Failures map[string]string
+
+	// Recorded calls, guarded by mu for callers that run concurrently
+	mu                 sync.Mutex
+	SearchQueries      []string
+	CreatedAttributes  map[string][]*models.ObjectTypeAttributePayloadScheme
+	CreatedObjects     map[string][]map[string]interface{}
+	UpdatedObjects     map[string][]map[string]interface{}
+	DeletedObjects     []string
+	DeletedObjectTypes []string
+	RemovedAttributes  []string
+}
+
+var _ common.ClientInterface = (*MockClient)(nil)
+
+// NewMockClient creates an empty mock client with deletions allowed
+func NewMockClient() *MockClient {
+	return &MockClient{
+		ObjectTypes:       make(map[string][]*models.ObjectTypeScheme),
+		Attributes:        make(map[string][]*models.ObjectTypeAttributeScheme),
+		Objects:           make(map[string]*models.ObjectScheme),
+		History:           make(map[string][]*models.ObjectHistoryScheme),
+		AllowDelete:       true,
+		Config:            &config.Config{},
+		Failures:          make(map[string]string),
+		CreatedAttributes: make(map[string][]*models.ObjectTypeAttributePayloadScheme),
+		CreatedObjects:    make(map[string][]map[string]interface{}),
+		UpdatedObjects:    make(map[string][]map[string]interface{}),
+	}
+}
+
+// AddObjectType registers an object type and its attributes under a schema
+func (m *MockClient) AddObjectType(objectType *models.ObjectTypeScheme, attributes ...*models.ObjectTypeAttributeScheme) {
+	m.ObjectTypes[objectType.ObjectSchemaID] = append(m.ObjectTypes[objectType.ObjectSchemaID], objectType)
+	m.Attributes[objectType.ID] = append(m.Attributes[objectType.ID], attributes...)
+}
+
+// failure returns the configured error response for a method, if any
+func (m *MockClient) failure(method string) *client.Response {
+	if message, ok := m.Failures[method]; ok {
+		return client.NewErrorResponse(errors.New(message))
+	}
+	return nil
+}
+
+func (m *MockClient) findObjectType(objectTypeID string) *models.ObjectTypeScheme {
+	for _, objectTypes := range m.ObjectTypes {
+		for _, objectType := range objectTypes {
+			if objectType.ID == objectTypeID {
+				return objectType
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MockClient) SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error) {
+	return m.SearchObjectsWithPagination(ctx, query, limit, 0)
+}
+
+func (m *MockClient) SearchObjectsWithPagination(ctx context.Context, query string, limit int, offset int) (*client.Response, error) {
+	if failed := m.failure("SearchObjects"); failed != nil {
+		return failed, nil
+	}
+	m.mu.Lock()
+	m.SearchQueries = append(m.SearchQueries, query)
+	m.mu.Unlock()
+
+	objects := m.SearchResults
+	if offset > len(objects) {
+		offset = len(objects)
+	}
+	objects = objects[offset:]
+	if limit > 0 && limit < len(objects) {
+		objects = objects[:limit]
+	}
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"objects": objects,
+		"total":   len(m.SearchResults),
+		"query":   query,
+	}), nil
+}
+
+func (m *MockClient) ListObjects(ctx context.Context, schemaID string, limit int) (*client.Response, error) {
+	return m.ListObjectsWithPagination(ctx, schemaID, limit, 0)
+}
+
+func (m *MockClient) ListObjectsWithPagination(ctx context.Context, schemaID string, limit int, offset int) (*client.Response, error) {
+	if failed := m.failure("ListObjects"); failed != nil {
+		return failed, nil
+	}
+
+	query := fmt.Sprintf("objectSchemaId = %s", schemaID)
+	response, err := m.SearchObjectsWithPagination(ctx, query, limit, offset)
+	if err != nil || !response.Success {
+		return response, err
+	}
+
+	data := response.Data.(map[string]interface{})
+	data["schema"] = schemaID
+	return response, nil
+}
+
+func (m *MockClient) GetObject(ctx context.Context, objectID string) (*client.Response, error) {
+	if failed := m.failure("GetObject"); failed != nil {
+		return failed, nil
+	}
+
+	object, ok := m.Objects[objectID]
+	if !ok {
+		return client.NewErrorResponse(fmt.Errorf("API error: 404 - object %s not found", objectID)), nil
+	}
+	return client.NewSuccessResponse(object), nil
+}
+
+// GetObjectHistory returns the canned history of an object
+func (m *MockClient) GetObjectHistory(ctx context.Context, objectID string) (*client.Response, error) {
+	if failed := m.failure("GetObjectHistory"); failed != nil {
+		return failed, nil
+	}
+
+	if _, ok := m.Objects[objectID]; !ok {
+		return client.NewErrorResponse(fmt.Errorf("API error: 404 - object %s not found", objectID)), nil
+	}
+	history := m.History[objectID]
+	return client.NewSuccessResponse(map[string]interface{}{
+		"history":   history,
+		"object_id": objectID,
+		"total":     len(history),
+	}), nil
+}
+
+// SearchUsers returns the users whose email address or display name contains the query
+func (m *MockClient) SearchUsers(ctx context.Context, query string, limit int) (*client.Response, error) {
+	if failed := m.failure("SearchUsers"); failed != nil {
+		return failed, nil
+	}
+
+	var users []*models.UserScheme
+	for _, user := range m.Users {
+		if containsFold(user.EmailAddress, query) || containsFold(user.DisplayName, query) {
+			users = append(users, user)
+		}
+	}
+	return client.NewSuccessResponse(map[string]interface{}{"users": users, "total": len(users), "query": query}), nil
+}
+
+// SearchGroups returns the groups whose name contains the query
+func (m *MockClient) SearchGroups(ctx context.Context, query string, limit int) (*client.Response, error) {
+	if failed := m.failure("SearchGroups"); failed != nil {
+		return failed, nil
+	}
+
+	var groups []*models.GroupDetailScheme
+	for _, group := range m.Groups {
+		if containsFold(group.Name, query) {
+			groups = append(groups, group)
+		}
+	}
+	return client.NewSuccessResponse(map[string]interface{}{"groups": groups, "total": len(groups), "query": query}), nil
+}
+
+// GetStatusTypes returns the canned status types
+func (m *MockClient) GetStatusTypes(ctx context.Context, schemaID string) (*client.Response, error) {
+	if failed := m.failure("GetStatusTypes"); failed != nil {
+		return failed, nil
+	}
+	return client.NewSuccessResponse(map[string]interface{}{"statuses": m.Statuses, "total": len(m.Statuses), "schema_id": schemaID}), nil
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func (m *MockClient) CreateObjectType(ctx context.Context, schemaID, name, description, iconID string, parentObjectTypeID *string) (*client.Response, error) {
+	if failed := m.failure("CreateObjectType"); failed != nil {
+		return failed, nil
+	}
+
+	objectType := &models.ObjectTypeScheme{
+		ID:             fmt.Sprintf("%d", 1000+len(m.ObjectTypes[schemaID])),
+		Name:           name,
+		Description:    description,
+		ObjectSchemaID: schemaID,
+	}
+	if parentObjectTypeID != nil {
+		objectType.ParentObjectTypeID = *parentObjectTypeID
+	}
+	if iconID != "" {
+		objectType.Icon = &models.IconScheme{ID: iconID}
+	}
+	m.AddObjectType(objectType)
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object_type": objectType,
+		"message":     fmt.Sprintf("Successfully created object type '%s' in schema %s", name, schemaID),
+	}), nil
+}
+
+func (m *MockClient) CreateObject(ctx context.Context, objectTypeID string, attributes map[string]interface{}) (*client.Response, error) {
+	if failed := m.failure("CreateObject"); failed != nil {
+		return failed, nil
+	}
+	m.CreatedObjects[objectTypeID] = append(m.CreatedObjects[objectTypeID], attributes)
+
+	// Created objects are numbered from 5000 in creation order
+	created := 0
+	for _, objects := range m.CreatedObjects {
+		created += len(objects)
+	}
+	id := fmt.Sprintf("%d", 4999+created)
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object":      &models.ObjectScheme{ID: id, ObjectKey: "NEW-" + id, ObjectType: &models.ObjectTypeScheme{ID: objectTypeID}},
+		"object_type": objectTypeID,
+		"message":     fmt.Sprintf("Successfully created object in object type %s", objectTypeID),
+	}), nil
+}
+
+func (m *MockClient) UpdateObject(ctx context.Context, objectID, objectTypeID string, attributes map[string]interface{}) (*client.Response, error) {
+	if failed := m.failure("UpdateObject"); failed != nil {
+		return failed, nil
+	}
+	m.mu.Lock()
+	m.UpdatedObjects[objectID] = append(m.UpdatedObjects[objectID], attributes)
+	m.mu.Unlock()
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object":    &models.ObjectScheme{ID: objectID, ObjectType: &models.ObjectTypeScheme{ID: objectTypeID}},
+		"object_id": objectID,
+		"message":   fmt.Sprintf("Successfully updated object %s", objectID),
+	}), nil
+}
+
+func (m *MockClient) DeleteObject(ctx context.Context, objectID string) (*client.Response, error) {
+	if !m.AllowDelete {
+		return client.NewErrorResponse(fmt.Errorf("delete operations are disabled")), nil
+	}
+	if failed := m.failure("DeleteObject"); failed != nil {
+		return failed, nil
+	}
+	m.mu.Lock()
+	m.DeletedObjects = append(m.DeletedObjects, objectID)
+	m.mu.Unlock()
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object_id": objectID,
+		"message":   fmt.Sprintf("Successfully deleted object %s", objectID),
+	}), nil
+}
+
+func (m *MockClient) GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	if failed := m.failure("GetObjectTypeAttributes"); failed != nil {
+		return failed, nil
+	}
+
+	attributes, ok := m.Attributes[objectTypeID]
+	if !ok {
+		return client.NewErrorResponse(fmt.Errorf("API error: 404 - object type %s not found", objectTypeID)), nil
+	}
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object_type_id": objectTypeID,
+		"attributes":     attributes,
+		"count":          len(attributes),
+	}), nil
+}
+
+func (m *MockClient) ListSchemas(ctx context.Context) (*client.Response, error) {
+	if failed := m.failure("ListSchemas"); failed != nil {
+		return failed, nil
+	}
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"schemas": m.Schemas,
+		"total":   len(m.Schemas),
+	}), nil
+}
+
+func (m *MockClient) CreateSchema(ctx context.Context, name, description string) (*client.Response, error) {
+	if failed := m.failure("CreateSchema"); failed != nil {
+		return failed, nil
+	}
+
+	schema := &models.ObjectSchemaScheme{
+		ID:          fmt.Sprintf("%d", 100+len(m.Schemas)),
+		Name:        name,
+		Description: description,
+	}
+	m.Schemas = append(m.Schemas, schema)
+
+	return client.NewSuccessResponse(schema), nil
+}
+
+func (m *MockClient) GetSchema(ctx context.Context, schemaID string) (*client.Response, error) {
+	if failed := m.failure("GetSchema"); failed != nil {
+		return failed, nil
+	}
+
+	for _, schema := range m.Schemas {
+		if schema.ID == schemaID {
+			return client.NewSuccessResponse(schema), nil
+		}
+	}
+	return client.NewErrorResponse(fmt.Errorf("API error: 404 - schema %s not found", schemaID)), nil
+}
+
+func (m *MockClient) GetObjectTypes(ctx context.Context, schemaID string) (*client.Response, error) {
+	if failed := m.failure("GetObjectTypes"); failed != nil {
+		return failed, nil
+	}
+
+	objectTypes := m.ObjectTypes[schemaID]
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object_types": objectTypes,
+		"schema":       schemaID,
+		"count":        len(objectTypes),
+	}), nil
+}
+
+func (m *MockClient) GetObjectType(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	if failed := m.failure("GetObjectType"); failed != nil {
+		return failed, nil
+	}
+
+	if objectType := m.findObjectType(objectTypeID); objectType != nil {
+		return client.NewSuccessResponse(objectType), nil
+	}
+	return client.NewErrorResponse(fmt.Errorf("API error: 404 - object type %s not found", objectTypeID)), nil
+}
+
+func (m *MockClient) UpdateObjectType(ctx context.Context, objectTypeID string, payload *models.ObjectTypePayloadScheme) (*client.Response, error) {
+	if failed := m.failure("UpdateObjectType"); failed != nil {
+		return failed, nil
+	}
+
+	objectType := m.findObjectType(objectTypeID)
+	if objectType == nil {
+		return client.NewErrorResponse(fmt.Errorf("API error: 404 - object type %s not found", objectTypeID)), nil
+	}
+	objectType.Name = payload.Name
+	objectType.Description = payload.Description
+	objectType.Icon = &models.IconScheme{ID: payload.IconID}
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object_type": objectType,
+		"message":     fmt.Sprintf("Successfully updated object type %s", objectTypeID),
+	}), nil
+}
+
+func (m *MockClient) ListIcons(ctx context.Context) (*client.Response, error) {
+	if failed := m.failure("ListIcons"); failed != nil {
+		return failed, nil
+	}
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"icons": m.Icons,
+		"total": len(m.Icons),
+	}), nil
+}
+
+func (m *MockClient) DeleteObjectType(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	if !m.AllowDelete {
+		return client.NewErrorResponse(fmt.Errorf("delete operations are disabled")), nil
+	}
+	if failed := m.failure("DeleteObjectType"); failed != nil {
+		return failed, nil
+	}
+	m.DeletedObjectTypes = append(m.DeletedObjectTypes, objectTypeID)
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object_type_id": objectTypeID,
+		"message":        fmt.Sprintf("Successfully deleted object type %s", objectTypeID),
+	}), nil
+}
+
+func (m *MockClient) CreateObjectTypeAttribute(ctx context.Context, objectTypeID string, payload *models.ObjectTypeAttributePayloadScheme) (*client.Response, error) {
+	if failed := m.failure("CreateObjectTypeAttribute"); failed != nil {
+		return failed, nil
+	}
+	m.CreatedAttributes[objectTypeID] = append(m.CreatedAttributes[objectTypeID], payload)
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"attribute":      &models.ObjectTypeAttributeScheme{Name: payload.Name},
+		"object_type_id": objectTypeID,
+		"message":        fmt.Sprintf("Successfully created attribute '%s' on object type %s", payload.Name, objectTypeID),
+	}), nil
+}
+
+func (m *MockClient) RemoveAttribute(ctx context.Context, objectTypeID, attributeID string) (*client.Response, error) {
+	if failed := m.failure("RemoveAttribute"); failed != nil {
+		return failed, nil
+	}
+	m.RemovedAttributes = append(m.RemovedAttributes, attributeID)
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object_type_id": objectTypeID,
+		"attribute_id":   attributeID,
+		"message":        fmt.Sprintf("Successfully removed attribute %s from object type %s", attributeID, objectTypeID),
+	}), nil
+}
+
+func (m *MockClient) RemoveRelationship(ctx context.Context, objectID, relationshipID string) (*client.Response, error) {
+	return client.NewErrorResponse(fmt.Errorf("remove relationship not yet implemented")), nil
+}
+
+func (m *MockClient) RemoveRelationshipByType(ctx context.Context, objectID, relationshipType, targetID string) (*client.Response, error) {
+	return client.NewErrorResponse(fmt.Errorf("remove relationship by type not yet implemented")), nil
+}
+
+func (m *MockClient) RemoveProperty(ctx context.Context, objectID, propertyID string) (*client.Response, error) {
+	return client.NewErrorResponse(fmt.Errorf("remove property not yet implemented")), nil
+}
+
+func (m *MockClient) RemovePropertyByName(ctx context.Context, objectID, propertyName string) (*client.Response, error) {
+	return client.NewErrorResponse(fmt.Errorf("remove property by name not yet implemented")), nil
+}
+
+func (m *MockClient) GetWorkspaceID() string {
+	return "mock-workspace"
+}
+
+func (m *MockClient) GetConfig() *config.Config {
+	return m.Config
+}
+
+func (m *MockClient) IsDeleteAllowed() bool {
+	return m.AllowDelete
+}
+
+func (m *MockClient) TestConnection(ctx context.Context) error {
+	return nil
+}
+
+func (m *MockClient) Close() error {
+	return nil
+}
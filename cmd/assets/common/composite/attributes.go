@@ -0,0 +1,784 @@
+package composite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+)
+
+// CopyAttributes copies non-system attributes from one object type to another
+func CopyAttributes(client common.ClientInterface, params common.CopyAttributesParams) (*common.Response, error) {
+	// Validate parameters
+	if params.From == "" || params.To == "" {
+		return common.NewErrorResponse(fmt.Errorf("source and destination object type IDs are required")), nil
+	}
+
+	sourceAttributes, err := foundation.ListAttributes(client, params.From)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to get source attributes: %w", err)), nil
+	}
+
+	destAttributes, err := foundation.ListAttributes(client, params.To)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to get destination attributes: %w", err)), nil
+	}
+
+	// Build map of existing destination attribute names
+	existingNames := make(map[string]bool)
+	for _, attr := range destAttributes {
+		existingNames[attr.Name] = true
+	}
+
+	// Plan which attributes to copy
+	var attributesToCopy []*models.ObjectTypeAttributeScheme
+	var skippedAttributes []string
+
+	for _, attr := range sourceAttributes {
+		// Skip system attributes (Created, Updated, Key)
+		if attr.System {
+			continue
+		}
+
+		if existingNames[attr.Name] && params.SkipExisting {
+			skippedAttributes = append(skippedAttributes, attr.Name)
+			continue
+		}
+
+		attributesToCopy = append(attributesToCopy, attr)
+	}
+
+	result := map[string]interface{}{
+		"action":            "copy_attributes",
+		"source_type":       params.From,
+		"destination_type":  params.To,
+		"source_count":      len(sourceAttributes),
+		"destination_count": len(destAttributes),
+		"planned_copies":    len(attributesToCopy),
+		"skipped_existing":  len(skippedAttributes),
+		"dry_run":           params.DryRun,
+	}
+
+	if len(skippedAttributes) > 0 {
+		result["skipped_attributes"] = skippedAttributes
+	}
+
+	if params.DryRun {
+		result["status"] = "dry_run_complete"
+		result["message"] = fmt.Sprintf("Would copy %d attributes from type %s to type %s", len(attributesToCopy), params.From, params.To)
+
+		if len(attributesToCopy) > 0 {
+			copyList := make([]string, 0, len(attributesToCopy))
+			for _, attr := range attributesToCopy {
+				copyList = append(copyList, attr.Name)
+			}
+			result["attributes_to_copy"] = copyList
+		}
+
+		result["workflow_context"] = map[string]interface{}{
+			"current_state":         "copy_planned",
+			"completion_percentage": 40,
+			"suggested_next_steps": []string{
+				"execute_copy",
+				"review_skipped_attributes",
+			},
+		}
+
+		return common.NewSuccessResponse(result), nil
+	}
+
+	// Actual copying
+	var createdAttributes []string
+	var failedAttributes []map[string]interface{}
+
+	for _, sourceAttr := range attributesToCopy {
+		response, err := foundation.CreateObjectTypeAttribute(client, params.To, copyAttributePayload(sourceAttr))
+		if err != nil {
+			failedAttributes = append(failedAttributes, map[string]interface{}{
+				"attribute": sourceAttr.Name,
+				"error":     err.Error(),
+			})
+			continue
+		}
+
+		if response.Success {
+			createdAttributes = append(createdAttributes, sourceAttr.Name)
+		} else {
+			failedAttributes = append(failedAttributes, map[string]interface{}{
+				"attribute": sourceAttr.Name,
+				"error":     response.Error,
+			})
+		}
+	}
+
+	result["status"] = "completed"
+	result["created_count"] = len(createdAttributes)
+	result["failed_count"] = len(failedAttributes)
+	result["message"] = fmt.Sprintf("Successfully copied %d attributes from type %s to type %s", len(createdAttributes), params.From, params.To)
+
+	if len(createdAttributes) > 0 {
+		result["created_attributes"] = createdAttributes
+	}
+	if len(failedAttributes) > 0 {
+		result["failed_attributes"] = failedAttributes
+	}
+
+	result["workflow_context"] = map[string]interface{}{
+		"current_state":         "attributes_copied",
+		"completion_percentage": 80,
+		"suggested_next_steps": []string{
+			"verify_destination_attributes",
+			"create_object_instance",
+		},
+	}
+
+	return common.NewSuccessResponse(result), nil
+}
+
+// CopyObjectType creates a new object type with the icon and attributes of an existing
+// one, either beside it under the same parent or as its child
+func CopyObjectType(client common.ClientInterface, params common.CopyObjectTypeParams) (*common.Response, error) {
+	// Validate parameters
+	if params.SourceID == "" {
+		return common.NewErrorResponse(fmt.Errorf("source object type ID is required")), nil
+	}
+	if params.Name == "" {
+		return common.NewErrorResponse(fmt.Errorf("name is required")), nil
+	}
+
+	response, err := foundation.GetObjectType(client, params.SourceID)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return common.NewErrorResponse(fmt.Errorf("failed to get source object type: %s", response.Error)), nil
+	}
+	source, ok := response.Data.(map[string]interface{})["object_type"].(*models.ObjectTypeScheme)
+	if !ok {
+		return common.NewErrorResponse(fmt.Errorf("unexpected object type response type: %T", response.Data)), nil
+	}
+
+	parentID := source.ParentObjectTypeID
+	if params.AsChild {
+		parentID = source.ID
+	}
+	description := params.Description
+	if description == "" {
+		description = source.Description
+	}
+	iconID := ""
+	if source.Icon != nil {
+		iconID = source.Icon.ID
+	}
+
+	result := map[string]interface{}{
+		"action":      "copy_object_type",
+		"source_type": source.ID,
+		"source_name": source.Name,
+		"name":        params.Name,
+		"schema_id":   source.ObjectSchemaID,
+		"parent_id":   parentID,
+		"as_child":    params.AsChild,
+		"icon_id":     iconID,
+		"dry_run":     params.DryRun,
+	}
+
+	if params.DryRun {
+		sourceAttributes, err := foundation.ListAttributes(client, source.ID)
+		if err != nil {
+			return common.NewErrorResponse(fmt.Errorf("failed to get source attributes: %w", err)), nil
+		}
+		var names []string
+		for _, attr := range sourceAttributes {
+			if !attr.System {
+				names = append(names, attr.Name)
+			}
+		}
+		result["attributes_to_copy"] = names
+		result["message"] = fmt.Sprintf("Would create object type '%s' and copy %d attributes from '%s'", params.Name, len(names), source.Name)
+		return common.NewSuccessResponse(result), nil
+	}
+
+	var parent *string
+	if parentID != "" {
+		parent = &parentID
+	}
+	response, err = foundation.CreateObjectType(client, common.CreateObjectTypeParams{
+		Schema:      source.ObjectSchemaID,
+		Name:        params.Name,
+		Description: description,
+		Parent:      parent,
+		Icon:        iconID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return common.NewErrorResponse(fmt.Errorf("failed to create object type: %s", response.Error)), nil
+	}
+	created, ok := response.Data.(map[string]interface{})["object_type"].(*models.ObjectTypeScheme)
+	if !ok {
+		return common.NewErrorResponse(fmt.Errorf("unexpected object type response type: %T", response.Data)), nil
+	}
+	result["object_type"] = created
+	result["object_type_id"] = created.ID
+
+	// Attributes a child inherits from its parent already exist and are skipped
+	copied, err := CopyAttributes(client, common.CopyAttributesParams{From: source.ID, To: created.ID, SkipExisting: true})
+	if err != nil {
+		return nil, err
+	}
+	if !copied.Success {
+		result["attribute_error"] = copied.Error
+		result["success"] = false
+		result["message"] = fmt.Sprintf("Created object type '%s' but could not copy its attributes: %s", params.Name, copied.Error)
+		return common.NewSuccessResponse(result), nil
+	}
+
+	attributes := copied.Data.(map[string]interface{})
+	for _, key := range []string{"created_count", "failed_count", "created_attributes", "failed_attributes", "skipped_attributes"} {
+		if value, ok := attributes[key]; ok {
+			result[key] = value
+		}
+	}
+	failed, _ := attributes["failed_count"].(int)
+	result["success"] = failed == 0
+	result["message"] = fmt.Sprintf("Created object type '%s' (%s) with %v attributes copied from '%s'", params.Name, created.ID, attributes["created_count"], source.Name)
+
+	return common.NewSuccessResponse(result), nil
+}
+
+// copyAttributePayload converts a source attribute into a creation payload for another object type
+func copyAttributePayload(sourceAttr *models.ObjectTypeAttributeScheme) *models.ObjectTypeAttributePayloadScheme {
+	minCardinality := sourceAttr.MinimumCardinality
+	maxCardinality := sourceAttr.MaximumCardinality
+
+	payload := &models.ObjectTypeAttributePayloadScheme{
+		Name:               sourceAttr.Name,
+		Description:        sourceAttr.Description,
+		MinimumCardinality: &minCardinality,
+		MaximumCardinality: &maxCardinality,
+		Summable:           sourceAttr.Summable,
+		UniqueAttribute:    sourceAttr.UniqueAttribute,
+	}
+
+	// Handle reference attributes (Type 1 = Reference)
+	if sourceAttr.Type == 1 && sourceAttr.ReferenceObjectTypeID != "" {
+		attrType := sourceAttr.Type
+		payload.Type = &attrType
+		payload.TypeValue = sourceAttr.ReferenceObjectTypeID
+	}
+
+	// Map the default type - this is critical for attribute creation
+	if sourceAttr.DefaultType != nil {
+		if sourceAttr.DefaultType.ID != 0 {
+			defaultTypeID := sourceAttr.DefaultType.ID
+			payload.DefaultTypeID = &defaultTypeID
+		} else if defaultTypeID, ok := foundation.DefaultTypeIDByName(sourceAttr.DefaultType.Name); ok {
+			payload.DefaultTypeID = &defaultTypeID
+		}
+	}
+
+	return payload
+}
+
+// ExtractAttributes extracts attributes with values from an object instance, or the
+// attribute schema from an object type, as a portable attribute set
+func ExtractAttributes(client common.ClientInterface, params common.ExtractAttributesParams) (*common.Response, error) {
+	if params.ObjectID == "" && params.ObjectTypeID == "" {
+		return common.NewErrorResponse(fmt.Errorf("either object ID or object type ID is required")), nil
+	}
+
+	var result map[string]interface{}
+	var err error
+	if params.ObjectID != "" {
+		result, err = extractFromObjectInstance(client, params)
+	} else {
+		result, err = extractFromObjectTypeSchema(client, params)
+	}
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+
+	result["extraction_config"] = map[string]interface{}{
+		"resolve_references": params.ResolveReferences,
+		"include_system":     params.IncludeSystem,
+	}
+	result["workflow_context"] = map[string]interface{}{
+		"current_state":         "attributes_extracted",
+		"completion_percentage": 50,
+		"suggested_next_steps": []string{
+			"apply_attributes",
+			"trace_dependencies",
+		},
+	}
+
+	return common.NewSuccessResponse(result), nil
+}
+
+// extractFromObjectInstance extracts attributes with values from an object instance
+func extractFromObjectInstance(client common.ClientInterface, params common.ExtractAttributesParams) (map[string]interface{}, error) {
+	response, err := foundation.GetObject(client, common.GetParams{ID: params.ObjectID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("failed to get object: %s", response.Error)
+	}
+
+	objectData, ok := response.Data.(*models.ObjectScheme)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object response type: %T", response.Data)
+	}
+
+	objectInfo := map[string]interface{}{
+		"object_id":  objectData.ID,
+		"object_key": objectData.ObjectKey,
+		"label":      objectData.Label,
+		"created":    objectData.Created,
+		"updated":    objectData.Updated,
+	}
+	if objectData.ObjectType != nil {
+		objectInfo["object_type"] = map[string]interface{}{
+			"id":   objectData.ObjectType.ID,
+			"name": objectData.ObjectType.Name,
+		}
+	}
+
+	var extractedAttributes []map[string]interface{}
+	for _, attr := range objectData.Attributes {
+		if attr.ObjectTypeAttribute == nil {
+			continue
+		}
+
+		// Skip system attributes unless requested
+		if !params.IncludeSystem && attr.ObjectTypeAttribute.System {
+			continue
+		}
+
+		attrInfo := extractAttributeFromInstance(attr)
+
+		if params.ResolveReferences && attr.ObjectTypeAttribute.Type == 1 && attr.ObjectTypeAttribute.ReferenceObjectTypeID != "" {
+			attrInfo["resolved_reference"] = DescribeObjectType(client, attr.ObjectTypeAttribute.ReferenceObjectTypeID)
+		}
+
+		extractedAttributes = append(extractedAttributes, attrInfo)
+	}
+
+	return map[string]interface{}{
+		"action":          "extract_attributes",
+		"source_type":     "object_instance",
+		"source_object":   objectInfo,
+		"attribute_count": len(extractedAttributes),
+		"attributes":      extractedAttributes,
+	}, nil
+}
+
+// extractFromObjectTypeSchema extracts the attribute schema from an object type
+func extractFromObjectTypeSchema(client common.ClientInterface, params common.ExtractAttributesParams) (map[string]interface{}, error) {
+	attributes, err := foundation.ListAttributes(client, params.ObjectTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object type attributes: %w", err)
+	}
+
+	var extractedAttributes []map[string]interface{}
+	for _, attr := range attributes {
+		// Skip system attributes unless requested
+		if !params.IncludeSystem && attr.System {
+			continue
+		}
+
+		attrInfo := extractAttributeFromSchema(attr)
+
+		if params.ResolveReferences && attr.Type == 1 && attr.ReferenceObjectTypeID != "" {
+			attrInfo["resolved_reference"] = DescribeObjectType(client, attr.ReferenceObjectTypeID)
+		}
+
+		extractedAttributes = append(extractedAttributes, attrInfo)
+	}
+
+	return map[string]interface{}{
+		"action":             "extract_attributes",
+		"source_type":        "object_type_schema",
+		"source_object_type": params.ObjectTypeID,
+		"attribute_count":    len(extractedAttributes),
+		"attributes":         extractedAttributes,
+	}, nil
+}
+
+// extractAttributeFromInstance extracts attribute info from an object instance attribute
+func extractAttributeFromInstance(attr *models.ObjectAttributeScheme) map[string]interface{} {
+	typeAttr := attr.ObjectTypeAttribute
+	attrInfo := map[string]interface{}{
+		"id":             attr.ID,
+		"name":           typeAttr.Name,
+		"system":         typeAttr.System,
+		"editable":       typeAttr.Editable,
+		"required":       typeAttr.MinimumCardinality > 0,
+		"attribute_type": typeAttr.Type,
+	}
+
+	if typeAttr.DefaultType != nil {
+		attrInfo["data_type"] = typeAttr.DefaultType.Name
+		if typeAttr.DefaultType.ID != 0 {
+			attrInfo["data_type_id"] = typeAttr.DefaultType.ID
+		}
+	}
+
+	if typeAttr.Type == 1 {
+		attrInfo["is_reference"] = true
+		if typeAttr.ReferenceObjectTypeID != "" {
+			attrInfo["reference_object_type_id"] = typeAttr.ReferenceObjectTypeID
+		}
+	}
+
+	if len(attr.ObjectAttributeValues) > 0 {
+		firstValue := attr.ObjectAttributeValues[0]
+		attrInfo["value"] = firstValue.Value
+		attrInfo["display_value"] = firstValue.DisplayValue
+
+		if len(attr.ObjectAttributeValues) > 1 {
+			allValues := make([]string, 0, len(attr.ObjectAttributeValues))
+			for _, val := range attr.ObjectAttributeValues {
+				allValues = append(allValues, val.Value)
+			}
+			attrInfo["all_values"] = allValues
+			attrInfo["multiple_values"] = true
+		}
+	}
+
+	return attrInfo
+}
+
+// extractAttributeFromSchema extracts attribute info from an object type attribute definition
+func extractAttributeFromSchema(attr *models.ObjectTypeAttributeScheme) map[string]interface{} {
+	attrInfo := map[string]interface{}{
+		"id":                  attr.ID,
+		"name":                attr.Name,
+		"description":         attr.Description,
+		"system":              attr.System,
+		"editable":            attr.Editable,
+		"required":            attr.MinimumCardinality > 0,
+		"minimum_cardinality": attr.MinimumCardinality,
+		"maximum_cardinality": attr.MaximumCardinality,
+		"attribute_type":      attr.Type,
+		"summable":            attr.Summable,
+		"unique":              attr.UniqueAttribute,
+	}
+
+	if attr.DefaultType != nil {
+		attrInfo["data_type"] = attr.DefaultType.Name
+		if attr.DefaultType.ID != 0 {
+			attrInfo["data_type_id"] = attr.DefaultType.ID
+		}
+	}
+
+	if attr.Type == 1 && attr.ReferenceObjectTypeID != "" {
+		attrInfo["is_reference"] = true
+		attrInfo["reference_object_type_id"] = attr.ReferenceObjectTypeID
+		if attr.ReferenceType != nil {
+			attrInfo["reference_type"] = attr.ReferenceType.Name
+		}
+	}
+
+	return attrInfo
+}
+
+// ApplyAttributes applies a set of extracted attributes to a target object type
+func ApplyAttributes(client common.ClientInterface, params common.ApplyAttributesParams) (*common.Response, error) {
+	// Validate parameters
+	if params.ObjectTypeID == "" {
+		return common.NewErrorResponse(fmt.Errorf("target object type ID is required")), nil
+	}
+	if len(params.Attributes) == 0 {
+		return common.NewErrorResponse(fmt.Errorf("at least one attribute is required")), nil
+	}
+
+	existingAttributes, err := foundation.ListAttributes(client, params.ObjectTypeID)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to get target object type attributes: %w", err)), nil
+	}
+
+	existingNames := make(map[string]bool)
+	for _, attr := range existingAttributes {
+		existingNames[attr.Name] = true
+	}
+
+	var selectedNames map[string]bool
+	if len(params.Select) > 0 {
+		selectedNames = make(map[string]bool)
+		for _, name := range params.Select {
+			selectedNames[strings.TrimSpace(name)] = true
+		}
+	}
+
+	var attributesToApply []map[string]interface{}
+	var skippedAttributes []map[string]interface{}
+	var conflictingAttributes []map[string]interface{}
+
+	for _, attr := range params.Attributes {
+		attrName, _ := attr["name"].(string)
+		if attrName == "" {
+			skippedAttributes = append(skippedAttributes, map[string]interface{}{
+				"name":   attrName,
+				"reason": "missing_name",
+			})
+			continue
+		}
+
+		if selectedNames != nil && !selectedNames[attrName] {
+			skippedAttributes = append(skippedAttributes, map[string]interface{}{
+				"name":   attrName,
+				"reason": "not_selected",
+			})
+			continue
+		}
+
+		if params.SkipReferences && isExtractedReference(attr) {
+			skippedAttributes = append(skippedAttributes, map[string]interface{}{
+				"name":   attrName,
+				"reason": "reference_skipped",
+			})
+			continue
+		}
+
+		if existingNames[attrName] && !params.ForceOverwrite {
+			conflictingAttributes = append(conflictingAttributes, map[string]interface{}{
+				"name":   attrName,
+				"reason": "already_exists",
+			})
+			continue
+		}
+
+		preparedAttr, err := prepareAttributeForApplication(attr, params.ReferenceMappings)
+		if err != nil {
+			skippedAttributes = append(skippedAttributes, map[string]interface{}{
+				"name":   attrName,
+				"reason": "preparation_failed",
+				"error":  err.Error(),
+			})
+			continue
+		}
+
+		attributesToApply = append(attributesToApply, preparedAttr)
+	}
+
+	result := map[string]interface{}{
+		"action":                 "apply_attributes",
+		"target_object_type":     params.ObjectTypeID,
+		"source_attribute_count": len(params.Attributes),
+		"planned_applications":   len(attributesToApply),
+		"skipped_count":          len(skippedAttributes),
+		"conflict_count":         len(conflictingAttributes),
+		"dry_run":                params.DryRun,
+	}
+
+	if len(skippedAttributes) > 0 {
+		result["skipped_attributes"] = skippedAttributes
+	}
+	if len(conflictingAttributes) > 0 {
+		result["conflicting_attributes"] = conflictingAttributes
+	}
+
+	if params.DryRun {
+		result["status"] = "dry_run_complete"
+		result["message"] = fmt.Sprintf("Would apply %d attributes to object type %s", len(attributesToApply), params.ObjectTypeID)
+
+		if len(attributesToApply) > 0 {
+			applyList := make([]string, 0, len(attributesToApply))
+			for _, attr := range attributesToApply {
+				applyList = append(applyList, attr["name"].(string))
+			}
+			result["attributes_to_apply"] = applyList
+		}
+
+		result["workflow_context"] = map[string]interface{}{
+			"current_state":         "application_planned",
+			"completion_percentage": 60,
+			"suggested_next_steps": []string{
+				"execute_application",
+				"resolve_conflicts",
+			},
+		}
+
+		return common.NewSuccessResponse(result), nil
+	}
+
+	var createdAttributes []string
+	var failedAttributes []map[string]interface{}
+
+	for _, attr := range attributesToApply {
+		payload := convertToAttributePayload(attr)
+
+		response, err := foundation.CreateObjectTypeAttribute(client, params.ObjectTypeID, payload)
+		if err != nil {
+			failedAttributes = append(failedAttributes, map[string]interface{}{
+				"attribute": attr["name"],
+				"error":     err.Error(),
+			})
+			continue
+		}
+
+		if response.Success {
+			createdAttributes = append(createdAttributes, payload.Name)
+		} else {
+			failedAttributes = append(failedAttributes, map[string]interface{}{
+				"attribute": attr["name"],
+				"error":     response.Error,
+			})
+		}
+	}
+
+	result["status"] = "completed"
+	result["created_count"] = len(createdAttributes)
+	result["failed_count"] = len(failedAttributes)
+	result["message"] = fmt.Sprintf("Successfully applied %d attributes to object type %s", len(createdAttributes), params.ObjectTypeID)
+
+	if len(createdAttributes) > 0 {
+		result["created_attributes"] = createdAttributes
+	}
+	if len(failedAttributes) > 0 {
+		result["failed_attributes"] = failedAttributes
+	}
+
+	result["workflow_context"] = map[string]interface{}{
+		"current_state":         "attributes_applied",
+		"completion_percentage": 90,
+		"suggested_next_steps": []string{
+			"verify_target_attributes",
+			"create_object_instance",
+		},
+	}
+
+	return common.NewSuccessResponse(result), nil
+}
+
+// ExtractedAttributesFromDocument pulls the attribute list out of a saved extract
+// response ({"success":..., "data":{"attributes":[...]}}) or a bare attribute array
+func ExtractedAttributesFromDocument(document interface{}) ([]map[string]interface{}, error) {
+	var rawAttributes []interface{}
+
+	switch doc := document.(type) {
+	case []interface{}:
+		rawAttributes = doc
+	case map[string]interface{}:
+		section := doc
+		if data, ok := doc["data"].(map[string]interface{}); ok {
+			section = data
+		}
+		attrs, ok := section["attributes"].([]interface{})
+		if !ok {
+			return nil, errors.New("invalid attributes document: missing attributes array")
+		}
+		rawAttributes = attrs
+	default:
+		return nil, fmt.Errorf("invalid attributes document type: %T", document)
+	}
+
+	attributes := make([]map[string]interface{}, 0, len(rawAttributes))
+	for i, raw := range rawAttributes {
+		attr, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid attribute at index %d: expected object, got %T", i, raw)
+		}
+		attributes = append(attributes, attr)
+	}
+
+	return attributes, nil
+}
+
+// isExtractedReference checks if an extracted attribute is a reference
+func isExtractedReference(attr map[string]interface{}) bool {
+	if isRef, ok := attr["is_reference"].(bool); ok {
+		return isRef
+	}
+	if attrType, ok := toInt(attr["attribute_type"]); ok {
+		return attrType == 1
+	}
+	return false
+}
+
+// prepareAttributeForApplication maps reference targets for an extracted attribute
+func prepareAttributeForApplication(attr map[string]interface{}, referenceMappings map[string]string) (map[string]interface{}, error) {
+	prepared := make(map[string]interface{}, len(attr))
+	for key, value := range attr {
+		prepared[key] = value
+	}
+
+	if !isExtractedReference(attr) {
+		return prepared, nil
+	}
+
+	if referenceMappings == nil {
+		return nil, fmt.Errorf("reference attribute requires mapping but no mappings provided")
+	}
+
+	if oldRefID, ok := attr["reference_object_type_id"].(string); ok {
+		newRefID, mapped := referenceMappings[oldRefID]
+		if !mapped {
+			return nil, fmt.Errorf("no mapping found for reference object type %s", oldRefID)
+		}
+		prepared["reference_object_type_id"] = newRefID
+		prepared["reference_mapped"] = true
+	}
+
+	return prepared, nil
+}
+
+// convertToAttributePayload converts a prepared attribute to a creation payload
+func convertToAttributePayload(attr map[string]interface{}) *models.ObjectTypeAttributePayloadScheme {
+	payload := &models.ObjectTypeAttributePayloadScheme{
+		Name: attr["name"].(string),
+	}
+
+	if description, ok := attr["description"].(string); ok {
+		payload.Description = description
+	}
+
+	if minCard, ok := toInt(attr["minimum_cardinality"]); ok {
+		payload.MinimumCardinality = &minCard
+	}
+	if maxCard, ok := toInt(attr["maximum_cardinality"]); ok {
+		payload.MaximumCardinality = &maxCard
+	}
+
+	if dataTypeID, ok := toInt(attr["data_type_id"]); ok {
+		payload.DefaultTypeID = &dataTypeID
+	} else if dataType, ok := attr["data_type"].(string); ok {
+		if defaultTypeID, ok := foundation.DefaultTypeIDByName(dataType); ok {
+			payload.DefaultTypeID = &defaultTypeID
+		}
+	}
+
+	if isExtractedReference(attr) {
+		refType := 1
+		payload.Type = &refType
+		if refObjTypeID, ok := attr["reference_object_type_id"].(string); ok {
+			payload.TypeValue = refObjTypeID
+		}
+	}
+
+	if summable, ok := attr["summable"].(bool); ok {
+		payload.Summable = summable
+	}
+	if unique, ok := attr["unique"].(bool); ok {
+		payload.UniqueAttribute = unique
+	}
+
+	return payload
+}
+
+// toInt converts JSON-decoded or native numeric values to int
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,345 @@
+package composite
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/commontest"
+)
+
+// newLaptopClient builds a workspace with a populated Laptops type and a sparse Workstations type
+func newLaptopClient(t *testing.T) *commontest.MockClient {
+	client := commontest.NewMockClient()
+	client.Config.CacheDir = t.TempDir()
+	client.Schemas = []*models.ObjectSchemaScheme{{ID: "7", Name: "IT"}}
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "65", Name: "Laptops", ObjectSchemaID: "7"},
+		&models.ObjectTypeAttributeScheme{ID: "700", Name: "Key", System: true},
+		&models.ObjectTypeAttributeScheme{ID: "701", Name: "Name", DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: 0, Name: "Text"}},
+		&models.ObjectTypeAttributeScheme{ID: "702", Name: "CPU", DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: 0, Name: "Text"}},
+		&models.ObjectTypeAttributeScheme{ID: "703", Name: "Manufacturer", Type: 1, ReferenceObjectTypeID: "80"},
+	)
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "141", Name: "Workstations", ObjectSchemaID: "7"},
+		&models.ObjectTypeAttributeScheme{ID: "900", Name: "Name"},
+	)
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "80", Name: "Vendors", ObjectSchemaID: "7"},
+		&models.ObjectTypeAttributeScheme{ID: "801", Name: "Name"},
+	)
+	return client
+}
+
+func TestCopyAttributes(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      common.CopyAttributesParams
+		failures    map[string]string
+		wantError   string
+		wantCreated []string
+		wantSkipped int
+		wantFailed  int
+	}{
+		{
+			name:        "copies non-system attributes and skips existing",
+			params:      common.CopyAttributesParams{From: "65", To: "141", SkipExisting: true},
+			wantCreated: []string{"CPU", "Manufacturer"},
+			wantSkipped: 1,
+		},
+		{
+			name:        "copies existing names when not skipping",
+			params:      common.CopyAttributesParams{From: "65", To: "141"},
+			wantCreated: []string{"Name", "CPU", "Manufacturer"},
+		},
+		{
+			name:        "dry run creates nothing",
+			params:      common.CopyAttributesParams{From: "65", To: "141", SkipExisting: true, DryRun: true},
+			wantSkipped: 1,
+		},
+		{
+			name:      "reports a missing source",
+			params:    common.CopyAttributesParams{From: "404", To: "141"},
+			wantError: "failed to get source attributes",
+		},
+		{
+			name:      "requires both object types",
+			params:    common.CopyAttributesParams{From: "65"},
+			wantError: "source and destination object type IDs are required",
+		},
+		{
+			name:        "records creation failures",
+			params:      common.CopyAttributesParams{From: "65", To: "141", SkipExisting: true},
+			failures:    map[string]string{"CreateObjectTypeAttribute": "API error: 400"},
+			wantSkipped: 1,
+			wantFailed:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newLaptopClient(t)
+			if tt.failures != nil {
+				client.Failures = tt.failures
+			}
+
+			response, err := CopyAttributes(client, tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				return
+			}
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+
+			var created []string
+			for _, payload := range client.CreatedAttributes[tt.params.To] {
+				created = append(created, payload.Name)
+			}
+			if !reflect.DeepEqual(created, tt.wantCreated) {
+				t.Errorf("created %v, want %v", created, tt.wantCreated)
+			}
+
+			data := response.Data.(map[string]interface{})
+			if data["skipped_existing"] != tt.wantSkipped {
+				t.Errorf("skipped %v, want %d", data["skipped_existing"], tt.wantSkipped)
+			}
+			if !tt.params.DryRun && data["failed_count"] != tt.wantFailed {
+				t.Errorf("failed %v, want %d", data["failed_count"], tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestCopyAttributesPreservesReferences(t *testing.T) {
+	client := newLaptopClient(t)
+
+	response, _ := CopyAttributes(client, common.CopyAttributesParams{From: "65", To: "141", SkipExisting: true})
+	if !response.Success {
+		t.Fatalf("expected success, got error %q", response.Error)
+	}
+
+	for _, payload := range client.CreatedAttributes["141"] {
+		if payload.Name != "Manufacturer" {
+			continue
+		}
+		if payload.Type == nil || *payload.Type != 1 || payload.TypeValue != "80" {
+			t.Errorf("reference target not preserved: type=%v value=%q", payload.Type, payload.TypeValue)
+		}
+		return
+	}
+	t.Fatal("Manufacturer was not copied")
+}
+
+func TestCopyObjectType(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      common.CopyObjectTypeParams
+		wantError   string
+		wantParent  string
+		wantCreated []string
+	}{
+		{
+			name:        "creates a child with the source attributes",
+			params:      common.CopyObjectTypeParams{SourceID: "65", Name: "Gaming Laptops", AsChild: true},
+			wantParent:  "65",
+			wantCreated: []string{"Name", "CPU", "Manufacturer"},
+		},
+		{
+			name:        "creates a sibling under the source's parent",
+			params:      common.CopyObjectTypeParams{SourceID: "65", Name: "Tablets"},
+			wantParent:  "60",
+			wantCreated: []string{"Name", "CPU", "Manufacturer"},
+		},
+		{
+			name:   "dry run creates nothing",
+			params: common.CopyObjectTypeParams{SourceID: "65", Name: "Tablets", DryRun: true},
+		},
+		{
+			name:      "requires a name",
+			params:    common.CopyObjectTypeParams{SourceID: "65"},
+			wantError: "name is required",
+		},
+		{
+			name:      "reports a missing source",
+			params:    common.CopyObjectTypeParams{SourceID: "404", Name: "Tablets"},
+			wantError: "failed to get source object type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newLaptopClient(t)
+			laptops := client.ObjectTypes["7"][0]
+			laptops.ParentObjectTypeID = "60"
+			laptops.Icon = &models.IconScheme{ID: "12", Name: "Laptop"}
+
+			response, err := CopyObjectType(client, tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				return
+			}
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+
+			data := response.Data.(map[string]interface{})
+			if tt.params.DryRun {
+				if len(client.ObjectTypes["7"]) != 3 {
+					t.Errorf("dry run created an object type")
+				}
+				if got := data["attributes_to_copy"]; !reflect.DeepEqual(got, []string{"Name", "CPU", "Manufacturer"}) {
+					t.Errorf("attributes to copy = %v", got)
+				}
+				return
+			}
+
+			created := data["object_type"].(*models.ObjectTypeScheme)
+			if created.Name != tt.params.Name || created.ParentObjectTypeID != tt.wantParent || created.Icon == nil || created.Icon.ID != "12" {
+				t.Errorf("created %+v, want %q under %q with icon 12", created, tt.params.Name, tt.wantParent)
+			}
+			var names []string
+			for _, payload := range client.CreatedAttributes[created.ID] {
+				names = append(names, payload.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantCreated) {
+				t.Errorf("copied %v, want %v", names, tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestApplyAttributes(t *testing.T) {
+	extracted := []map[string]interface{}{
+		{"name": "Name", "data_type": "Text"},
+		{"name": "RAM", "data_type": "Integer", "minimum_cardinality": float64(1)},
+		{"name": "Cost", "data_type_id": float64(3)},
+		{"name": "Manufacturer", "is_reference": true, "reference_object_type_id": "80"},
+	}
+
+	tests := []struct {
+		name          string
+		params        common.ApplyAttributesParams
+		wantError     string
+		wantCreated   []string
+		wantConflicts int
+	}{
+		{
+			name:          "skips conflicts and unmapped references",
+			params:        common.ApplyAttributesParams{ObjectTypeID: "141", Attributes: extracted},
+			wantCreated:   []string{"RAM", "Cost"},
+			wantConflicts: 1,
+		},
+		{
+			name: "maps reference targets",
+			params: common.ApplyAttributesParams{
+				ObjectTypeID:      "141",
+				Attributes:        extracted,
+				ReferenceMappings: map[string]string{"80": "180"},
+			},
+			wantCreated:   []string{"RAM", "Cost", "Manufacturer"},
+			wantConflicts: 1,
+		},
+		{
+			name:        "applies only selected attributes",
+			params:      common.ApplyAttributesParams{ObjectTypeID: "141", Attributes: extracted, Select: []string{" Cost"}},
+			wantCreated: []string{"Cost"},
+		},
+		{
+			name:        "overwrites existing names when forced",
+			params:      common.ApplyAttributesParams{ObjectTypeID: "141", Attributes: extracted, ForceOverwrite: true, SkipReferences: true},
+			wantCreated: []string{"Name", "RAM", "Cost"},
+		},
+		{
+			name:          "dry run creates nothing",
+			params:        common.ApplyAttributesParams{ObjectTypeID: "141", Attributes: extracted, DryRun: true},
+			wantConflicts: 1,
+		},
+		{
+			name:      "requires attributes",
+			params:    common.ApplyAttributesParams{ObjectTypeID: "141"},
+			wantError: "at least one attribute is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newLaptopClient(t)
+
+			response, err := ApplyAttributes(client, tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				return
+			}
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+
+			var created []string
+			for _, payload := range client.CreatedAttributes["141"] {
+				created = append(created, payload.Name)
+				if payload.Name == "Manufacturer" && payload.TypeValue != "180" {
+					t.Errorf("reference not remapped, got target %q", payload.TypeValue)
+				}
+				if payload.Name == "Cost" && (payload.DefaultTypeID == nil || *payload.DefaultTypeID != 3) {
+					t.Errorf("Cost default type not carried over: %v", payload.DefaultTypeID)
+				}
+			}
+			if !reflect.DeepEqual(created, tt.wantCreated) {
+				t.Errorf("created %v, want %v", created, tt.wantCreated)
+			}
+
+			data := response.Data.(map[string]interface{})
+			if data["conflict_count"] != tt.wantConflicts {
+				t.Errorf("conflicts %v, want %d", data["conflict_count"], tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestExtractedAttributesFromDocument(t *testing.T) {
+	attrs := []interface{}{map[string]interface{}{"name": "CPU"}}
+
+	tests := []struct {
+		name      string
+		document  interface{}
+		wantCount int
+		wantErr   bool
+	}{
+		{"bare array", attrs, 1, false},
+		{"attributes section", map[string]interface{}{"attributes": attrs}, 1, false},
+		{"CLI response envelope", map[string]interface{}{"success": true, "data": map[string]interface{}{"attributes": attrs}}, 1, false},
+		{"missing attributes", map[string]interface{}{"data": map[string]interface{}{}}, 0, true},
+		{"non-object attribute", []interface{}{"CPU"}, 0, true},
+		{"scalar document", "CPU", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes, err := ExtractedAttributesFromDocument(tt.document)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(attributes) != tt.wantCount {
+				t.Errorf("got %d attributes, want %d", len(attributes), tt.wantCount)
+			}
+		})
+	}
+}
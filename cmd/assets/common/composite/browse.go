@@ -57,7 +57,7 @@ func BrowseSchema(client common.ClientInterface, params common.BrowseSchemaParam
 		if objects, ok := sampleData["objects"].([]interface{}); ok {
 			sampleObjects = objects
 		}
-		if total, ok := sampleData["total"].(int); ok {
+		if total, ok := foundation.TotalFromData(sampleData); ok {
 			totalObjects = total
 		}
 	}
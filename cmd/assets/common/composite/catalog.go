@@ -0,0 +1,202 @@
+package composite
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+)
+
+// AttributeCatalogEntry represents a cataloged attribute
+type AttributeCatalogEntry struct {
+	AttributeID           string `json:"attribute_id"`
+	Name                  string `json:"name"`
+	Description           string `json:"description"`
+	DataType              string `json:"data_type"`
+	DataTypeID            int    `json:"data_type_id,omitempty"`
+	IsReference           bool   `json:"is_reference"`
+	ReferenceObjectTypeID string `json:"reference_object_type_id,omitempty"`
+	ReferenceType         string `json:"reference_type,omitempty"`
+	IsSystem              bool   `json:"is_system"`
+	Required              bool   `json:"required"`
+	Editable              bool   `json:"editable"`
+	Unique                bool   `json:"unique"`
+	Summable              bool   `json:"summable"`
+	ObjectTypeID          string `json:"object_type_id"`
+	ObjectTypeName        string `json:"object_type_name"`
+	SchemaID              string `json:"schema_id"`
+	SchemaName            string `json:"schema_name,omitempty"`
+}
+
+// CatalogAttributes builds a searchable, paginated catalog of attributes across one
+// schema or the whole workspace
+func CatalogAttributes(client common.ClientInterface, params common.CatalogAttributesParams) (*common.Response, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 {
+		params.PerPage = 25
+	}
+
+	// Compile the pattern before doing any API work
+	var pattern *regexp.Regexp
+	if params.Pattern != "" {
+		var err error
+		pattern, err = regexp.Compile("(?i)" + params.Pattern)
+		if err != nil {
+			return common.NewErrorResponse(fmt.Errorf("invalid pattern: %w", err)), nil
+		}
+	}
+
+	var schemas []*models.ObjectSchemaScheme
+	scope := "All Schemas"
+	if params.Schema != "" {
+		schemas = []*models.ObjectSchemaScheme{{ID: params.Schema}}
+		scope = fmt.Sprintf("Schema %s", params.Schema)
+	} else {
+		var err error
+		schemas, err = foundation.ListAllSchemas(client)
+		if err != nil {
+			return common.NewErrorResponse(fmt.Errorf("failed to list schemas: %w", err)), nil
+		}
+	}
+
+	var allAttributes []AttributeCatalogEntry
+	for _, schema := range schemas {
+		objectTypes, err := foundation.ListObjectTypes(client, schema.ID)
+		if err != nil {
+			if params.Schema != "" {
+				return common.NewErrorResponse(fmt.Errorf("failed to get object types: %w", err)), nil
+			}
+			continue // Skip failed schemas
+		}
+
+		for _, objType := range objectTypes {
+			attributes, err := foundation.ListAttributes(client, objType.ID)
+			if err != nil {
+				continue // Skip failed lookups
+			}
+
+			for _, attr := range attributes {
+				allAttributes = append(allAttributes, newCatalogEntry(attr, objType, schema))
+			}
+		}
+	}
+
+	// Filter by pattern
+	filteredAttributes := allAttributes
+	if pattern != nil {
+		filteredAttributes = nil
+		for _, attr := range allAttributes {
+			if pattern.MatchString(attr.Name) || pattern.MatchString(attr.Description) {
+				filteredAttributes = append(filteredAttributes, attr)
+			}
+		}
+	}
+
+	sort.Slice(filteredAttributes, func(i, j int) bool {
+		return strings.ToLower(filteredAttributes[i].Name) < strings.ToLower(filteredAttributes[j].Name)
+	})
+
+	totalCount := len(filteredAttributes)
+	var paginatedAttributes []AttributeCatalogEntry
+	var pageInfo map[string]interface{}
+
+	if params.All {
+		paginatedAttributes = filteredAttributes
+		pageInfo = map[string]interface{}{
+			"pagination": "disabled",
+			"total":      totalCount,
+		}
+	} else {
+		startIdx := (params.Page - 1) * params.PerPage
+		endIdx := startIdx + params.PerPage
+
+		if startIdx >= totalCount {
+			paginatedAttributes = []AttributeCatalogEntry{}
+		} else {
+			if endIdx > totalCount {
+				endIdx = totalCount
+			}
+			paginatedAttributes = filteredAttributes[startIdx:endIdx]
+		}
+
+		pageInfo = map[string]interface{}{
+			"current_page": params.Page,
+			"per_page":     params.PerPage,
+			"total_pages":  (totalCount + params.PerPage - 1) / params.PerPage,
+			"total":        totalCount,
+			"showing":      fmt.Sprintf("%d-%d of %d", startIdx+1, startIdx+len(paginatedAttributes), totalCount),
+		}
+	}
+
+	result := map[string]interface{}{
+		"action":     "catalog_attributes",
+		"scope":      scope,
+		"attributes": paginatedAttributes,
+		"page_info":  pageInfo,
+		"workflow_context": map[string]interface{}{
+			"current_state":         "catalog_browsed",
+			"completion_percentage": 30,
+			"suggested_next_steps": []string{
+				"extract_attributes",
+				"trace_reference",
+				"apply_attributes",
+			},
+		},
+	}
+
+	if params.Pattern != "" {
+		result["pattern"] = params.Pattern
+		result["pattern_matches"] = len(filteredAttributes)
+	}
+
+	return common.NewSuccessResponse(result), nil
+}
+
+// HasReferenceEntries checks if any cataloged attributes are references
+func HasReferenceEntries(attributes []AttributeCatalogEntry) bool {
+	for _, attr := range attributes {
+		if attr.IsReference {
+			return true
+		}
+	}
+	return false
+}
+
+// newCatalogEntry builds a catalog entry for an attribute in its object type and schema
+func newCatalogEntry(attr *models.ObjectTypeAttributeScheme, objType *models.ObjectTypeScheme, schema *models.ObjectSchemaScheme) AttributeCatalogEntry {
+	entry := AttributeCatalogEntry{
+		AttributeID:    attr.ID,
+		Name:           attr.Name,
+		Description:    attr.Description,
+		IsReference:    attr.Type == 1,
+		IsSystem:       attr.System,
+		Required:       attr.MinimumCardinality > 0,
+		Editable:       attr.Editable,
+		Unique:         attr.UniqueAttribute,
+		Summable:       attr.Summable,
+		ObjectTypeID:   objType.ID,
+		ObjectTypeName: objType.Name,
+		SchemaID:       schema.ID,
+		SchemaName:     schema.Name,
+	}
+
+	if attr.DefaultType != nil {
+		entry.DataType = attr.DefaultType.Name
+		entry.DataTypeID = attr.DefaultType.ID
+	}
+
+	if attr.Type == 1 && attr.ReferenceObjectTypeID != "" {
+		entry.ReferenceObjectTypeID = attr.ReferenceObjectTypeID
+		if attr.ReferenceType != nil {
+			entry.ReferenceType = attr.ReferenceType.Name
+		}
+	}
+
+	return entry
+}
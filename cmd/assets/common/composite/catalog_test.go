@@ -0,0 +1,94 @@
+package composite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+)
+
+func TestCatalogAttributes(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    common.CatalogAttributesParams
+		wantError string
+		wantNames []string
+		wantTotal int
+	}{
+		{
+			name:      "catalogs every schema sorted by name",
+			params:    common.CatalogAttributesParams{All: true},
+			wantNames: []string{"CPU", "Key", "Manufacturer", "Name", "Name", "Name"},
+			wantTotal: 6,
+		},
+		{
+			name:      "filters by case-insensitive pattern",
+			params:    common.CatalogAttributesParams{Pattern: "cpu|manu", All: true},
+			wantNames: []string{"CPU", "Manufacturer"},
+			wantTotal: 2,
+		},
+		{
+			name:      "paginates results",
+			params:    common.CatalogAttributesParams{Page: 2, PerPage: 4},
+			wantNames: []string{"Name", "Name"},
+			wantTotal: 6,
+		},
+		{
+			name:      "returns an empty page past the end",
+			params:    common.CatalogAttributesParams{Page: 5, PerPage: 4},
+			wantNames: []string{},
+			wantTotal: 6,
+		},
+		{
+			name:      "rejects invalid patterns",
+			params:    common.CatalogAttributesParams{Pattern: "("},
+			wantError: "invalid pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := CatalogAttributes(newLaptopClient(t), tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				return
+			}
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+
+			data := response.Data.(map[string]interface{})
+			entries := data["attributes"].([]AttributeCatalogEntry)
+			names := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				names = append(names, entry.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("got %v, want %v", names, tt.wantNames)
+			}
+
+			pageInfo := data["page_info"].(map[string]interface{})
+			if pageInfo["total"] != tt.wantTotal {
+				t.Errorf("total %v, want %d", pageInfo["total"], tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestCatalogEntriesFlagReferences(t *testing.T) {
+	response, _ := CatalogAttributes(newLaptopClient(t), common.CatalogAttributesParams{Pattern: "manufacturer"})
+	entries := response.Data.(map[string]interface{})["attributes"].([]AttributeCatalogEntry)
+
+	if !HasReferenceEntries(entries) {
+		t.Fatal("expected a reference entry")
+	}
+	if entries[0].ReferenceObjectTypeID != "80" || entries[0].SchemaName != "IT" {
+		t.Errorf("unexpected entry: %+v", entries[0])
+	}
+}
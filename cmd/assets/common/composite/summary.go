@@ -0,0 +1,96 @@
+package composite
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+)
+
+// SummarizeSchema provides a high-level overview of a schema's object type structure
+func SummarizeSchema(client common.ClientInterface, params common.SchemaSummaryParams) (*common.Response, error) {
+	// Validate parameters
+	if params.SchemaID == "" {
+		return common.NewErrorResponse(fmt.Errorf("schema ID is required")), nil
+	}
+
+	schemaResponse, err := foundation.GetSchema(client, common.GetSchemaParams{SchemaID: params.SchemaID})
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to get schema: %w", err)), nil
+	}
+	if !schemaResponse.Success {
+		return schemaResponse, nil
+	}
+
+	objectTypes, err := foundation.ListObjectTypes(client, params.SchemaID)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to get object types: %w", err)), nil
+	}
+
+	// Analyze the hierarchy
+	names := make(map[string]string, len(objectTypes))
+	parents := make(map[string]string, len(objectTypes))
+	parentCounts := make(map[string]int)
+	rootTypes := 0
+
+	for _, objType := range objectTypes {
+		names[objType.ID] = objType.Name
+		if objType.ParentObjectTypeID != "" {
+			parents[objType.ID] = objType.ParentObjectTypeID
+			parentCounts[objType.ParentObjectTypeID]++
+		} else {
+			rootTypes++
+		}
+	}
+
+	deepest := 0
+	for id := range names {
+		depth := 1
+		for parent, ok := parents[id]; ok && depth <= len(objectTypes); parent, ok = parents[parent] {
+			depth++
+		}
+		if depth > deepest {
+			deepest = depth
+		}
+	}
+
+	schemaData := schemaResponse.Data.(map[string]interface{})
+	summary := map[string]interface{}{
+		"action":             "schema_summary",
+		"schema":             schemaData["schema"],
+		"total_object_types": len(objectTypes),
+		"root_types":         rootTypes,
+		"parent_types":       len(parentCounts),
+		"deepest_hierarchy":  deepest,
+		"workflow_context": map[string]interface{}{
+			"current_state":         "schema_summarized",
+			"completion_percentage": 40,
+			"suggested_next_steps": []string{
+				"browse_schema",
+				"catalog_attributes",
+			},
+		},
+	}
+
+	if len(parentCounts) > 0 {
+		topParents := make([]map[string]interface{}, 0, len(parentCounts))
+		for parentID, count := range parentCounts {
+			parentName := parentID
+			if name, ok := names[parentID]; ok {
+				parentName = name
+			}
+			topParents = append(topParents, map[string]interface{}{
+				"id":          parentID,
+				"name":        parentName,
+				"child_count": count,
+			})
+		}
+		sort.Slice(topParents, func(i, j int) bool {
+			return topParents[i]["child_count"].(int) > topParents[j]["child_count"].(int)
+		})
+		summary["top_parents"] = topParents
+	}
+
+	return common.NewSuccessResponse(summary), nil
+}
@@ -0,0 +1,54 @@
+package composite
+
+import (
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/commontest"
+)
+
+func TestSummarizeSchema(t *testing.T) {
+	client := commontest.NewMockClient()
+	client.Schemas = []*models.ObjectSchemaScheme{{ID: "7", Name: "IT"}}
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "1", Name: "Hardware", ObjectSchemaID: "7"})
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "2", Name: "Computers", ObjectSchemaID: "7", ParentObjectTypeID: "1"})
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "3", Name: "Printers", ObjectSchemaID: "7", ParentObjectTypeID: "1"})
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "4", Name: "Laptops", ObjectSchemaID: "7", ParentObjectTypeID: "2"})
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "5", Name: "Software", ObjectSchemaID: "7"})
+
+	tests := []struct {
+		name  string
+		key   string
+		value interface{}
+	}{
+		{"total object types", "total_object_types", 5},
+		{"root types", "root_types", 2},
+		{"parent types", "parent_types", 2},
+		{"deepest hierarchy", "deepest_hierarchy", 3},
+	}
+
+	response, err := SummarizeSchema(client, common.SchemaSummaryParams{SchemaID: "7"})
+	if err != nil || !response.Success {
+		t.Fatalf("summary failed: %v %v", err, response.Error)
+	}
+	data := response.Data.(map[string]interface{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if data[tt.key] != tt.value {
+				t.Errorf("%s = %v, want %v", tt.key, data[tt.key], tt.value)
+			}
+		})
+	}
+
+	topParents := data["top_parents"].([]map[string]interface{})
+	if topParents[0]["name"] != "Hardware" || topParents[0]["child_count"] != 2 {
+		t.Errorf("expected Hardware to lead the parents, got %v", topParents[0])
+	}
+
+	missing, _ := SummarizeSchema(client, common.SchemaSummaryParams{SchemaID: "99"})
+	if missing.Success {
+		t.Error("expected an error for an unknown schema")
+	}
+}
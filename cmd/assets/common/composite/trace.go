@@ -0,0 +1,261 @@
+package composite
+
+import (
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+)
+
+// DescribeObjectType reports whether a referenced object type is reachable, which
+// distinguishes same-schema targets from cross-schema references
+func DescribeObjectType(client common.ClientInterface, objectTypeID string) map[string]interface{} {
+	response, err := foundation.GetObjectTypeAttributes(client, common.GetObjectTypeAttributesParams{
+		ObjectTypeID: objectTypeID,
+	})
+	if err != nil {
+		return map[string]interface{}{
+			"object_type_id": objectTypeID,
+			"status":         "cross_schema_reference",
+			"error":          err.Error(),
+			"message":        "Object type exists in different schema - cross-schema reference detected",
+		}
+	}
+
+	if !response.Success {
+		return map[string]interface{}{
+			"object_type_id": objectTypeID,
+			"status":         "access_denied",
+			"error":          response.Error,
+		}
+	}
+
+	attrData := response.Data.(map[string]interface{})
+
+	return map[string]interface{}{
+		"object_type_id":  objectTypeID,
+		"status":          "same_schema",
+		"attribute_count": attrData["count"],
+		"message":         "Reference target found in same schema",
+	}
+}
+
+// TraceReference follows a reference attribute to discover its target object type
+func TraceReference(client common.ClientInterface, params common.TraceReferenceParams) (*common.Response, error) {
+	// Validate parameters
+	if params.AttributeID == "" && params.AttributeName == "" {
+		return common.NewErrorResponse(fmt.Errorf("either attribute ID or attribute name is required")), nil
+	}
+	if params.AttributeID == "" && params.ObjectTypeID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object type ID is required when tracing by attribute name")), nil
+	}
+
+	attr, objectTypeID, objectTypeName, err := locateAttribute(client, params)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+
+	isReference := attr.Type == 1 && attr.ReferenceObjectTypeID != ""
+
+	result := map[string]interface{}{
+		"action":             "trace_reference",
+		"attribute_id":       attr.ID,
+		"attribute_name":     attr.Name,
+		"source_schema":      params.SchemaID,
+		"source_object_type": objectTypeID,
+		"source_object_name": objectTypeName,
+	}
+
+	if isReference {
+		result["reference_target"] = DescribeObjectType(client, attr.ReferenceObjectTypeID)
+		result["status"] = "reference_resolved"
+		result["message"] = fmt.Sprintf("Reference attribute '%s' points to object type %s", attr.Name, attr.ReferenceObjectTypeID)
+	} else {
+		result["status"] = "not_reference"
+		result["message"] = fmt.Sprintf("Attribute '%s' is not a reference (type %d)", attr.Name, attr.Type)
+		result["attribute_type"] = attr.Type
+		if attr.DefaultType != nil {
+			result["data_type"] = attr.DefaultType.Name
+		}
+	}
+
+	result["workflow_context"] = map[string]interface{}{
+		"current_state":         "reference_traced",
+		"completion_percentage": 60,
+		"suggested_next_steps": []string{
+			"trace_dependencies",
+			"copy_attributes",
+		},
+	}
+
+	return common.NewSuccessResponse(result), nil
+}
+
+// locateAttribute finds the attribute being traced and the object type that owns it.
+// With an object type it looks there directly; otherwise it scans the given schema,
+// or every schema in the workspace when none is given.
+func locateAttribute(client common.ClientInterface, params common.TraceReferenceParams) (*models.ObjectTypeAttributeScheme, string, string, error) {
+	if params.ObjectTypeID != "" {
+		attributes, err := foundation.ListAttributes(client, params.ObjectTypeID)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("failed to get object type attributes: %w", err)
+		}
+		for _, attr := range attributes {
+			if (params.AttributeID != "" && attr.ID == params.AttributeID) ||
+				(params.AttributeID == "" && attr.Name == params.AttributeName) {
+				return attr, params.ObjectTypeID, "", nil
+			}
+		}
+		if params.AttributeID != "" {
+			return nil, "", "", fmt.Errorf("attribute ID %s not found in object type %s", params.AttributeID, params.ObjectTypeID)
+		}
+		return nil, "", "", fmt.Errorf("attribute '%s' not found in object type %s", params.AttributeName, params.ObjectTypeID)
+	}
+
+	schemaIDs := []string{params.SchemaID}
+	if params.SchemaID == "" {
+		schemas, err := foundation.ListAllSchemas(client)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("failed to list schemas: %w", err)
+		}
+		schemaIDs = schemaIDs[:0]
+		for _, schema := range schemas {
+			schemaIDs = append(schemaIDs, schema.ID)
+		}
+	}
+
+	for _, schemaID := range schemaIDs {
+		objectTypes, err := foundation.ListObjectTypes(client, schemaID)
+		if err != nil {
+			if params.SchemaID != "" {
+				return nil, "", "", fmt.Errorf("failed to get object types: %w", err)
+			}
+			continue // Skip unreadable schemas when scanning the workspace
+		}
+
+		for _, objType := range objectTypes {
+			attributes, err := foundation.ListAttributes(client, objType.ID)
+			if err != nil {
+				continue // Skip failed lookups
+			}
+			for _, attr := range attributes {
+				if attr.ID == params.AttributeID {
+					return attr, objType.ID, objType.Name, nil
+				}
+			}
+		}
+	}
+
+	if params.SchemaID != "" {
+		return nil, "", "", fmt.Errorf("attribute ID %s not found in schema %s", params.AttributeID, params.SchemaID)
+	}
+	return nil, "", "", fmt.Errorf("attribute ID %s not found in any schema", params.AttributeID)
+}
+
+// TraceDependencies discovers the reference dependencies of an object type
+func TraceDependencies(client common.ClientInterface, params common.TraceDependenciesParams) (*common.Response, error) {
+	// Validate parameters
+	if params.ObjectTypeID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object type ID is required")), nil
+	}
+	if params.SchemaID == "" && !params.AllSchemas {
+		return common.NewErrorResponse(fmt.Errorf("either schema ID or all schemas is required")), nil
+	}
+
+	if params.AllSchemas {
+		return traceDependenciesAllSchemas(client, params.ObjectTypeID)
+	}
+
+	attributes, err := foundation.ListAttributes(client, params.ObjectTypeID)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to get object type attributes: %w", err)), nil
+	}
+
+	var dependencies []map[string]interface{}
+	var nonReferences []map[string]interface{}
+
+	for _, attr := range attributes {
+		if attr.System {
+			continue // Skip system attributes
+		}
+
+		attrInfo := map[string]interface{}{
+			"name":     attr.Name,
+			"id":       attr.ID,
+			"type":     attr.Type,
+			"required": attr.MinimumCardinality > 0,
+		}
+		if attr.DefaultType != nil {
+			attrInfo["data_type"] = attr.DefaultType.Name
+		}
+
+		if attr.Type == 1 && attr.ReferenceObjectTypeID != "" {
+			attrInfo["reference_target"] = DescribeObjectType(client, attr.ReferenceObjectTypeID)
+			attrInfo["reference_object_type_id"] = attr.ReferenceObjectTypeID
+			dependencies = append(dependencies, attrInfo)
+		} else {
+			nonReferences = append(nonReferences, attrInfo)
+		}
+	}
+
+	result := map[string]interface{}{
+		"action":              "trace_dependencies",
+		"object_type_id":      params.ObjectTypeID,
+		"schema_id":           params.SchemaID,
+		"total_attributes":    len(dependencies) + len(nonReferences),
+		"reference_count":     len(dependencies),
+		"non_reference_count": len(nonReferences),
+	}
+
+	if len(dependencies) > 0 {
+		result["dependencies"] = dependencies
+		result["status"] = "dependencies_found"
+		result["message"] = fmt.Sprintf("Found %d reference dependencies for object type %s", len(dependencies), params.ObjectTypeID)
+	} else {
+		result["status"] = "no_dependencies"
+		result["message"] = fmt.Sprintf("Object type %s has no reference dependencies", params.ObjectTypeID)
+	}
+
+	if len(nonReferences) > 0 {
+		result["simple_attributes"] = nonReferences
+	}
+
+	result["workflow_context"] = map[string]interface{}{
+		"current_state":         "dependencies_traced",
+		"completion_percentage": 50,
+		"suggested_next_steps": []string{
+			"trace_reference",
+			"extract_attributes",
+			"copy_attributes",
+		},
+	}
+
+	return common.NewSuccessResponse(result), nil
+}
+
+// traceDependenciesAllSchemas reports the workspace schemas for cross-schema analysis
+func traceDependenciesAllSchemas(client common.ClientInterface, objectTypeID string) (*common.Response, error) {
+	schemasResponse, err := foundation.ListSchemas(client)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to list schemas: %w", err)), nil
+	}
+	if !schemasResponse.Success {
+		return schemasResponse, nil
+	}
+
+	schemasData := schemasResponse.Data.(map[string]interface{})
+
+	return common.NewSuccessResponse(map[string]interface{}{
+		"action":            "trace_dependencies_all_schemas",
+		"object_type_id":    objectTypeID,
+		"workspace_schemas": schemasData["schemas"],
+		"status":            "cross_schema_analysis",
+		"message":           "Cross-schema dependency analysis requires implementing schema discovery workflow",
+		"next_steps": []string{
+			"Implement findObjectTypeInSchemas function",
+			"Add cross-schema reference resolution",
+			"Build dependency tree visualization",
+		},
+	}), nil
+}
@@ -0,0 +1,128 @@
+package composite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+)
+
+func TestTraceReference(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         common.TraceReferenceParams
+		wantError      string
+		wantStatus     string
+		wantObjectType string
+	}{
+		{
+			name:           "traces by attribute name",
+			params:         common.TraceReferenceParams{AttributeName: "Manufacturer", ObjectTypeID: "65"},
+			wantStatus:     "reference_resolved",
+			wantObjectType: "65",
+		},
+		{
+			name:           "finds an attribute ID in a schema",
+			params:         common.TraceReferenceParams{AttributeID: "703", SchemaID: "7"},
+			wantStatus:     "reference_resolved",
+			wantObjectType: "65",
+		},
+		{
+			name:           "scans every schema without a schema ID",
+			params:         common.TraceReferenceParams{AttributeID: "703"},
+			wantStatus:     "reference_resolved",
+			wantObjectType: "65",
+		},
+		{
+			name:           "reports non-reference attributes",
+			params:         common.TraceReferenceParams{AttributeID: "702", SchemaID: "7"},
+			wantStatus:     "not_reference",
+			wantObjectType: "65",
+		},
+		{
+			name:      "reports unknown attribute IDs",
+			params:    common.TraceReferenceParams{AttributeID: "999", SchemaID: "7"},
+			wantError: "attribute ID 999 not found in schema 7",
+		},
+		{
+			name:      "requires an object type for names",
+			params:    common.TraceReferenceParams{AttributeName: "Manufacturer"},
+			wantError: "object type ID is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := TraceReference(newLaptopClient(t), tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				return
+			}
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+
+			data := response.Data.(map[string]interface{})
+			if data["status"] != tt.wantStatus {
+				t.Errorf("status %v, want %s", data["status"], tt.wantStatus)
+			}
+			if data["source_object_type"] != tt.wantObjectType {
+				t.Errorf("source object type %v, want %s", data["source_object_type"], tt.wantObjectType)
+			}
+			if tt.wantStatus == "reference_resolved" {
+				target := data["reference_target"].(map[string]interface{})
+				if target["object_type_id"] != "80" || target["status"] != "same_schema" {
+					t.Errorf("unexpected reference target: %v", target)
+				}
+			}
+		})
+	}
+}
+
+func TestTraceDependencies(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         common.TraceDependenciesParams
+		wantError      string
+		wantStatus     string
+		wantReferences int
+	}{
+		{"finds reference dependencies", common.TraceDependenciesParams{ObjectTypeID: "65", SchemaID: "7"}, "", "dependencies_found", 1},
+		{"reports types without references", common.TraceDependenciesParams{ObjectTypeID: "141", SchemaID: "7"}, "", "no_dependencies", 0},
+		{"requires a scope", common.TraceDependenciesParams{ObjectTypeID: "65"}, "either schema ID or all schemas is required", "", 0},
+		{"reports unknown object types", common.TraceDependenciesParams{ObjectTypeID: "404", SchemaID: "7"}, "failed to get object type attributes", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := TraceDependencies(newLaptopClient(t), tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				return
+			}
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+
+			data := response.Data.(map[string]interface{})
+			if data["status"] != tt.wantStatus {
+				t.Errorf("status %v, want %s", data["status"], tt.wantStatus)
+			}
+			if data["reference_count"] != tt.wantReferences {
+				t.Errorf("reference count %v, want %d", data["reference_count"], tt.wantReferences)
+			}
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package composite
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
 	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+	"github.com/aaronsb/atlassian-assets/internal/defaults"
+	"github.com/aaronsb/atlassian-assets/internal/property"
+	"github.com/aaronsb/atlassian-assets/internal/rules"
 )
 
 // ValidateObject validates object data against object type requirements
@@ -35,6 +39,16 @@ func ValidateObject(client common.ClientInterface, params common.ValidateObjectP
 	// Perform validation logic
 	validationResult := performValidation(params.Data, attributesData)
 
+	// Apply the user-defined validation rules
+	violations, err := foundation.CheckRules(client, params.ObjectTypeID, params.Data)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+	validationResult["rule_violations"] = violations
+	if rules.HasErrors(violations) {
+		validationResult["is_valid"] = false
+	}
+
 	// Build validation response
 	validationData := map[string]interface{}{
 		"object_type_id":    params.ObjectTypeID,
@@ -76,11 +90,19 @@ func CompleteObject(client common.ClientInterface, params common.CompleteObjectP
 		return attributesResponse, nil
 	}
 
-	// Extract attributes data
-	attributesData := attributesResponse.Data.(map[string]interface{})
-	
-	// Perform intelligent completion
-	completionResult := performCompletion(params.Data, attributesData)
+	// Complete the data from the defaults policy
+	completionResult, err := performCompletion(client, params.ObjectTypeID, params.Data)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+
+	// Check the completed data against the user-defined validation rules
+	violations, err := foundation.CheckRules(client, params.ObjectTypeID, completionResult["completed_data"].(map[string]interface{}))
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+	completionResult["rule_violations"] = violations
+	completionResult["is_valid"] = !rules.HasErrors(violations)
 
 	// Build completion response
 	completionData := map[string]interface{}{
@@ -166,44 +188,73 @@ func performValidation(data map[string]interface{}, attributesData map[string]in
 	return validationResult
 }
 
-// performCompletion performs intelligent completion on object data
-func performCompletion(data map[string]interface{}, attributesData map[string]interface{}) map[string]interface{} {
-	completionResult := map[string]interface{}{
-		"original_data":    data,
-		"completed_data":   make(map[string]interface{}),
-		"suggestions":      []interface{}{},
-		"applied_defaults": []string{},
-		"confidence":       "high",
-	}
-
+// performCompletion completes object data from the configured defaults policy
+func performCompletion(client common.ClientInterface, objectTypeID string, data map[string]interface{}) (map[string]interface{}, error) {
 	// Copy original data
 	completedData := make(map[string]interface{})
 	for k, v := range data {
 		completedData[k] = v
 	}
 
-	// Apply intelligent defaults and suggestions
-	if _, ok := completedData["name"]; !ok {
-		completedData["name"] = "New Asset"
-		completionResult["applied_defaults"] = append(completionResult["applied_defaults"].([]string), "name")
+	appliedDefaults := []defaults.Applied{}
+	defaultSet, err := defaults.LoadDefault()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load defaults policy: %w", err)
 	}
+	if !defaultSet.Empty() {
+		sequences, err := defaults.DefaultSequenceStore()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open sequence store: %w", err)
+		}
 
-	if _, ok := completedData["status"]; !ok {
-		completedData["status"] = "Active"
-		completionResult["applied_defaults"] = append(completionResult["applied_defaults"].([]string), "status")
+		ctx := context.Background()
+		choices, err := attributeChoices(ctx, client, objectTypeID)
+		if err != nil {
+			return nil, err
+		}
+		applied, err := defaultSet.Apply(ctx, defaults.Env{
+			API:       client,
+			Sequences: sequences,
+			Choices:   choices,
+		}, objectTypeID, completedData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply defaults: %w", err)
+		}
+		appliedDefaults = append(appliedDefaults, applied...)
 	}
 
 	// Add suggestions
-	suggestions := []interface{}{
-		map[string]interface{}{
-			"field":       "description",
-			"suggestion":  "Consider adding a description for better asset documentation",
-			"confidence":  "medium",
-		},
+	suggestions := []interface{}{}
+	if _, ok := completedData["description"]; !ok {
+		suggestions = append(suggestions, map[string]interface{}{
+			"field":      "description",
+			"suggestion": "Consider adding a description for better asset documentation",
+			"confidence": "medium",
+		})
 	}
 
-	completionResult["completed_data"] = completedData
-	completionResult["suggestions"] = suggestions
+	return map[string]interface{}{
+		"original_data":    data,
+		"completed_data":   completedData,
+		"suggestions":      suggestions,
+		"applied_defaults": appliedDefaults,
+		"defaults_sources": defaultSet.Sources,
+	}, nil
+}
+
+// attributeChoices lists the status names and select options of each attribute, as
+// the CLI's completion offers them to static defaults
+func attributeChoices(ctx context.Context, client common.ClientInterface, objectTypeID string) (map[string][]string, error) {
+	metadata, err := property.NewPropertyResolver(client).WithStatuses(client).GetObjectTypeMetadata(ctx, objectTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attribute choices: %w", err)
+	}
 
-	return completionResult
-}
\ No newline at end of file
+	choices := make(map[string][]string)
+	for _, meta := range metadata {
+		if values := meta.Choices(); len(values) > 0 {
+			choices[meta.Name] = values
+		}
+	}
+	return choices, nil
+}
@@ -0,0 +1,74 @@
+package composite
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/commontest"
+	"github.com/aaronsb/atlassian-assets/internal/defaults"
+	"github.com/aaronsb/atlassian-assets/internal/rules"
+)
+
+func TestCompleteObjectDefaults(t *testing.T) {
+	dir := t.TempDir()
+	policy := filepath.Join(dir, "defaults.yaml")
+	content := `
+object_type: Laptops
+defaults:
+  - field: Device Type
+    value: physical
+  - field: Status
+    value: retired
+  - field: asset_tag
+    template: "{{.name | upper}}-{{seq 3}}"
+`
+	if err := os.WriteFile(policy, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ATLASSIAN_ASSETS_DEFAULTS", policy)
+	t.Setenv("ATLASSIAN_ASSETS_SEQUENCES", filepath.Join(dir, "sequences.json"))
+	t.Setenv("ATLASSIAN_ASSETS_RULES", filepath.Join(dir, "rules.yaml"))
+	rulesContent := "rules:\n  - kind: length\n    field: asset_tag\n    max: 4\n    severity: warning\n"
+	if err := os.WriteFile(filepath.Join(dir, "rules.yaml"), []byte(rulesContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := commontest.NewMockClient()
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "23", Name: "Laptops", ObjectSchemaID: "6"},
+		&models.ObjectTypeAttributeScheme{
+			Name:        "Device Type",
+			DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: 10, Name: "Select"},
+			Options:     "Physical, Virtual",
+		},
+		&models.ObjectTypeAttributeScheme{Name: "Status", Type: 7, TypeValueMulti: []string{"1", "2"}})
+	client.Statuses = []*models.ObjectTypeAssetAttributeStatusScheme{{ID: "1", Name: "In Stock"}, {ID: "2", Name: "Retired"}}
+
+	response, err := CompleteObject(client, common.CompleteObjectParams{
+		ObjectTypeID: "23",
+		Data:         map[string]interface{}{"name": "lap"},
+	})
+	if err != nil || !response.Success {
+		t.Fatalf("completion failed: %v %v", err, response.Error)
+	}
+
+	result := response.Data.(map[string]interface{})["completion_result"].(map[string]interface{})
+	completed := result["completed_data"].(map[string]interface{})
+	// Static defaults take the spelling of the select option or status name
+	if completed["Device Type"] != "Physical" || completed["Status"] != "Retired" || completed["asset_tag"] != "LAP-001" {
+		t.Errorf("completed_data = %v", completed)
+	}
+
+	applied := result["applied_defaults"].([]defaults.Applied)
+	if len(applied) != 3 || applied[0].Source != defaults.SourceStatic || applied[2].Source != defaults.SourceTemplate {
+		t.Errorf("applied_defaults = %+v", applied)
+	}
+
+	// Rules are checked against the completed data, including generated values
+	violations := result["rule_violations"].([]rules.Violation)
+	if len(violations) != 1 || violations[0].Field != "asset_tag" {
+		t.Errorf("rule_violations = %+v", violations)
+	}
+}
@@ -0,0 +1,552 @@
+package foundation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/internal/archive"
+	"github.com/aaronsb/atlassian-assets/internal/property"
+)
+
+// Maximum number of objects referencing a deleted object that its snapshot records
+const inboundReferenceLimit = 1000
+
+// DefaultArchivePath returns the archive file in the client's cache directory
+func DefaultArchivePath(client common.ClientInterface) (string, error) {
+	cacheDir, err := client.GetConfig().GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return archive.DefaultPath(cacheDir), nil
+}
+
+// attributeIndex caches object type attribute metadata by attribute ID. It is safe for
+// concurrent use.
+type attributeIndex struct {
+	resolver *property.PropertyResolver
+
+	mu    sync.Mutex
+	types map[string]map[string]*property.AttributeMetadata
+}
+
+func newAttributeIndex(pr *property.PropertyResolver) *attributeIndex {
+	return &attributeIndex{resolver: pr, types: make(map[string]map[string]*property.AttributeMetadata)}
+}
+
+// attributes returns the metadata of an object type's attributes keyed by ID
+func (idx *attributeIndex) attributes(ctx context.Context, objectTypeID string) (map[string]*property.AttributeMetadata, error) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if byID, ok := idx.types[objectTypeID]; ok {
+		return byID, nil
+	}
+
+	metadata, err := idx.resolver.GetObjectTypeMetadata(ctx, objectTypeID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*property.AttributeMetadata, len(metadata))
+	for _, meta := range metadata {
+		byID[meta.ID] = meta
+	}
+	idx.types[objectTypeID] = byID
+	return byID, nil
+}
+
+// SnapshotObject records an object's attribute values, its type and the attributes of
+// other objects that reference it, so that it can be recreated after deletion
+func SnapshotObject(client common.ClientInterface, objectID string) (*archive.Tombstone, error) {
+	return snapshotObject(context.Background(), client, newAttributeIndex(newPropertyResolver(client)), objectID)
+}
+
+func snapshotObject(ctx context.Context, client common.ClientInterface, index *attributeIndex, objectID string) (*archive.Tombstone, error) {
+	response, err := client.GetObject(ctx, objectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("failed to get object: %s", response.Error)
+	}
+	object, ok := response.Data.(*models.ObjectScheme)
+	if !ok || object.ObjectType == nil {
+		return nil, fmt.Errorf("unexpected object response type: %T", response.Data)
+	}
+
+	metadata, err := index.attributes(ctx, object.ObjectType.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attributes of object type %s: %w", object.ObjectType.ID, err)
+	}
+
+	tombstone := &archive.Tombstone{
+		ObjectID:       object.ID,
+		ObjectKey:      object.ObjectKey,
+		Label:          object.Label,
+		ObjectTypeID:   object.ObjectType.ID,
+		ObjectTypeName: object.ObjectType.Name,
+		SchemaID:       object.ObjectType.ObjectSchemaID,
+	}
+
+	for _, attr := range object.Attributes {
+		if attr == nil {
+			continue
+		}
+		archived := archive.Attribute{ID: attributeIDOf(attr)}
+		if meta, ok := metadata[archived.ID]; ok {
+			archived.Name = meta.Name
+			archived.DataType = meta.DataType
+			archived.System = meta.System
+		}
+		for _, v := range attr.ObjectAttributeValues {
+			if v == nil {
+				continue
+			}
+			value := archive.Value{Value: v.Value, DisplayValue: v.DisplayValue, SearchValue: v.SearchValue}
+			if value.Value == "" && v.Group != nil {
+				value.Value = v.Group.Name
+			}
+			if v.Status != nil {
+				value.StatusID = v.Status.ID
+			}
+			archived.Values = append(archived.Values, value)
+		}
+		tombstone.Attributes = append(tombstone.Attributes, archived)
+	}
+
+	tombstone.InboundReferences, err = inboundReferences(ctx, client, index, object)
+	if err != nil {
+		return nil, err
+	}
+	return tombstone, nil
+}
+
+// inboundReferences finds the reference attributes of other objects that point at object
+func inboundReferences(ctx context.Context, client common.ClientInterface, index *attributeIndex, object *models.ObjectScheme) ([]archive.InboundReference, error) {
+	if object.ObjectKey == "" {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf(`object HAVING outboundReferences(Key = "%s")`, object.ObjectKey)
+	response, err := client.SearchObjects(ctx, query, inboundReferenceLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search referencing objects: %w", err)
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("failed to search referencing objects: %s", response.Error)
+	}
+	referrers, err := ObjectsFromData(response.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var references []archive.InboundReference
+	for _, referrer := range referrers {
+		if referrer == nil || referrer.ID == object.ID || referrer.ObjectType == nil {
+			continue
+		}
+		metadata, err := index.attributes(ctx, referrer.ObjectType.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get attributes of object type %s: %w", referrer.ObjectType.ID, err)
+		}
+
+		for _, attr := range referrer.Attributes {
+			if attr == nil {
+				continue
+			}
+			meta, ok := metadata[attributeIDOf(attr)]
+			if !ok || meta.DataType != "Reference" || !referencesObject(attr, object) {
+				continue
+			}
+			references = append(references, archive.InboundReference{
+				ObjectID:      referrer.ID,
+				ObjectKey:     referrer.ObjectKey,
+				ObjectTypeID:  referrer.ObjectType.ID,
+				AttributeID:   meta.ID,
+				AttributeName: meta.Name,
+			})
+		}
+	}
+	return references, nil
+}
+
+// referencesObject reports whether a reference attribute value names object
+func referencesObject(attr *models.ObjectAttributeScheme, object *models.ObjectScheme) bool {
+	for _, v := range attr.ObjectAttributeValues {
+		if v != nil && (strings.EqualFold(v.SearchValue, object.ObjectKey) || v.Value == object.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+func attributeIDOf(attr *models.ObjectAttributeScheme) string {
+	if attr.ObjectTypeAttributeID == "" && attr.ObjectTypeAttribute != nil {
+		return attr.ObjectTypeAttribute.ID
+	}
+	return attr.ObjectTypeAttributeID
+}
+
+// restoreValue picks the stored form of a value the API accepts when it is written back.
+// Reference values are object keys, resolved to IDs on restore.
+func restoreValue(dataType string, v archive.Value) string {
+	switch dataType {
+	case "Reference":
+		return firstNonEmpty(v.SearchValue, v.Value)
+	case "Status":
+		return firstNonEmpty(v.StatusID, v.Value)
+	}
+	return firstNonEmpty(v.Value, v.DisplayValue)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// deferredReference is a reference between two restored objects, set once both exist
+type deferredReference struct {
+	tombstone *archive.Tombstone
+	attribute archive.Attribute
+}
+
+// restoreRun holds the state of one restore
+type restoreRun struct {
+	ctx      context.Context
+	client   common.ClientInterface
+	resolver *property.PropertyResolver
+	index    *attributeIndex
+
+	selected map[string]*archive.Tombstone // Tombstones being restored, by original key
+	restored map[string]string             // Original object ID -> new object ID
+	errs     []string
+}
+
+// RestoreObjects recreates objects from the tombstones in an archive and reattaches the
+// references other objects held to them
+func RestoreObjects(client common.ClientInterface, params common.RestoreObjectsParams) (*common.Response, error) {
+	if params.Archive == "" {
+		return common.NewErrorResponse(fmt.Errorf("archive file is required")), nil
+	}
+
+	store := archive.Open(params.Archive)
+	tombstones, err := store.Tombstones()
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+
+	ctx := context.Background()
+	pr := newPropertyResolver(client)
+	run := &restoreRun{
+		ctx:      ctx,
+		client:   client,
+		resolver: pr,
+		index:    newAttributeIndex(pr),
+		selected: make(map[string]*archive.Tombstone),
+		restored: make(map[string]string),
+	}
+
+	// Pick the tombstones to restore
+	byID := make(map[string]*archive.Tombstone, len(tombstones))
+	for _, tombstone := range tombstones {
+		byID[tombstone.ObjectID] = tombstone
+	}
+	var candidates []*archive.Tombstone
+	if len(params.IDs) > 0 {
+		for _, id := range params.IDs {
+			id = strings.TrimSpace(id)
+			if tombstone, ok := byID[id]; ok {
+				candidates = append(candidates, tombstone)
+			} else if id != "" {
+				run.errs = append(run.errs, fmt.Sprintf("Object %s: not found in archive", id))
+			}
+		}
+	} else {
+		candidates = tombstones
+	}
+
+	var skipped []map[string]interface{}
+	var pending []*archive.Tombstone
+	for _, tombstone := range candidates {
+		reason := ""
+		switch {
+		case tombstone.RestoredID != "":
+			reason = fmt.Sprintf("already restored as object %s", tombstone.RestoredID)
+		case run.objectExists(tombstone.ObjectID):
+			reason = "object still exists"
+		}
+		if reason != "" {
+			skipped = append(skipped, map[string]interface{}{"object_id": tombstone.ObjectID, "object_key": tombstone.ObjectKey, "reason": reason})
+			continue
+		}
+		pending = append(pending, tombstone)
+		if tombstone.ObjectKey != "" {
+			run.selected[strings.ToUpper(tombstone.ObjectKey)] = tombstone
+		}
+	}
+
+	// Recreate the objects, holding back references to objects not restored yet
+	var restored []map[string]interface{}
+	var deferred []deferredReference
+	for _, tombstone := range pending {
+		attributes, later := run.payload(tombstone)
+		response, err := CreateObject(client, common.CreateObjectParams{ObjectTypeID: tombstone.ObjectTypeID, Attributes: attributes})
+		if err != nil || !response.Success {
+			run.errs = append(run.errs, fmt.Sprintf("Object %s (%s): %s", tombstone.ObjectID, tombstone.ObjectKey, responseError(response, err)))
+			continue
+		}
+
+		created, _ := createdObject(response)
+		if created == nil || created.ID == "" {
+			run.errs = append(run.errs, fmt.Sprintf("Object %s (%s): created object has no ID", tombstone.ObjectID, tombstone.ObjectKey))
+			continue
+		}
+		run.restored[tombstone.ObjectID] = created.ID
+		if err := store.MarkRestored(tombstone.ObjectID, created.ID); err != nil {
+			run.errs = append(run.errs, fmt.Sprintf("Object %s: restored as %s but not marked in the archive: %v", tombstone.ObjectID, created.ID, err))
+		}
+		for _, attr := range later {
+			deferred = append(deferred, deferredReference{tombstone: tombstone, attribute: attr})
+		}
+		restored = append(restored, map[string]interface{}{
+			"original_id":    tombstone.ObjectID,
+			"original_key":   tombstone.ObjectKey,
+			"restored_id":    created.ID,
+			"restored_key":   created.ObjectKey,
+			"object_type_id": tombstone.ObjectTypeID,
+			"label":          tombstone.Label,
+		})
+	}
+
+	// Set the references between restored objects now that all of them exist
+	for _, d := range deferred {
+		run.restoreDeferred(d)
+	}
+
+	// Point the surviving objects that referenced a restored object at its new ID
+	reattached := 0
+	var unattached []map[string]interface{}
+	for _, tombstone := range pending {
+		newID, ok := run.restored[tombstone.ObjectID]
+		if !ok {
+			continue
+		}
+		for _, ref := range tombstone.InboundReferences {
+			if _, ok := run.restored[ref.ObjectID]; ok {
+				continue // Recreated with its own references
+			}
+			if reason := run.reattach(ref, tombstone.ObjectKey, newID); reason != "" {
+				unattached = append(unattached, map[string]interface{}{
+					"object_id":      ref.ObjectID,
+					"object_key":     ref.ObjectKey,
+					"attribute_name": ref.AttributeName,
+					"target_id":      newID,
+					"reason":         reason,
+				})
+				continue
+			}
+			reattached++
+		}
+	}
+
+	result := map[string]interface{}{
+		"action":                "restore_objects",
+		"archive":               params.Archive,
+		"restored":              restored,
+		"restored_count":        len(restored),
+		"total_count":           len(pending),
+		"reattached_references": reattached,
+		"success":               len(run.errs) == 0 && len(unattached) == 0,
+	}
+	if len(skipped) > 0 {
+		result["skipped"] = skipped
+	}
+	if len(unattached) > 0 {
+		result["unattached_references"] = unattached
+	}
+	if len(run.errs) > 0 {
+		result["errors"] = run.errs
+	}
+
+	return common.NewSuccessResponse(result), nil
+}
+
+// objectExists reports whether an object with the original ID is still in the
+// workspace, as it is when its deletion failed after the snapshot was taken
+func (r *restoreRun) objectExists(objectID string) bool {
+	response, err := r.client.GetObject(r.ctx, objectID)
+	return err == nil && response.Success
+}
+
+// payload builds the create attributes for a tombstone. References to objects that are
+// restored in the same run but do not exist yet are returned to be set afterwards.
+func (r *restoreRun) payload(tombstone *archive.Tombstone) (map[string]interface{}, []archive.Attribute) {
+	attributes := make(map[string]interface{})
+	var later []archive.Attribute
+
+	for _, attr := range tombstone.Attributes {
+		if attr.System || attr.DataType == "" {
+			continue
+		}
+
+		var values []string
+		waiting := false
+		for _, v := range attr.Values {
+			value := restoreValue(attr.DataType, v)
+			if value == "" {
+				continue
+			}
+			if attr.DataType == "Reference" {
+				if target, ok := r.selected[strings.ToUpper(value)]; ok {
+					newID, done := r.restored[target.ObjectID]
+					if !done {
+						waiting = true
+						continue
+					}
+					value = newID
+				}
+			}
+			values = append(values, value)
+		}
+
+		if waiting {
+			later = append(later, attr)
+		}
+		if len(values) > 0 {
+			attributes[attr.ID] = values
+		}
+	}
+	return attributes, later
+}
+
+// restoreDeferred sets a reference attribute of a restored object once the objects it
+// references have been recreated
+func (r *restoreRun) restoreDeferred(d deferredReference) {
+	objectID := r.restored[d.tombstone.ObjectID]
+
+	var values []string
+	for _, v := range d.attribute.Values {
+		value := restoreValue(d.attribute.DataType, v)
+		if target, ok := r.selected[strings.ToUpper(value)]; ok {
+			newID, done := r.restored[target.ObjectID]
+			if !done {
+				continue // The referenced object could not be restored
+			}
+			value = newID
+		}
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	if err := r.update(objectID, d.tombstone.ObjectTypeID, d.attribute.ID, values); err != nil {
+		r.errs = append(r.errs, fmt.Sprintf("Object %s: failed to set %s: %v", objectID, d.attribute.Name, err))
+	}
+}
+
+// reattach adds the restored object to the reference attribute of an object that
+// referenced the original, returning why it could not
+func (r *restoreRun) reattach(ref archive.InboundReference, originalKey, newID string) string {
+	response, err := r.client.GetObject(r.ctx, ref.ObjectID)
+	if err != nil || !response.Success {
+		return "referencing object no longer exists"
+	}
+	referrer, ok := response.Data.(*models.ObjectScheme)
+	if !ok {
+		return fmt.Sprintf("unexpected object response type: %T", response.Data)
+	}
+
+	metadata, err := r.index.attributes(r.ctx, ref.ObjectTypeID)
+	if err != nil {
+		return err.Error()
+	}
+	meta, ok := metadata[ref.AttributeID]
+	if !ok {
+		return "attribute no longer exists"
+	}
+
+	// Keep the current values, which still name other objects by key
+	var values []string
+	for _, attr := range referrer.Attributes {
+		if attr == nil || attributeIDOf(attr) != ref.AttributeID {
+			continue
+		}
+		for _, v := range attr.ObjectAttributeValues {
+			if v == nil {
+				continue
+			}
+			value := restoreValue("Reference", archive.Value{Value: v.Value, SearchValue: v.SearchValue})
+			if value == "" || strings.EqualFold(value, originalKey) {
+				continue
+			}
+			values = append(values, value)
+		}
+	}
+
+	if meta.MaxCardinality == 1 && len(values) > 0 {
+		return "attribute has been set to another object since the deletion"
+	}
+
+	if err := r.update(ref.ObjectID, ref.ObjectTypeID, ref.AttributeID, append(values, newID)); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// update resolves reference values and writes them to one attribute of an object
+func (r *restoreRun) update(objectID, objectTypeID, attributeID string, values []string) error {
+	metadata, err := r.index.attributes(r.ctx, objectTypeID)
+	if err != nil {
+		return err
+	}
+	meta, ok := metadata[attributeID]
+	if !ok {
+		return fmt.Errorf("attribute %s not found on object type %s", attributeID, objectTypeID)
+	}
+
+	resolved, err := r.resolver.ResolveProperty(r.ctx, meta, values)
+	if err != nil {
+		return err
+	}
+	if resolved == nil {
+		return nil
+	}
+
+	response, err := r.client.UpdateObject(r.ctx, objectID, objectTypeID, map[string]interface{}{attributeID: resolved.Values()})
+	if err != nil {
+		return err
+	}
+	if !response.Success {
+		return errors.New(response.Error)
+	}
+	return nil
+}
+
+// createdObject returns the object in a create response
+func createdObject(response *common.Response) (*models.ObjectScheme, bool) {
+	data, ok := response.Data.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	object, ok := data["object"].(*models.ObjectScheme)
+	return object, ok
+}
+
+// responseError describes a failed call that returned either an error or an error response
+func responseError(response *common.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return response.Error
+}
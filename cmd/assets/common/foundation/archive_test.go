@@ -0,0 +1,190 @@
+package foundation
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/commontest"
+	"github.com/aaronsb/atlassian-assets/internal/archive"
+)
+
+// newArchiveClient serves a laptop (HW-100) referenced by an employee (EMP-1), who
+// also references a second laptop (HW-5)
+func newArchiveClient() *commontest.MockClient {
+	client := commontest.NewMockClient()
+	laptops := &models.ObjectTypeScheme{ID: "5", Name: "Laptops", ObjectSchemaID: "1"}
+	employees := &models.ObjectTypeScheme{ID: "7", Name: "Employees", ObjectSchemaID: "1"}
+	client.AddObjectType(laptops,
+		&models.ObjectTypeAttributeScheme{ID: "51", Name: "Key", System: true, MaximumCardinality: 1},
+		&models.ObjectTypeAttributeScheme{ID: "52", Name: "Name", Editable: true, MinimumCardinality: 1, MaximumCardinality: 1},
+		&models.ObjectTypeAttributeScheme{ID: "53", Name: "Status", Editable: true, Type: 7, MaximumCardinality: 1, TypeValueMulti: []string{"1", "2"}},
+	)
+	client.AddObjectType(employees,
+		&models.ObjectTypeAttributeScheme{ID: "71", Name: "Name", Editable: true, MinimumCardinality: 1, MaximumCardinality: 1},
+		&models.ObjectTypeAttributeScheme{ID: "72", Name: "Laptops", Editable: true, Type: 1, ReferenceObjectTypeID: "5", MaximumCardinality: -1},
+	)
+
+	value := func(v ...*models.ObjectTypeAssetAttributeValueScheme) []*models.ObjectTypeAssetAttributeValueScheme { return v }
+	client.Objects["100"] = &models.ObjectScheme{ID: "100", ObjectKey: "HW-100", Label: "dev-box", ObjectType: laptops,
+		Attributes: []*models.ObjectAttributeScheme{
+			{ObjectTypeAttributeID: "51", ObjectAttributeValues: value(&models.ObjectTypeAssetAttributeValueScheme{Value: "HW-100"})},
+			{ObjectTypeAttributeID: "52", ObjectAttributeValues: value(&models.ObjectTypeAssetAttributeValueScheme{Value: "dev-box"})},
+			{ObjectTypeAttributeID: "53", ObjectAttributeValues: value(&models.ObjectTypeAssetAttributeValueScheme{
+				DisplayValue: "In use", Status: &models.ObjectTypeAssetAttributeStatusScheme{ID: "2", Name: "In use"}})},
+		}}
+	client.Objects["200"] = &models.ObjectScheme{ID: "200", ObjectKey: "EMP-1", Label: "Ada", ObjectType: employees,
+		Attributes: []*models.ObjectAttributeScheme{
+			{ObjectTypeAttributeID: "71", ObjectAttributeValues: value(&models.ObjectTypeAssetAttributeValueScheme{Value: "Ada"})},
+			{ObjectTypeAttributeID: "72", ObjectAttributeValues: value(
+				&models.ObjectTypeAssetAttributeValueScheme{DisplayValue: "dev-box", SearchValue: "HW-100"},
+				&models.ObjectTypeAssetAttributeValueScheme{DisplayValue: "spare", SearchValue: "HW-5"},
+			)},
+		}}
+	return client
+}
+
+func TestDeleteInstancesArchive(t *testing.T) {
+	client := newArchiveClient()
+	client.SearchResults = []*models.ObjectScheme{client.Objects["200"]}
+	path := filepath.Join(t.TempDir(), "archive", archive.DefaultFileName)
+
+	response, err := DeleteInstances(client, common.DeleteInstancesParams{IDs: []string{"100"}, Confirm: true, Archive: path})
+	if err != nil || !response.Success {
+		t.Fatalf("DeleteInstances failed: %v %s", err, response.Error)
+	}
+	if data := response.Data.(map[string]interface{}); data["archive"] != path || data["deleted_count"] != 1 {
+		t.Errorf("response = %v, want one deletion archived to %s", data, path)
+	}
+
+	tombstones, err := archive.Open(path).Tombstones()
+	if err != nil || len(tombstones) != 1 {
+		t.Fatalf("tombstones = %v, %v, want one", tombstones, err)
+	}
+	tombstone := tombstones[0]
+	if tombstone.ObjectKey != "HW-100" || tombstone.ObjectTypeID != "5" || tombstone.ObjectTypeName != "Laptops" || len(tombstone.Attributes) != 3 {
+		t.Errorf("tombstone = %+v", tombstone)
+	}
+	if status := tombstone.Attributes[2]; status.DataType != "Status" || status.Values[0].StatusID != "2" {
+		t.Errorf("status attribute = %+v, want the status ID kept", status)
+	}
+	wantRefs := []archive.InboundReference{{ObjectID: "200", ObjectKey: "EMP-1", ObjectTypeID: "7", AttributeID: "72", AttributeName: "Laptops"}}
+	if !reflect.DeepEqual(tombstone.InboundReferences, wantRefs) {
+		t.Errorf("inbound references = %+v, want %+v", tombstone.InboundReferences, wantRefs)
+	}
+
+	// An object that cannot be snapshotted is left alone
+	client.Failures["GetObject"] = "API error: 500"
+	response, _ = DeleteInstances(client, common.DeleteInstancesParams{IDs: []string{"200"}, Confirm: true, Archive: path})
+	data := response.Data.(map[string]interface{})
+	if data["deleted_count"] != 0 || !strings.Contains(strings.Join(data["errors"].([]string), ""), "archiving failed") {
+		t.Errorf("response = %v, want the deletion refused", data)
+	}
+	if len(client.DeletedObjects) != 1 {
+		t.Errorf("deleted %v, want only object 100", client.DeletedObjects)
+	}
+}
+
+func TestRestoreObjects(t *testing.T) {
+	t.Setenv("ATLASSIAN_ASSETS_RULES", t.TempDir())
+	client := newArchiveClient()
+	client.SearchResults = []*models.ObjectScheme{client.Objects["200"]}
+	path := filepath.Join(t.TempDir(), archive.DefaultFileName)
+
+	if _, err := DeleteInstances(client, common.DeleteInstancesParams{IDs: []string{"100"}, Confirm: true, Archive: path}); err != nil {
+		t.Fatalf("DeleteInstances failed: %v", err)
+	}
+	delete(client.Objects, "100")
+
+	// Reference lookups by key now find the other laptop
+	client.SearchResults = []*models.ObjectScheme{{ID: "5", ObjectKey: "HW-5"}}
+
+	response, err := RestoreObjects(client, common.RestoreObjectsParams{Archive: path})
+	if err != nil || !response.Success {
+		t.Fatalf("RestoreObjects failed: %v %s", err, response.Error)
+	}
+	data := response.Data.(map[string]interface{})
+	if data["restored_count"] != 1 || data["reattached_references"] != 1 || data["success"] != true {
+		t.Fatalf("response = %v", data)
+	}
+
+	wantCreated := []map[string]interface{}{{"52": "dev-box", "53": "2"}}
+	if !reflect.DeepEqual(client.CreatedObjects["5"], wantCreated) {
+		t.Errorf("created %v, want %v", client.CreatedObjects["5"], wantCreated)
+	}
+	wantUpdate := []map[string]interface{}{{"72": []string{"5", "5000"}}}
+	if !reflect.DeepEqual(client.UpdatedObjects["200"], wantUpdate) {
+		t.Errorf("updated %v, want %v", client.UpdatedObjects["200"], wantUpdate)
+	}
+
+	// A restored object is not restored twice
+	response, _ = RestoreObjects(client, common.RestoreObjectsParams{Archive: path, IDs: []string{"100"}})
+	data = response.Data.(map[string]interface{})
+	skipped, _ := data["skipped"].([]map[string]interface{})
+	if data["restored_count"] != 0 || len(skipped) != 1 || !strings.Contains(skipped[0]["reason"].(string), "already restored as object 5000") {
+		t.Errorf("second restore = %v, want it skipped", data)
+	}
+}
+
+func TestRestoreObjectsBetweenRestoredObjects(t *testing.T) {
+	t.Setenv("ATLASSIAN_ASSETS_RULES", t.TempDir())
+	client := newArchiveClient()
+	path := filepath.Join(t.TempDir(), archive.DefaultFileName)
+
+	// The employee goes first, so its reference to the laptop waits for the laptop
+	client.SearchResults = []*models.ObjectScheme{client.Objects["200"]}
+	if _, err := DeleteInstances(client, common.DeleteInstancesParams{IDs: []string{"200", "100"}, Confirm: true, Archive: path}); err != nil {
+		t.Fatalf("DeleteInstances failed: %v", err)
+	}
+	delete(client.Objects, "100")
+	delete(client.Objects, "200")
+	client.SearchResults = []*models.ObjectScheme{{ID: "5", ObjectKey: "HW-5"}}
+
+	response, err := RestoreObjects(client, common.RestoreObjectsParams{Archive: path})
+	if err != nil || !response.Success {
+		t.Fatalf("RestoreObjects failed: %v %s", err, response.Error)
+	}
+	data := response.Data.(map[string]interface{})
+	if data["restored_count"] != 2 || data["reattached_references"] != 0 {
+		t.Fatalf("response = %v", data)
+	}
+
+	// The employee is created with the reference it can resolve and updated with both
+	// once the laptop is back
+	if created := client.CreatedObjects["7"]; len(created) != 1 || !reflect.DeepEqual(created[0]["72"], "5") {
+		t.Errorf("employee created with %v", created)
+	}
+	wantUpdate := []map[string]interface{}{{"72": []string{"5001", "5"}}}
+	if !reflect.DeepEqual(client.UpdatedObjects["5000"], wantUpdate) {
+		t.Errorf("updated %v, want %v", client.UpdatedObjects, wantUpdate)
+	}
+}
+
+func TestRestoreObjectsErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), archive.DefaultFileName)
+	client := newArchiveClient()
+
+	if response, _ := RestoreObjects(client, common.RestoreObjectsParams{}); response.Success || !strings.Contains(response.Error, "archive file is required") {
+		t.Errorf("response = %+v, want the archive required", response)
+	}
+	if response, _ := RestoreObjects(client, common.RestoreObjectsParams{Archive: path}); response.Success {
+		t.Errorf("response = %+v, want a missing archive reported", response)
+	}
+
+	// A snapshot of an object whose deletion failed is not restored
+	if err := archive.Open(path).Add(&archive.Tombstone{ObjectID: "100", ObjectKey: "HW-100", ObjectTypeID: "5"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	response, _ := RestoreObjects(client, common.RestoreObjectsParams{Archive: path, IDs: []string{"100", "999"}})
+	data := response.Data.(map[string]interface{})
+	skipped, _ := data["skipped"].([]map[string]interface{})
+	if len(skipped) != 1 || skipped[0]["reason"] != "object still exists" {
+		t.Errorf("skipped = %v, want the live object skipped", skipped)
+	}
+	if errs, _ := data["errors"].([]string); len(errs) != 1 || !strings.Contains(errs[0], "999: not found in archive") {
+		t.Errorf("errors = %v, want the unknown ID reported", errs)
+	}
+}
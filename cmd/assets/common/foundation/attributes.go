@@ -0,0 +1,157 @@
+package foundation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+)
+
+// defaultTypeIDs maps Assets default attribute type names to their IDs
+var defaultTypeIDs = map[string]int{
+	"Text":       0,
+	"Integer":    1,
+	"Boolean":    2,
+	"Double":     3,
+	"Float":      3,
+	"Date":       4,
+	"Time":       5,
+	"DateTime":   6,
+	"URL":        7,
+	"Email":      8,
+	"Textarea":   9,
+	"Select":     10,
+	"IP Address": 11,
+}
+
+// DefaultTypeIDByName returns the default type ID for a data type name
+func DefaultTypeIDByName(name string) (int, bool) {
+	id, ok := defaultTypeIDs[name]
+	return id, ok
+}
+
+// AttributesFromData extracts the typed attribute list from object type attribute response data
+func AttributesFromData(data interface{}) ([]*models.ObjectTypeAttributeScheme, error) {
+	responseData, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected attribute response type: %T", data)
+	}
+
+	switch attrs := responseData["attributes"].(type) {
+	case []*models.ObjectTypeAttributeScheme:
+		return attrs, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected attributes type: %T", attrs)
+	}
+}
+
+// ListAttributes retrieves the typed attribute list for an object type
+func ListAttributes(client common.ClientInterface, objectTypeID string) ([]*models.ObjectTypeAttributeScheme, error) {
+	response, err := GetObjectTypeAttributes(client, common.GetObjectTypeAttributesParams{
+		ObjectTypeID: objectTypeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, errors.New(response.Error)
+	}
+
+	return AttributesFromData(response.Data)
+}
+
+// FindAttributeByName looks up an attribute by name within an object type
+func FindAttributeByName(client common.ClientInterface, objectTypeID, attributeName string) (*models.ObjectTypeAttributeScheme, error) {
+	attributes, err := ListAttributes(client, objectTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object type attributes: %w", err)
+	}
+
+	for _, attr := range attributes {
+		if attr.Name == attributeName {
+			return attr, nil
+		}
+	}
+
+	return nil, fmt.Errorf("attribute '%s' not found in object type %s", attributeName, objectTypeID)
+}
+
+// CreateObjectTypeAttribute creates an attribute on an object type
+func CreateObjectTypeAttribute(client common.ClientInterface, objectTypeID string, payload *models.ObjectTypeAttributePayloadScheme) (*common.Response, error) {
+	// Validate parameters
+	if objectTypeID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object type ID is required")), nil
+	}
+	if payload == nil || payload.Name == "" {
+		return common.NewErrorResponse(fmt.Errorf("attribute name is required")), nil
+	}
+
+	ctx := context.Background()
+	response, err := client.CreateObjectTypeAttribute(ctx, objectTypeID, payload)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to create attribute: %w", err)), nil
+	}
+
+	// Add metadata
+	if response.Success {
+		responseData := response.Data.(map[string]interface{})
+		responseData["operation"] = "create_object_type_attribute"
+		invalidateObjectType(client, objectTypeID)
+
+		return common.NewSuccessResponse(responseData), nil
+	}
+
+	return common.NewErrorResponse(errors.New(response.Error)), nil
+}
+
+// RemoveAttribute removes an attribute definition from an object type
+func RemoveAttribute(client common.ClientInterface, params common.RemoveAttributeParams) (*common.Response, error) {
+	// Validate parameters
+	if params.ObjectTypeID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object type ID is required")), nil
+	}
+	if params.AttributeID == "" && params.AttributeName == "" {
+		return common.NewErrorResponse(fmt.Errorf("either attribute ID or attribute name is required")), nil
+	}
+	if !params.Confirm {
+		return common.NewErrorResponse(fmt.Errorf("attribute removal requires explicit confirmation")), nil
+	}
+
+	// Resolve attribute ID if needed
+	attributeID := params.AttributeID
+	if attributeID == "" {
+		attr, err := FindAttributeByName(client, params.ObjectTypeID, params.AttributeName)
+		if err != nil {
+			return common.NewErrorResponse(err), nil
+		}
+		attributeID = attr.ID
+	}
+
+	ctx := context.Background()
+	response, err := client.RemoveAttribute(ctx, params.ObjectTypeID, attributeID)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to remove attribute: %w", err)), nil
+	}
+
+	if response.Success {
+		invalidateObjectType(client, params.ObjectTypeID)
+
+		responseData := map[string]interface{}{
+			"action":       "remove_attribute",
+			"type_id":      params.ObjectTypeID,
+			"attribute_id": attributeID,
+			"confirm":      params.Confirm,
+			"removed":      true,
+		}
+		if params.AttributeName != "" {
+			responseData["attribute_name"] = params.AttributeName
+		}
+		return common.NewSuccessResponse(responseData), nil
+	}
+
+	return common.NewErrorResponse(errors.New(response.Error)), nil
+}
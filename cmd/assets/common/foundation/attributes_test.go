@@ -0,0 +1,97 @@
+package foundation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/commontest"
+)
+
+func TestRemoveAttribute(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      common.RemoveAttributeParams
+		wantError   string
+		wantRemoved string
+	}{
+		{
+			name:        "removes by ID",
+			params:      common.RemoveAttributeParams{ObjectTypeID: "65", AttributeID: "701", Confirm: true},
+			wantRemoved: "701",
+		},
+		{
+			name:        "resolves the attribute name",
+			params:      common.RemoveAttributeParams{ObjectTypeID: "65", AttributeName: "Serial Number", Confirm: true},
+			wantRemoved: "702",
+		},
+		{
+			name:      "reports an unknown name",
+			params:    common.RemoveAttributeParams{ObjectTypeID: "65", AttributeName: "Color", Confirm: true},
+			wantError: "not found",
+		},
+		{
+			name:      "requires confirmation",
+			params:    common.RemoveAttributeParams{ObjectTypeID: "65", AttributeID: "701"},
+			wantError: "requires explicit confirmation",
+		},
+		{
+			name:      "requires an attribute",
+			params:    common.RemoveAttributeParams{ObjectTypeID: "65", Confirm: true},
+			wantError: "either attribute ID or attribute name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := commontest.NewMockClient()
+			client.Config.CacheDir = t.TempDir()
+			client.AddObjectType(&models.ObjectTypeScheme{ID: "65", Name: "Laptops", ObjectSchemaID: "7"},
+				&models.ObjectTypeAttributeScheme{ID: "701", Name: "Name"},
+				&models.ObjectTypeAttributeScheme{ID: "702", Name: "Serial Number"},
+			)
+
+			response, err := RemoveAttribute(client, tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				return
+			}
+
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+			if len(client.RemovedAttributes) != 1 || client.RemovedAttributes[0] != tt.wantRemoved {
+				t.Errorf("removed %v, want [%s]", client.RemovedAttributes, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestDefaultTypeIDByName(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantID int
+		wantOK bool
+	}{
+		{"Text", 0, true},
+		{"Float", 3, true},
+		{"Select", 10, true},
+		{"Hologram", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := DefaultTypeIDByName(tt.name)
+			if id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("DefaultTypeIDByName(%q) = %d, %v; want %d, %v", tt.name, id, ok, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
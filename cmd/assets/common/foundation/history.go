@@ -0,0 +1,158 @@
+package foundation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+)
+
+// Kinds of change in an object's history
+const (
+	changeAdded   = "added"
+	changeChanged = "changed"
+	changeRemoved = "removed"
+	changeEvent   = "event" // Not tied to an attribute, such as the object's creation
+)
+
+// historyEntry is one change to an object with its attribute named
+type historyEntry struct {
+	ID          string `json:"id"`
+	Created     string `json:"created"`
+	Actor       string `json:"actor,omitempty"`
+	ActorEmail  string `json:"actor_email,omitempty"`
+	Attribute   string `json:"attribute,omitempty"`
+	AttributeID string `json:"attribute_id,omitempty"`
+	Change      string `json:"change"`
+	OldValue    string `json:"old_value,omitempty"`
+	NewValue    string `json:"new_value,omitempty"`
+	Type        int    `json:"type"`
+}
+
+// GetObjectHistory lists the changes made to an object, newest first, with the before
+// and after value of each attribute change, who made it and when
+func GetObjectHistory(client common.ClientInterface, params common.ObjectHistoryParams) (*common.Response, error) {
+	if params.ID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object ID is required")), nil
+	}
+	if params.Limit < 0 {
+		return common.NewErrorResponse(fmt.Errorf("limit must be 0 or greater, got %d", params.Limit)), nil
+	}
+	objectID, err := resolveObjectID(client, params.ID)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+
+	ctx := context.Background()
+	response, err := client.GetObject(ctx, objectID)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to get object: %w", err)), nil
+	}
+	if !response.Success {
+		return common.NewErrorResponse(errors.New(response.Error)), nil
+	}
+	object, ok := response.Data.(*models.ObjectScheme)
+	if !ok {
+		return common.NewErrorResponse(fmt.Errorf("unexpected object response type: %T", response.Data)), nil
+	}
+
+	response, err = client.GetObjectHistory(ctx, objectID)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to get object history: %w", err)), nil
+	}
+	if !response.Success {
+		return common.NewErrorResponse(errors.New(response.Error)), nil
+	}
+	history, _ := response.Data.(map[string]interface{})["history"].([]*models.ObjectHistoryScheme)
+
+	names := attributeNames(client, object)
+	entries := make([]*historyEntry, 0, len(history))
+	for _, h := range history {
+		entry := newHistoryEntry(h, names)
+		if params.Attribute != "" && !strings.EqualFold(entry.Attribute, params.Attribute) && entry.AttributeID != params.Attribute {
+			continue
+		}
+		entries = append(entries, entry)
+		if params.Limit > 0 && len(entries) == params.Limit {
+			break
+		}
+	}
+
+	data := map[string]interface{}{
+		"object_id":  objectID,
+		"object_key": object.ObjectKey,
+		"label":      object.Label,
+		"history":    entries,
+		"total":      len(entries),
+		"operation":  "get_object_history",
+	}
+	if object.ObjectType != nil {
+		data["object_type_id"] = object.ObjectType.ID
+	}
+	if params.Attribute != "" {
+		data["attribute"] = params.Attribute
+	}
+	return common.NewSuccessResponse(data), nil
+}
+
+// attributeNames maps the attribute IDs of an object's type to their names. History is
+// still shown without them, with attributes as the API reported them.
+func attributeNames(client common.ClientInterface, object *models.ObjectScheme) map[string]string {
+	names := make(map[string]string)
+	if object.ObjectType == nil {
+		return names
+	}
+
+	attributes, err := ListAttributes(client, object.ObjectType.ID)
+	if err != nil {
+		logger.Warning("Could not resolve attribute names for object type %s: %v", object.ObjectType.ID, err)
+		return names
+	}
+	for _, attribute := range attributes {
+		names[attribute.ID] = attribute.Name
+	}
+	return names
+}
+
+func newHistoryEntry(h *models.ObjectHistoryScheme, names map[string]string) *historyEntry {
+	entry := &historyEntry{
+		ID:        h.ID,
+		Created:   h.Created,
+		Attribute: h.AffectedAttribute,
+		OldValue:  h.OldValue,
+		NewValue:  h.NewValue,
+		Type:      h.Type,
+	}
+	if h.Actor != nil {
+		entry.Actor = firstNonEmpty(h.Actor.DisplayName, h.Actor.Name, h.Actor.Key)
+		entry.ActorEmail = h.Actor.EmailAddress
+	}
+
+	// The API reports some attributes by ID
+	if name, ok := names[h.AffectedAttribute]; ok {
+		entry.Attribute, entry.AttributeID = name, h.AffectedAttribute
+	} else {
+		for id, name := range names {
+			if strings.EqualFold(name, h.AffectedAttribute) {
+				entry.AttributeID = id
+				break
+			}
+		}
+	}
+
+	switch {
+	case h.AffectedAttribute == "":
+		entry.Change = changeEvent
+	case h.OldValue == "":
+		entry.Change = changeAdded
+	case h.NewValue == "":
+		entry.Change = changeRemoved
+	default:
+		entry.Change = changeChanged
+	}
+	return entry
+}
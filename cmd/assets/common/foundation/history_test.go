@@ -0,0 +1,78 @@
+package foundation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+)
+
+func TestGetObjectHistory(t *testing.T) {
+	client := newArchiveClient()
+	client.SearchResults = []*models.ObjectScheme{client.Objects["100"]}
+	ada := &models.ObjectHistoryActorScheme{DisplayName: "Ada Lovelace", EmailAddress: "ada@example.com"}
+	client.History["100"] = []*models.ObjectHistoryScheme{
+		{ID: "4", Created: "2025-08-30T10:00:00.000Z", Actor: ada, AffectedAttribute: "53", OldValue: "In use", NewValue: "Retired", Type: 2},
+		{ID: "3", Created: "2025-08-29T10:00:00.000Z", Actor: ada, AffectedAttribute: "Name", OldValue: "dev-box", NewValue: "", Type: 2},
+		{ID: "2", Created: "2025-08-28T10:00:00.000Z", Actor: ada, AffectedAttribute: "53", OldValue: "", NewValue: "In use", Type: 2},
+		{ID: "1", Created: "2025-08-27T10:00:00.000Z", Actor: &models.ObjectHistoryActorScheme{Name: "automation"}, Type: 0},
+	}
+
+	response, err := GetObjectHistory(client, common.ObjectHistoryParams{ID: "HW-100"})
+	if err != nil || !response.Success {
+		t.Fatalf("GetObjectHistory failed: %v %s", err, response.Error)
+	}
+	data := response.Data.(map[string]interface{})
+	entries := data["history"].([]*historyEntry)
+	if data["object_id"] != "100" || data["object_key"] != "HW-100" || data["total"] != 4 || len(entries) != 4 {
+		t.Fatalf("history data = %+v", data)
+	}
+
+	// Attributes are named, whether the API reported them by ID or by name
+	want := []struct{ attribute, attributeID, change, actor string }{
+		{"Status", "53", changeChanged, "Ada Lovelace"},
+		{"Name", "52", changeRemoved, "Ada Lovelace"},
+		{"Status", "53", changeAdded, "Ada Lovelace"},
+		{"", "", changeEvent, "automation"},
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Attribute != w.attribute || e.AttributeID != w.attributeID || e.Change != w.change || e.Actor != w.actor {
+			t.Errorf("entry %d = %+v, want %+v", i, e, w)
+		}
+	}
+	if entries[0].OldValue != "In use" || entries[0].NewValue != "Retired" || entries[0].ActorEmail != "ada@example.com" {
+		t.Errorf("entry 0 = %+v", entries[0])
+	}
+
+	// Filtered by attribute name or ID, newest first
+	for _, attribute := range []string{"status", "53"} {
+		response, _ = GetObjectHistory(client, common.ObjectHistoryParams{ID: "100", Attribute: attribute, Limit: 1})
+		entries = response.Data.(map[string]interface{})["history"].([]*historyEntry)
+		if len(entries) != 1 || entries[0].ID != "4" {
+			t.Errorf("history of %s = %+v, want the newest Status change", attribute, entries)
+		}
+	}
+}
+
+func TestGetObjectHistoryErrors(t *testing.T) {
+	client := newArchiveClient()
+	client.Failures["GetObjectHistory"] = "history unavailable"
+
+	tests := []struct {
+		params    common.ObjectHistoryParams
+		wantError string
+	}{
+		{params: common.ObjectHistoryParams{}, wantError: "object ID is required"},
+		{params: common.ObjectHistoryParams{ID: "100", Limit: -1}, wantError: "limit must be 0 or greater"},
+		{params: common.ObjectHistoryParams{ID: "999"}, wantError: "not found"},
+		{params: common.ObjectHistoryParams{ID: "100"}, wantError: "history unavailable"},
+	}
+	for _, tt := range tests {
+		response, err := GetObjectHistory(client, tt.params)
+		if err != nil || response.Success || !strings.Contains(response.Error, tt.wantError) {
+			t.Errorf("GetObjectHistory(%+v) = %+v, %v; want error %q", tt.params, response, err, tt.wantError)
+		}
+	}
+}
@@ -2,12 +2,26 @@ package foundation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
 	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/internal/archive"
+	"github.com/aaronsb/atlassian-assets/internal/bulk"
+	apiclient "github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/property"
+	"github.com/aaronsb/atlassian-assets/internal/resolver"
+	"github.com/aaronsb/atlassian-assets/internal/rules"
 )
 
+// ErrDeleteDisabled is returned when the configuration does not allow deletions
+var ErrDeleteDisabled = errors.New("delete operations are disabled - set ATLASSIAN_ASSETS_ALLOW_DELETE=true in environment to enable")
+
+// Number of objects fetched per page when collecting every match of a query
+const defaultPageSize = 100
+
 // SearchObjects performs asset search using either simple terms or AQL
 func SearchObjects(client common.ClientInterface, params common.SearchParams) (*common.Response, error) {
 	// Validate parameters
@@ -33,8 +47,11 @@ func SearchObjects(client common.ClientInterface, params common.SearchParams) (*
 		queryType = "aql"
 	} else {
 		// Build AQL from simple search terms
-		var err error
-		finalQuery, err = buildSimpleSearchQuery(params.Simple, params.Schema, params.Type, params.Status, params.Owner)
+		owner, err := resolveOwner(ctx, client, params.Owner)
+		if err != nil {
+			return common.NewErrorResponse(err), nil
+		}
+		finalQuery, err = buildSimpleSearchQuery(params.Simple, params.Schema, params.Type, params.Status, owner)
 		if err != nil {
 			return common.NewErrorResponse(fmt.Errorf("failed to build search query: %w", err)), nil
 		}
@@ -57,7 +74,7 @@ func SearchObjects(client common.ClientInterface, params common.SearchParams) (*
 		return common.NewSuccessResponse(responseData), nil
 	}
 
-	return common.NewErrorResponse(fmt.Errorf(response.Error)), nil
+	return common.NewErrorResponse(errors.New(response.Error)), nil
 }
 
 // ListObjects lists objects in a schema with optional filtering
@@ -92,7 +109,7 @@ func ListObjects(client common.ClientInterface, params common.ListParams) (*comm
 		return common.NewSuccessResponse(responseData), nil
 	}
 
-	return common.NewErrorResponse(fmt.Errorf(response.Error)), nil
+	return common.NewErrorResponse(errors.New(response.Error)), nil
 }
 
 // GetObject retrieves a specific object by ID
@@ -101,9 +118,13 @@ func GetObject(client common.ClientInterface, params common.GetParams) (*common.
 	if params.ID == "" {
 		return common.NewErrorResponse(fmt.Errorf("object ID is required")), nil
 	}
+	objectID, err := resolveObjectID(client, params.ID)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
 
 	ctx := context.Background()
-	response, err := client.GetObject(ctx, params.ID)
+	response, err := client.GetObject(ctx, objectID)
 	if err != nil {
 		return common.NewErrorResponse(fmt.Errorf("failed to get object: %w", err)), nil
 	}
@@ -112,13 +133,13 @@ func GetObject(client common.ClientInterface, params common.GetParams) (*common.
 	if response.Success {
 		responseData := map[string]interface{}{
 			"object": response.Data,
-			"object_id": params.ID,
+			"object_id": objectID,
 			"operation": "get_object",
 		}
 		return common.NewSuccessResponse(responseData), nil
 	}
 
-	return common.NewErrorResponse(fmt.Errorf(response.Error)), nil
+	return common.NewErrorResponse(errors.New(response.Error)), nil
 }
 
 // CreateObject creates a new object instance
@@ -131,8 +152,27 @@ func CreateObject(client common.ClientInterface, params common.CreateObjectParam
 		return common.NewErrorResponse(fmt.Errorf("attributes are required")), nil
 	}
 
+	// Refuse objects that break an error-severity validation rule
+	violations, err := CheckRules(client, params.ObjectTypeID, params.Attributes)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+	if rules.HasErrors(violations) {
+		return common.NewErrorResponse(fmt.Errorf("object violates validation rules: %s", describeViolations(violations))), nil
+	}
+
+	// Check each value against its attribute type and send it in normalized form
 	ctx := context.Background()
-	response, err := client.CreateObject(ctx, params.ObjectTypeID, params.Attributes)
+	resolved, resolveErrs := newPropertyResolver(client).ResolveObjectProperties(ctx, params.ObjectTypeID, params.Attributes)
+	if len(resolveErrs) > 0 {
+		messages := make([]string, 0, len(resolveErrs))
+		for _, err := range resolveErrs {
+			messages = append(messages, err.Error())
+		}
+		return common.NewErrorResponse(fmt.Errorf("invalid attributes: %s", strings.Join(messages, "; "))), nil
+	}
+
+	response, err := client.CreateObject(ctx, params.ObjectTypeID, property.PayloadAttributes(resolved))
 	if err != nil {
 		return common.NewErrorResponse(fmt.Errorf("failed to create object: %w", err)), nil
 	}
@@ -142,11 +182,39 @@ func CreateObject(client common.ClientInterface, params common.CreateObjectParam
 		responseData := response.Data.(map[string]interface{})
 		responseData["object_type_id"] = params.ObjectTypeID
 		responseData["operation"] = "create_object"
+		if len(violations) > 0 {
+			responseData["rule_warnings"] = violations
+		}
 		
 		return common.NewSuccessResponse(responseData), nil
 	}
 
-	return common.NewErrorResponse(fmt.Errorf(response.Error)), nil
+	return common.NewErrorResponse(errors.New(response.Error)), nil
+}
+
+// CheckRules evaluates the user-defined validation rules against object data
+func CheckRules(client common.ClientInterface, objectTypeID string, data map[string]interface{}) ([]rules.Violation, error) {
+	ruleSet, err := rules.LoadDefault()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load validation rules: %w", err)
+	}
+
+	violations, err := ruleSet.Check(context.Background(), client, objectTypeID, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate validation rules: %w", err)
+	}
+	return violations, nil
+}
+
+// describeViolations joins the messages of error-severity violations
+func describeViolations(violations []rules.Violation) string {
+	var messages []string
+	for _, v := range violations {
+		if v.Severity == rules.SeverityError {
+			messages = append(messages, fmt.Sprintf("[%s] %s", v.Code, v.Message))
+		}
+	}
+	return strings.Join(messages, "; ")
 }
 
 // DeleteObject deletes an object by ID
@@ -156,8 +224,16 @@ func DeleteObject(client common.ClientInterface, params common.DeleteObjectParam
 		return common.NewErrorResponse(fmt.Errorf("object ID is required")), nil
 	}
 
+	if !client.IsDeleteAllowed() {
+		return common.NewErrorResponse(ErrDeleteDisabled), nil
+	}
+	objectID, err := resolveObjectID(client, params.ID)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+
 	ctx := context.Background()
-	response, err := client.DeleteObject(ctx, params.ID)
+	response, err := client.DeleteObject(ctx, objectID)
 	if err != nil {
 		return common.NewErrorResponse(fmt.Errorf("failed to delete object: %w", err)), nil
 	}
@@ -165,27 +241,404 @@ func DeleteObject(client common.ClientInterface, params common.DeleteObjectParam
 	// Add metadata
 	if response.Success {
 		responseData := map[string]interface{}{
-			"object_id": params.ID,
+			"object_id": objectID,
 			"operation": "delete_object",
-			"message": fmt.Sprintf("Successfully deleted object %s", params.ID),
+			"message": fmt.Sprintf("Successfully deleted object %s", objectID),
+		}
+		return common.NewSuccessResponse(responseData), nil
+	}
+
+	return common.NewErrorResponse(errors.New(response.Error)), nil
+}
+
+// DeleteInstances deletes a list of object instances, or every instance matched by an
+// AQL query. Deleting by query requires the number of matches to be stated up front
+// and refuses to run when the live count differs or exceeds the limit.
+func DeleteInstances(client common.ClientInterface, params common.DeleteInstancesParams) (*common.Response, error) {
+	if !client.IsDeleteAllowed() {
+		return common.NewErrorResponse(ErrDeleteDisabled), nil
+	}
+
+	// Validate parameters
+	if len(params.IDs) == 0 && params.Query == "" {
+		return common.NewErrorResponse(fmt.Errorf("either object IDs or a query is required")), nil
+	}
+	if params.Query != "" && params.ExpectCount == nil {
+		return common.NewErrorResponse(fmt.Errorf("deleting by query requires the expected number of matching objects")), nil
+	}
+	if params.Limit < 1 {
+		params.Limit = 10
+	}
+	if params.PageSize < 1 {
+		params.PageSize = defaultPageSize
+	}
+
+	// A report that already has results resumes an interrupted run
+	report, err := openReport(params.Report, "delete_instances", params.Query)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+	if report != nil {
+		defer report.Close()
+	}
+	alreadyDeleted := 0
+	if report != nil {
+		alreadyDeleted = report.DoneCount()
+	}
+
+	ctx := context.Background()
+	var instanceIDs []string
+
+	if len(params.IDs) > 0 {
+		instanceIDs, err = ResolveObjectIDs(client, params.IDs)
+		if err != nil {
+			return common.NewErrorResponse(err), nil
+		}
+	} else {
+		objects, total, err := searchAll(ctx, client, params.Query, params.PageSize, func(total int) error {
+			if total == 0 && alreadyDeleted == 0 {
+				return fmt.Errorf("no instances found to delete")
+			}
+			if total+alreadyDeleted != *params.ExpectCount {
+				if alreadyDeleted > 0 {
+					return fmt.Errorf("query matches %d objects and %d were deleted before, expected %d in total; nothing was deleted", total, alreadyDeleted, *params.ExpectCount)
+				}
+				return fmt.Errorf("query matches %d objects, expected %d; nothing was deleted", total, *params.ExpectCount)
+			}
+			if total > params.Limit {
+				return fmt.Errorf("query matches %d objects, more than the limit of %d; raise the limit to delete them all", total, params.Limit)
+			}
+			if !params.Confirm {
+				return fmt.Errorf("deletion of %d instances requires explicit confirmation", total)
+			}
+			return nil
+		})
+		if err != nil {
+			return common.NewErrorResponse(err), nil
+		}
+		if len(objects) != total {
+			return common.NewErrorResponse(fmt.Errorf("query matches changed while they were read (%d expected, %d read); nothing was deleted", total, len(objects))), nil
+		}
+		for _, object := range objects {
+			instanceIDs = append(instanceIDs, object.ID)
+		}
+	}
+
+	if len(instanceIDs) == 0 && alreadyDeleted == 0 {
+		return common.NewErrorResponse(fmt.Errorf("no instances found to delete")), nil
+	}
+
+	if !params.Confirm {
+		return common.NewErrorResponse(fmt.Errorf("deletion of %d instances requires explicit confirmation", len(instanceIDs))), nil
+	}
+
+	// Skip what an earlier run already deleted
+	var pending []string
+	skipped := 0
+	for _, id := range instanceIDs {
+		if report != nil && report.Done(id) {
+			skipped++
+			continue
+		}
+		pending = append(pending, id)
+	}
+	if report != nil {
+		run := bulk.Run{Operation: "delete_instances", Query: params.Query}
+		if params.ExpectCount != nil {
+			run.ExpectCount = *params.ExpectCount
+		}
+		if err := report.Start(run); err != nil {
+			return common.NewErrorResponse(err), nil
+		}
+	}
+
+	// Snapshot each object into the archive before deleting it, so it can be restored
+	var store *archive.Archive
+	var index *attributeIndex
+	if params.Archive != "" {
+		store = archive.Open(params.Archive)
+		index = newAttributeIndex(newPropertyResolver(client))
+	}
+
+	outcomes := make(map[string]error, len(pending))
+	var reportErr error
+	bulk.Each(pending, params.Concurrency, func(instanceID string) error {
+		return deleteInstance(ctx, client, store, index, instanceID)
+	}, func(instanceID string, err error) {
+		outcomes[instanceID] = err
+		if report != nil {
+			if rerr := report.Record(instanceID, err); rerr != nil && reportErr == nil {
+				reportErr = rerr
+			}
+		}
+		if params.Progress != nil {
+			params.Progress(len(outcomes), len(pending))
+		}
+	})
+
+	var errs []string
+	var deletedIDs []string
+	for _, instanceID := range pending {
+		if err := outcomes[instanceID]; err != nil {
+			errs = append(errs, fmt.Sprintf("Instance %s: %v", instanceID, err))
+			continue
+		}
+		deletedIDs = append(deletedIDs, instanceID)
+	}
+	if reportErr != nil {
+		errs = append(errs, reportErr.Error())
+	}
+
+	result := map[string]interface{}{
+		"action":        "delete_instances",
+		"requested_ids": instanceIDs,
+		"deleted_ids":   deletedIDs,
+		"deleted_count": len(deletedIDs),
+		"total_count":   len(instanceIDs),
+		"confirm":       params.Confirm,
+		"query":         params.Query,
+		"success":       len(errs) == 0,
+	}
+	if params.ExpectCount != nil {
+		result["expect_count"] = *params.ExpectCount
+	}
+	if report != nil {
+		result["report"] = report.Path()
+		result["skipped_count"] = skipped
+		result["previously_deleted_count"] = alreadyDeleted
+	}
+	if store != nil {
+		result["archive"] = store.Path()
+	}
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+
+	return common.NewSuccessResponse(result), nil
+}
+
+// openReport opens the report of a bulk operation, or returns nil when no report file is
+// given. A report written for another operation or query is refused.
+func openReport(path, operation, query string) (*bulk.Report, error) {
+	if path == "" {
+		return nil, nil
+	}
+	report, err := bulk.OpenReport(path)
+	if err != nil {
+		return nil, err
+	}
+	if run := report.Run(); run != nil && (run.Operation != operation || run.Query != query) {
+		report.Close()
+		return nil, fmt.Errorf("report %s belongs to a different run (%s %q)", path, run.Operation, run.Query)
+	}
+	return report, nil
+}
+
+// deleteInstance deletes one object, snapshotting it into the archive first when one is given
+func deleteInstance(ctx context.Context, client common.ClientInterface, store *archive.Archive, index *attributeIndex, instanceID string) error {
+	if store != nil {
+		tombstone, err := snapshotObject(ctx, client, index, instanceID)
+		if err == nil {
+			err = store.Add(tombstone)
+		}
+		if err != nil {
+			return fmt.Errorf("not deleted, archiving failed: %w", err)
+		}
+	}
+
+	response, err := client.DeleteObject(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	if !response.Success {
+		return errors.New(response.Error)
+	}
+	return nil
+}
+
+// searchAll collects every object matching a query, page by page. check is given the
+// live match count after the first page and can stop the search.
+func searchAll(ctx context.Context, client common.ClientInterface, query string, pageSize int, check func(total int) error) ([]*models.ObjectScheme, int, error) {
+	var matches []*models.ObjectScheme
+	total := -1
+
+	for offset := 0; total < 0 || offset < total; offset += pageSize {
+		response, err := client.SearchObjectsWithPagination(ctx, query, pageSize, offset)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to search instances: %w", err)
+		}
+		if !response.Success {
+			return nil, 0, fmt.Errorf("search failed: %s", response.Error)
+		}
+
+		objects, err := ObjectsFromData(response.Data)
+		if err != nil {
+			return nil, 0, err
+		}
+		if total < 0 {
+			total = len(objects)
+			if t, ok := TotalFromData(response.Data); ok {
+				total = t
+			}
+			if err := check(total); err != nil {
+				return nil, 0, err
+			}
+		}
+		if len(objects) == 0 {
+			break
+		}
+		matches = append(matches, objects...)
+	}
+	return matches, total, nil
+}
+
+// ObjectsFromData extracts the typed object list from search or list response data
+func ObjectsFromData(data interface{}) ([]*models.ObjectScheme, error) {
+	responseData, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected object response type: %T", data)
+	}
+
+	switch objects := responseData["objects"].(type) {
+	case []*models.ObjectScheme:
+		return objects, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected objects type: %T", objects)
+	}
+}
+
+// TotalFromData extracts the match count from search or list response data. The count
+// is an int from the client, but a float64 or int64 once the data has been through JSON.
+func TotalFromData(data interface{}) (int, bool) {
+	responseData, ok := data.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+
+	switch total := responseData["total"].(type) {
+	case int:
+		return total, true
+	case int64:
+		return int(total), true
+	case float64:
+		return int(total), true
+	default:
+		return 0, false
+	}
+}
+
+// RemoveRelationship removes a relationship from an object, by ID or by type and target
+func RemoveRelationship(client common.ClientInterface, params common.RemoveRelationshipParams) (*common.Response, error) {
+	// Validate parameters
+	if params.ObjectID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object ID is required")), nil
+	}
+	if params.RelationshipID == "" && (params.RelationshipType == "" || params.TargetID == "") {
+		return common.NewErrorResponse(fmt.Errorf("either relationship ID or both relationship type and target ID are required")), nil
+	}
+	if !params.Confirm {
+		return common.NewErrorResponse(fmt.Errorf("relationship removal requires explicit confirmation")), nil
+	}
+	objectID, err := resolveObjectID(client, params.ObjectID)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+	params.ObjectID = objectID
+	if params.TargetID != "" {
+		if params.TargetID, err = resolveObjectID(client, params.TargetID); err != nil {
+			return common.NewErrorResponse(err), nil
+		}
+	}
+
+	ctx := context.Background()
+	var response *apiclient.Response
+	if params.RelationshipID != "" {
+		response, err = client.RemoveRelationship(ctx, params.ObjectID, params.RelationshipID)
+	} else {
+		response, err = client.RemoveRelationshipByType(ctx, params.ObjectID, params.RelationshipType, params.TargetID)
+	}
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to remove relationship: %w", err)), nil
+	}
+
+	if response.Success {
+		responseData := map[string]interface{}{
+			"action":            "remove_relationship",
+			"object_id":         params.ObjectID,
+			"relationship_id":   params.RelationshipID,
+			"relationship_type": params.RelationshipType,
+			"target_id":         params.TargetID,
+			"confirm":           params.Confirm,
+			"removed":           true,
 		}
 		return common.NewSuccessResponse(responseData), nil
 	}
 
-	return common.NewErrorResponse(fmt.Errorf(response.Error)), nil
+	return common.NewErrorResponse(errors.New(response.Error)), nil
 }
 
-// UpdateObject updates an existing object (placeholder for future implementation)
-func UpdateObject(client common.ClientInterface, params common.UpdateObjectParams) (*common.Response, error) {
-	// This would need to be implemented with the actual update functionality
-	// For now, return a placeholder response
-	return common.NewSuccessResponse(map[string]interface{}{
-		"action": "update_object",
-		"id":     params.ID,
-		"data":   params.Data,
-		"status": "not_implemented",
-		"message": "Object update functionality will be implemented",
-	}), nil
+// RemoveProperty clears property values from an object, by ID or by name
+func RemoveProperty(client common.ClientInterface, params common.RemovePropertyParams) (*common.Response, error) {
+	// Validate parameters
+	if params.ObjectID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object ID is required")), nil
+	}
+	if params.PropertyID == "" && len(params.PropertyNames) == 0 {
+		return common.NewErrorResponse(fmt.Errorf("either property ID or property names are required")), nil
+	}
+	if !params.Confirm {
+		return common.NewErrorResponse(fmt.Errorf("property removal requires explicit confirmation")), nil
+	}
+	objectID, err := resolveObjectID(client, params.ObjectID)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+	params.ObjectID = objectID
+
+	ctx := context.Background()
+	var removedProperties []string
+	var errs []string
+
+	if params.PropertyID != "" {
+		response, err := client.RemoveProperty(ctx, params.ObjectID, params.PropertyID)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Property %s: %v", params.PropertyID, err))
+		} else if !response.Success {
+			errs = append(errs, fmt.Sprintf("Property %s: %v", params.PropertyID, response.Error))
+		} else {
+			removedProperties = append(removedProperties, params.PropertyID)
+		}
+	} else {
+		for _, name := range params.PropertyNames {
+			name = strings.TrimSpace(name)
+			response, err := client.RemovePropertyByName(ctx, params.ObjectID, name)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Property %s: %v", name, err))
+			} else if !response.Success {
+				errs = append(errs, fmt.Sprintf("Property %s: %v", name, response.Error))
+			} else {
+				removedProperties = append(removedProperties, name)
+			}
+		}
+	}
+
+	responseData := map[string]interface{}{
+		"action":             "remove_property",
+		"object_id":          params.ObjectID,
+		"property_id":        params.PropertyID,
+		"property_names":     params.PropertyNames,
+		"removed_properties": removedProperties,
+		"removed_count":      len(removedProperties),
+		"confirm":            params.Confirm,
+		"success":            len(errs) == 0,
+	}
+	if len(errs) > 0 {
+		responseData["errors"] = errs
+	}
+
+	return common.NewSuccessResponse(responseData), nil
 }
 
 // Helper functions
@@ -234,6 +687,24 @@ func buildSimpleSearchQuery(term, schema, objectType, status, owner string) (str
 	return query, nil
 }
 
+// resolveOwner turns an owner given as an email or display name into the account ID
+// that user attributes are matched on. Owners with no matching Jira user are kept
+// as written, since the Owner attribute may hold plain text.
+func resolveOwner(ctx context.Context, client common.ClientInterface, owner string) (string, error) {
+	if owner == "" {
+		return "", nil
+	}
+
+	accountID, err := resolver.NewDirectory(client).ResolveUser(ctx, owner)
+	switch {
+	case err == nil:
+		return accountID, nil
+	case errors.Is(err, resolver.ErrNotFound):
+		return owner, nil
+	}
+	return "", fmt.Errorf("failed to resolve owner: %w", err)
+}
+
 // buildTermSearchCondition creates AQL search condition with basic patterns
 func buildTermSearchCondition(term string) string {
 	var nameCondition, keyCondition string
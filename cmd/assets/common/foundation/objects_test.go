@@ -0,0 +1,557 @@
+package foundation
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/commontest"
+	"github.com/aaronsb/atlassian-assets/internal/bulk"
+	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/rules"
+)
+
+func TestDeleteInstances(t *testing.T) {
+	count := func(n int) *int { return &n }
+	objects := func(ids ...string) []*models.ObjectScheme {
+		var result []*models.ObjectScheme
+		for _, id := range ids {
+			result = append(result, &models.ObjectScheme{ID: id})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name        string
+		params      common.DeleteInstancesParams
+		allowDelete bool
+		results     []*models.ObjectScheme
+		failures    map[string]string
+		wantError   string
+		wantDeleted []string
+		wantErrors  int
+	}{
+		{
+			name:        "deletes listed IDs",
+			params:      common.DeleteInstancesParams{IDs: []string{"1", " 2 "}, Confirm: true},
+			allowDelete: true,
+			wantDeleted: []string{"1", "2"},
+		},
+		{
+			name:        "deletes objects matched by query",
+			params:      common.DeleteInstancesParams{Query: "Name = temp", ExpectCount: count(2), Confirm: true},
+			allowDelete: true,
+			results:     objects("10", "11"),
+			wantDeleted: []string{"10", "11"},
+		},
+		{
+			name:        "deletes matches across every page",
+			params:      common.DeleteInstancesParams{Query: "Name = temp", ExpectCount: count(5), PageSize: 2, Confirm: true},
+			allowDelete: true,
+			results:     objects("1", "2", "3", "4", "5"),
+			wantDeleted: []string{"1", "2", "3", "4", "5"},
+		},
+		{
+			name:        "requires the expected count with a query",
+			params:      common.DeleteInstancesParams{Query: "Name = temp", Confirm: true},
+			allowDelete: true,
+			results:     objects("10"),
+			wantError:   "requires the expected number of matching objects",
+		},
+		{
+			name:        "refuses when the live count differs",
+			params:      common.DeleteInstancesParams{Query: "Name = temp", ExpectCount: count(3), Confirm: true},
+			allowDelete: true,
+			results:     objects("10", "11"),
+			wantError:   "query matches 2 objects, expected 3",
+		},
+		{
+			name:        "refuses more matches than the limit",
+			params:      common.DeleteInstancesParams{Query: "Name = temp", ExpectCount: count(3), Limit: 2, Confirm: true},
+			allowDelete: true,
+			results:     objects("10", "11", "12"),
+			wantError:   "more than the limit of 2",
+		},
+		{
+			name:        "requires confirmation for a query",
+			params:      common.DeleteInstancesParams{Query: "Name = temp", ExpectCount: count(1)},
+			allowDelete: true,
+			results:     objects("10"),
+			wantError:   "requires explicit confirmation",
+		},
+		{
+			name:        "requires confirmation",
+			params:      common.DeleteInstancesParams{IDs: []string{"1"}},
+			allowDelete: true,
+			wantError:   "requires explicit confirmation",
+		},
+		{
+			name:        "refuses when deletes are disabled",
+			params:      common.DeleteInstancesParams{IDs: []string{"1"}, Confirm: true},
+			allowDelete: false,
+			wantError:   "delete operations are disabled",
+		},
+		{
+			name:        "requires IDs or a query",
+			params:      common.DeleteInstancesParams{Confirm: true},
+			allowDelete: true,
+			wantError:   "either object IDs or a query is required",
+		},
+		{
+			name:        "reports an empty query result",
+			params:      common.DeleteInstancesParams{Query: "Name = none", ExpectCount: count(0), Confirm: true},
+			allowDelete: true,
+			wantError:   "no instances found to delete",
+		},
+		{
+			name:        "reports search failures",
+			params:      common.DeleteInstancesParams{Query: "Name = temp", ExpectCount: count(1), Confirm: true},
+			allowDelete: true,
+			failures:    map[string]string{"SearchObjects": "invalid AQL"},
+			wantError:   "search failed: invalid AQL",
+		},
+		{
+			name:        "collects per-object failures",
+			params:      common.DeleteInstancesParams{IDs: []string{"1"}, Confirm: true},
+			allowDelete: true,
+			failures:    map[string]string{"DeleteObject": "API error: 404"},
+			wantErrors:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := commontest.NewMockClient()
+			client.AllowDelete = tt.allowDelete
+			client.SearchResults = tt.results
+			if tt.failures != nil {
+				client.Failures = tt.failures
+			}
+
+			response, err := DeleteInstances(client, tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				if len(client.DeletedObjects) > 0 {
+					t.Errorf("expected no deletions, got %v", client.DeletedObjects)
+				}
+				return
+			}
+
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+			// Objects are deleted concurrently, in no particular order
+			sort.Strings(client.DeletedObjects)
+			if !reflect.DeepEqual(client.DeletedObjects, tt.wantDeleted) {
+				t.Errorf("deleted %v, want %v", client.DeletedObjects, tt.wantDeleted)
+			}
+
+			data := response.Data.(map[string]interface{})
+			errs, _ := data["errors"].([]string)
+			if len(errs) != tt.wantErrors {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrors, errs)
+			}
+		})
+	}
+}
+
+func TestDeleteInstancesResume(t *testing.T) {
+	expect := 4
+	report := filepath.Join(t.TempDir(), "delete.jsonl")
+
+	// A first run fails for one object and is cut short before the others
+	client := commontest.NewMockClient()
+	client.SearchResults = []*models.ObjectScheme{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}}
+	client.Failures["DeleteObject"] = "API error: 500"
+	params := common.DeleteInstancesParams{Query: "Name = temp", ExpectCount: &expect, Limit: 10, Confirm: true, Report: report}
+	if response, _ := DeleteInstances(client, params); !response.Success || response.Data.(map[string]interface{})["deleted_count"] != 0 {
+		t.Fatalf("first run = %+v, want every deletion to fail", response)
+	}
+
+	// Record two deletions by hand, as if the next run was interrupted after them
+	r, err := bulk.OpenReport(report)
+	if err != nil {
+		t.Fatalf("OpenReport failed: %v", err)
+	}
+	r.Record("1", nil)
+	r.Record("2", nil)
+	r.Close()
+
+	// The live query now only matches what is left; with the report they add up
+	client = commontest.NewMockClient()
+	client.SearchResults = []*models.ObjectScheme{{ID: "3"}, {ID: "4"}}
+	var progress []int
+	params.Progress = func(done, total int) { progress = append(progress, done*10+total) }
+	response, _ := DeleteInstances(client, params)
+	if !response.Success {
+		t.Fatalf("resumed run failed: %s", response.Error)
+	}
+	data := response.Data.(map[string]interface{})
+	if data["deleted_count"] != 2 || data["previously_deleted_count"] != 2 || data["report"] != report {
+		t.Errorf("resumed run = %v", data)
+	}
+	if !reflect.DeepEqual(progress, []int{12, 22}) {
+		t.Errorf("progress = %v, want 1/2 then 2/2", progress)
+	}
+
+	// Listed IDs that the report records as deleted are skipped
+	client = commontest.NewMockClient()
+	response, _ = DeleteInstances(client, common.DeleteInstancesParams{IDs: []string{"2", "5"}, Confirm: true, Report: filepath.Join(t.TempDir(), "ids.jsonl")})
+	if response.Data.(map[string]interface{})["skipped_count"] != 0 {
+		t.Errorf("fresh report skipped objects: %v", response.Data)
+	}
+	r, _ = bulk.OpenReport(report)
+	r.Close()
+	response, _ = DeleteInstances(client, common.DeleteInstancesParams{IDs: []string{"2", "5"}, Confirm: true, Report: report})
+	if response.Success || !strings.Contains(response.Error, "belongs to a different run") {
+		t.Errorf("reusing a query report for IDs = %+v, want it refused", response)
+	}
+}
+
+func TestObjectsFromData(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      interface{}
+		wantCount int
+		wantErr   bool
+	}{
+		{"typed objects", map[string]interface{}{"objects": []*models.ObjectScheme{{ID: "1"}, {ID: "2"}}}, 2, false},
+		{"missing objects", map[string]interface{}{}, 0, false},
+		{"untyped objects", map[string]interface{}{"objects": []interface{}{}}, 0, true},
+		{"not a map", "objects", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := ObjectsFromData(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(objects) != tt.wantCount {
+				t.Errorf("got %d objects, want %d", len(objects), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestTotalFromData(t *testing.T) {
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"total": 240, "objects": []}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		data      interface{}
+		wantTotal int
+		wantOK    bool
+	}{
+		{"int", map[string]interface{}{"total": 240}, 240, true},
+		{"int64", map[string]interface{}{"total": int64(240)}, 240, true},
+		{"decoded from JSON", decoded, 240, true},
+		{"missing total", map[string]interface{}{}, 0, false},
+		{"not a map", "total", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, ok := TotalFromData(tt.data)
+			if total != tt.wantTotal || ok != tt.wantOK {
+				t.Errorf("TotalFromData = %d, %v; want %d, %v", total, ok, tt.wantTotal, tt.wantOK)
+			}
+		})
+	}
+}
+
+// decodedTotalClient reports search totals as JSON decoding leaves them
+type decodedTotalClient struct {
+	*commontest.MockClient
+}
+
+func (c decodedTotalClient) SearchObjectsWithPagination(ctx context.Context, query string, limit int, offset int) (*client.Response, error) {
+	response, err := c.MockClient.SearchObjectsWithPagination(ctx, query, limit, offset)
+	if err != nil || !response.Success {
+		return response, err
+	}
+
+	data := response.Data.(map[string]interface{})
+	encoded, _ := json.Marshal(map[string]interface{}{"total": data["total"]})
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, err
+	}
+	data["total"] = decoded["total"]
+	return response, nil
+}
+
+func TestSearchAllDecodedTotal(t *testing.T) {
+	mock := commontest.NewMockClient()
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		mock.SearchResults = append(mock.SearchResults, &models.ObjectScheme{ID: id})
+	}
+
+	var checked int
+	matches, total, err := searchAll(context.Background(), decodedTotalClient{mock}, "Name = temp", 2, func(total int) error {
+		checked = total
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("searchAll failed: %v", err)
+	}
+	if len(matches) != 5 || total != 5 || checked != 5 {
+		t.Errorf("searchAll = %d matches, total %d, checked %d; want 5 of each", len(matches), total, checked)
+	}
+}
+
+func TestCreateObjectValidationRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	content := `
+object_type: Laptops
+rules:
+  - id: asset-tag-length
+    kind: length
+    field: asset_tag
+    min: 3
+  - id: test-serial
+    kind: regex
+    field: serial_number
+    pattern: (?i)test
+    negate: true
+    severity: warning
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ATLASSIAN_ASSETS_RULES", path)
+
+	tests := []struct {
+		name         string
+		attributes   map[string]interface{}
+		wantError    string
+		wantWarnings int
+	}{
+		{"creates a valid object", map[string]interface{}{"name": "LAP-1", "asset_tag": "LAP-001"}, "", 0},
+		{"refuses error violations", map[string]interface{}{"name": "LAP-1", "asset_tag": "L1"}, "ASSET_TAG_LENGTH", 0},
+		{"reports warnings", map[string]interface{}{"name": "LAP-1", "serial_number": "test-1"}, "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := commontest.NewMockClient()
+			client.AddObjectType(&models.ObjectTypeScheme{ID: "23", Name: "Laptops", ObjectSchemaID: "6"},
+				textAttribute("1", "Name"), textAttribute("2", "Asset Tag"), textAttribute("3", "Serial Number"))
+
+			response, err := CreateObject(client, common.CreateObjectParams{ObjectTypeID: "23", Attributes: tt.attributes})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				if len(client.CreatedObjects["23"]) > 0 {
+					t.Errorf("expected no objects created, got %v", client.CreatedObjects["23"])
+				}
+				return
+			}
+
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+			data := response.Data.(map[string]interface{})
+			warnings, _ := data["rule_warnings"].([]rules.Violation)
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("got %d rule warnings, want %d: %v", len(warnings), tt.wantWarnings, warnings)
+			}
+		})
+	}
+}
+
+func textAttribute(id, name string) *models.ObjectTypeAttributeScheme {
+	return &models.ObjectTypeAttributeScheme{ID: id, Name: name, Editable: true, MaximumCardinality: 1,
+		DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: 0, Name: "Text"}}
+}
+
+func TestCreateObjectTypedAttributes(t *testing.T) {
+	t.Setenv("ATLASSIAN_ASSETS_RULES", t.TempDir())
+
+	typed := func(id, name string, defaultType int, maxCardinality int) *models.ObjectTypeAttributeScheme {
+		return &models.ObjectTypeAttributeScheme{ID: id, Name: name, Editable: true, MaximumCardinality: maxCardinality,
+			DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: defaultType}}
+	}
+	attributes := []*models.ObjectTypeAttributeScheme{
+		{ID: "1", Name: "Name", Editable: true, MinimumCardinality: 1, MaximumCardinality: 1},
+		typed("2", "RAM GB", 1, 1),
+		typed("3", "Managed", 2, 1),
+		typed("4", "Purchased", 4, 1),
+		typed("5", "Address", 11, 1),
+		{ID: "6", Name: "Tags", Editable: true, MaximumCardinality: 2,
+			DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: 10, Name: "Select"}, Options: "Linux, Windows, Mac"},
+		{ID: "7", Name: "Owner", Editable: true, MaximumCardinality: 1, Type: 2},
+		{ID: "8", Name: "Team", Editable: true, MaximumCardinality: 1, Type: 4},
+	}
+
+	tests := []struct {
+		name       string
+		attributes map[string]interface{}
+		want       map[string]interface{}
+		wantError  string
+	}{
+		{
+			name: "normalizes typed values",
+			attributes: map[string]interface{}{
+				"Name": "lap-1", "ram_gb": float64(16), "managed": "yes", "Purchased": "2024-03-01T10:00:00Z",
+				"address": "10.0.0.1", "tags": []interface{}{"linux", "MAC"}, "owner": "5b10ac8d82e05b22cc7d4ef5",
+			},
+			want: map[string]interface{}{
+				"1": "lap-1", "2": "16", "3": "true", "4": "2024-03-01", "5": "10.0.0.1",
+				"6": []string{"Linux", "Mac"}, "7": "5b10ac8d82e05b22cc7d4ef5",
+			},
+		},
+		{"accepts attribute IDs", map[string]interface{}{"1": "lap-1", "2": 8}, map[string]interface{}{"1": "lap-1", "2": "8"}, ""},
+		{"rejects a fractional integer", map[string]interface{}{"Name": "x", "RAM GB": 1.5}, nil, "invalid integer"},
+		{"rejects a bad boolean", map[string]interface{}{"Name": "x", "Managed": "maybe"}, nil, "invalid boolean"},
+		{"rejects a bad IP address", map[string]interface{}{"Name": "x", "Address": "10.0.0.300"}, nil, "invalid IP address"},
+		{"rejects too many values", map[string]interface{}{"Name": "x", "Tags": []interface{}{"Linux", "Mac", "Windows"}}, nil, "too many values"},
+		{"rejects several values for a single-value attribute", map[string]interface{}{"Name": []string{"a", "b"}}, nil, "at most 1 allowed"},
+		{
+			name:       "resolves users and groups",
+			attributes: map[string]interface{}{"Name": "x", "Owner": "ADA@example.com", "Team": "IT-Ops"},
+			want:       map[string]interface{}{"1": "x", "7": "5b10ac8d82e05b22cc7d4ef5", "8": "it-ops"},
+		},
+		{"resolves a user by display name", map[string]interface{}{"Name": "x", "Owner": "ada lovelace"}, map[string]interface{}{"1": "x", "7": "5b10ac8d82e05b22cc7d4ef5"}, ""},
+		{"rejects an ambiguous user", map[string]interface{}{"Name": "x", "Owner": "Alan Turing"}, nil, "matches 2 Jira users"},
+		{"rejects an unknown user", map[string]interface{}{"Name": "x", "Owner": "grace@example.com"}, nil, "no Jira user matches 'grace@example.com'"},
+		{"rejects an unknown group", map[string]interface{}{"Name": "x", "Team": "it"}, nil, "no Jira group named 'it'"},
+		{"requires mandatory attributes", map[string]interface{}{"RAM GB": 8}, nil, "required field is missing"},
+		{"rejects unknown attributes", map[string]interface{}{"Name": "x", "Colour": "red"}, nil, "unknown property: Colour"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := commontest.NewMockClient()
+			client.AddObjectType(&models.ObjectTypeScheme{ID: "23", Name: "Laptops", ObjectSchemaID: "6"}, attributes...)
+			client.Users = []*models.UserScheme{
+				{AccountID: "5b10ac8d82e05b22cc7d4ef5", DisplayName: "Ada Lovelace", EmailAddress: "ada@example.com"},
+				{AccountID: "5b10ac8d82e05b22cc7d4ef6", DisplayName: "Alan Turing"},
+				{AccountID: "5b10ac8d82e05b22cc7d4ef7", DisplayName: "Alan Turing"},
+			}
+			client.Groups = []*models.GroupDetailScheme{{Name: "it-ops"}, {Name: "it-admins"}}
+
+			response, err := CreateObject(client, common.CreateObjectParams{ObjectTypeID: "23", Attributes: tt.attributes})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				return
+			}
+
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+			created := client.CreatedObjects["23"]
+			if len(created) != 1 || !reflect.DeepEqual(created[0], tt.want) {
+				t.Errorf("created %v, want %v", created, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchObjectsOwnerFilter(t *testing.T) {
+	tests := []struct {
+		owner     string
+		wantQuery string
+		wantError string
+	}{
+		{"ada@example.com", `Owner = "5b10ac8d82e05b22cc7d4ef5"`, ""},
+		{"5b10ac8d82e05b22cc7d4ef9", `Owner = "5b10ac8d82e05b22cc7d4ef9"`, ""},
+		{"Facilities", `Owner = "Facilities"`, ""},
+		{"Alan Turing", "", "matches 2 Jira users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.owner, func(t *testing.T) {
+			client := commontest.NewMockClient()
+			client.Users = []*models.UserScheme{
+				{AccountID: "5b10ac8d82e05b22cc7d4ef5", DisplayName: "Ada Lovelace", EmailAddress: "ada@example.com"},
+				{AccountID: "5b10ac8d82e05b22cc7d4ef6", DisplayName: "Alan Turing"},
+				{AccountID: "5b10ac8d82e05b22cc7d4ef7", DisplayName: "Alan Turing"},
+			}
+
+			response, err := SearchObjects(client, common.SearchParams{Simple: "laptop", Owner: tt.owner, Limit: 10})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				return
+			}
+			if len(client.SearchQueries) != 1 || !strings.Contains(client.SearchQueries[0], tt.wantQuery) {
+				t.Errorf("queries = %v, want one containing %s", client.SearchQueries, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestObjectKeysAreResolved(t *testing.T) {
+	laptop := &models.ObjectScheme{
+		ID:         "1001",
+		ObjectKey:  "HW-1",
+		Label:      "Laptop 42",
+		ObjectType: &models.ObjectTypeScheme{ID: "141", Name: "Laptops", ObjectSchemaID: "7"},
+	}
+	client := commontest.NewMockClient()
+	client.Config.CacheDir, client.Config.CacheTTLHours = t.TempDir(), 1
+	client.Schemas = []*models.ObjectSchemaScheme{{ID: "7", Name: "Hardware"}}
+	client.Objects["1001"] = laptop
+	client.SearchResults = []*models.ObjectScheme{laptop}
+
+	response, err := GetObject(client, common.GetParams{ID: "Hardware/HW-1"})
+	if err != nil || !response.Success {
+		t.Fatalf("GetObject failed: %v %v", err, response.Error)
+	}
+	if id := response.Data.(map[string]interface{})["object_id"]; id != "1001" {
+		t.Errorf("object_id = %v, want 1001", id)
+	}
+	if want := []string{`objectSchemaId = 7 AND Key = "HW-1"`}; !reflect.DeepEqual(client.SearchQueries, want) {
+		t.Errorf("queries = %q, want %q", client.SearchQueries, want)
+	}
+
+	// Numeric IDs are used as given and keys come from the saved index
+	ids, err := ResolveObjectIDs(client, []string{"hw-1", " 1002 ", ""})
+	if err != nil || !reflect.DeepEqual(ids, []string{"1001", "1002"}) {
+		t.Errorf("ResolveObjectIDs = %v, %v", ids, err)
+	}
+	if len(client.SearchQueries) != 1 {
+		t.Errorf("indexed key searched again: %q", client.SearchQueries)
+	}
+
+	client.SearchResults = nil
+	response, _ = DeleteInstances(client, common.DeleteInstancesParams{IDs: []string{"HW-9"}, Confirm: true})
+	if response.Success || !strings.Contains(response.Error, "no object with key 'HW-9'") {
+		t.Errorf("unknown key error = %q", response.Error)
+	}
+	if len(client.DeletedObjects) != 0 {
+		t.Errorf("deleted %v after a failed lookup", client.DeletedObjects)
+	}
+}
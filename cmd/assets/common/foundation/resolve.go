@@ -0,0 +1,243 @@
+package foundation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/internal/property"
+	"github.com/aaronsb/atlassian-assets/internal/resolver"
+)
+
+// Resolve translates between human-readable names and internal IDs for schemas,
+// object types and objects
+func Resolve(client common.ClientInterface, params common.ResolveParams) (*common.Response, error) {
+	switch params.Kind {
+	case "schema":
+		return ResolveSchema(client, params)
+	case "object_type", "type":
+		return ResolveObjectType(client, params)
+	case "object":
+		return ResolveObject(client, params)
+	case "":
+		return common.NewErrorResponse(fmt.Errorf("kind is required (schema, object_type or object)")), nil
+	default:
+		return common.NewErrorResponse(fmt.Errorf("unknown kind '%s' (expected schema, object_type or object)", params.Kind)), nil
+	}
+}
+
+// ResolveSchema resolves a schema name to its ID or an ID to its name, or lists
+// all schemas when neither is given
+func ResolveSchema(client common.ClientInterface, params common.ResolveParams) (*common.Response, error) {
+	if params.Name != "" && params.ID != "" {
+		return common.NewErrorResponse(fmt.Errorf("specify either name or id, not both")), nil
+	}
+
+	r := resolver.NewResolver(client)
+	ctx := context.Background()
+
+	switch {
+	case params.Name != "":
+		id, err := r.ResolveSchemaID(ctx, params.Name)
+		if err != nil {
+			return common.NewErrorResponse(fmt.Errorf("failed to resolve schema name: %w", err)), nil
+		}
+		return common.NewSuccessResponse(map[string]interface{}{
+			"action": "name_to_id",
+			"input":  params.Name,
+			"result": id,
+			"type":   "schema",
+		}), nil
+
+	case params.ID != "":
+		name, err := r.ResolveSchemaName(ctx, params.ID)
+		if err != nil {
+			return common.NewErrorResponse(fmt.Errorf("failed to resolve schema ID: %w", err)), nil
+		}
+		return common.NewSuccessResponse(map[string]interface{}{
+			"action": "id_to_name",
+			"input":  params.ID,
+			"result": name,
+			"type":   "schema",
+		}), nil
+
+	default:
+		schemas, err := r.ListResolvedSchemas(ctx)
+		if err != nil {
+			return common.NewErrorResponse(fmt.Errorf("failed to list schemas: %w", err)), nil
+		}
+		return common.NewSuccessResponse(map[string]interface{}{
+			"action":  "list_schemas",
+			"schemas": schemas,
+			"count":   len(schemas),
+		}), nil
+	}
+}
+
+// ResolveObjectType resolves an object type name within a schema to its ID, an ID
+// to its name, or lists the object types of a schema
+func ResolveObjectType(client common.ClientInterface, params common.ResolveParams) (*common.Response, error) {
+	if params.Name != "" && params.ID != "" {
+		return common.NewErrorResponse(fmt.Errorf("specify either name or id, not both")), nil
+	}
+
+	r := resolver.NewResolver(client)
+	ctx := context.Background()
+
+	switch {
+	case params.Name != "":
+		if params.Schema == "" {
+			return common.NewErrorResponse(fmt.Errorf("schema is required when resolving an object type name")), nil
+		}
+		id, err := r.ResolveObjectTypeID(ctx, params.Schema, params.Name)
+		if err != nil {
+			return common.NewErrorResponse(fmt.Errorf("failed to resolve object type name: %w", err)), nil
+		}
+		return common.NewSuccessResponse(map[string]interface{}{
+			"action": "name_to_id",
+			"input":  params.Name,
+			"result": id,
+			"schema": params.Schema,
+			"type":   "object_type",
+		}), nil
+
+	case params.ID != "":
+		name, schemaName, err := r.ResolveObjectTypeName(ctx, params.ID)
+		if err != nil {
+			return common.NewErrorResponse(fmt.Errorf("failed to resolve object type ID: %w", err)), nil
+		}
+		return common.NewSuccessResponse(map[string]interface{}{
+			"action":      "id_to_name",
+			"input":       params.ID,
+			"result":      name,
+			"schema_name": schemaName,
+			"type":        "object_type",
+		}), nil
+
+	default:
+		if params.Schema == "" {
+			return common.NewErrorResponse(fmt.Errorf("schema is required when listing object types")), nil
+		}
+		objectTypes, err := r.ListResolvedObjectTypes(ctx, params.Schema)
+		if err != nil {
+			return common.NewErrorResponse(fmt.Errorf("failed to list object types: %w", err)), nil
+		}
+		return common.NewSuccessResponse(map[string]interface{}{
+			"action":       "list_object_types",
+			"schema":       params.Schema,
+			"object_types": objectTypes,
+			"count":        len(objectTypes),
+		}), nil
+	}
+}
+
+// ResolveObject resolves an object reference (ID, key or schema/key) and returns
+// its identity with schema and object type names
+func ResolveObject(client common.ClientInterface, params common.ResolveParams) (*common.Response, error) {
+	ref := params.Ref
+	if ref == "" {
+		ref = params.ID
+	}
+	if ref == "" {
+		return common.NewErrorResponse(fmt.Errorf("object reference is required")), nil
+	}
+
+	r := resolver.NewResolver(client)
+	ctx := context.Background()
+
+	objectID, err := r.ResolveObjectID(ctx, ref)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to resolve object reference: %w", err)), nil
+	}
+
+	objectInfo, err := r.GetObjectInfo(ctx, objectID)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to get object info: %w", err)), nil
+	}
+
+	// Add schema and object type names for context
+	var schemaName, objectTypeName string
+	if objectInfo.SchemaID != "" {
+		schemaName, _ = r.ResolveSchemaName(ctx, objectInfo.SchemaID)
+	}
+	if objectInfo.ParentID != "" {
+		objectTypeName, _, _ = r.ResolveObjectTypeName(ctx, objectInfo.ParentID)
+	}
+
+	return common.NewSuccessResponse(map[string]interface{}{
+		"action":           "resolve_object",
+		"input":            ref,
+		"object_id":        objectInfo.ID,
+		"object_key":       objectInfo.Name,
+		"display_name":     objectInfo.DisplayName,
+		"schema_id":        objectInfo.SchemaID,
+		"schema_name":      schemaName,
+		"object_type_id":   objectInfo.ParentID,
+		"object_type_name": objectTypeName,
+		"last_updated":     objectInfo.LastUpdated,
+	}), nil
+}
+
+// ResolveObjectIDs turns object references into object IDs. A reference is an ID, an
+// object key, or "schema/key" or "schema/label"; IDs are passed through without a lookup.
+func ResolveObjectIDs(client common.ClientInterface, refs []string) ([]string, error) {
+	ctx := context.Background()
+	var r *resolver.Resolver
+
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(ref); err == nil {
+			ids = append(ids, ref)
+			continue
+		}
+
+		if r == nil {
+			r = resolver.NewResolver(client)
+		}
+		id, err := r.ResolveObjectID(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve object %s: %w", ref, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// resolveObjectID resolves a single object reference to its ID
+func resolveObjectID(client common.ClientInterface, ref string) (string, error) {
+	ids, err := ResolveObjectIDs(client, []string{ref})
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", fmt.Errorf("object ID is required")
+	}
+	return ids[0], nil
+}
+
+// invalidateSchema marks a schema changed by this tool stale in the resolver cache
+func invalidateSchema(client common.ClientInterface, schemaID string) {
+	resolver.NewResolver(client).InvalidateSchema(schemaID)
+}
+
+// invalidateObjectType marks the schema of an object type changed by this tool, or whose
+// attributes it changed, stale in the resolver cache
+func invalidateObjectType(client common.ClientInterface, objectTypeID string) {
+	resolver.NewResolver(client).InvalidateObjectType(objectTypeID)
+}
+
+// newPropertyResolver creates a property resolver that reads attribute definitions from
+// the resolver cache, looks users and groups up in the Jira directory and takes status
+// names
+func newPropertyResolver(client common.ClientInterface) *property.PropertyResolver {
+	return property.NewPropertyResolver(client).
+		WithAttributeCache(resolver.NewResolver(client)).
+		WithDirectory(resolver.NewDirectory(client)).
+		WithStatuses(client)
+}
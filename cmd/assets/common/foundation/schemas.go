@@ -2,9 +2,16 @@ package foundation
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
 	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/internal/resolver"
 )
 
 // ListSchemas lists all available schemas
@@ -23,7 +30,7 @@ func ListSchemas(client common.ClientInterface) (*common.Response, error) {
 		return common.NewSuccessResponse(responseData), nil
 	}
 
-	return common.NewErrorResponse(fmt.Errorf(response.Error)), nil
+	return common.NewErrorResponse(errors.New(response.Error)), nil
 }
 
 // GetSchema retrieves details of a specific schema
@@ -49,7 +56,7 @@ func GetSchema(client common.ClientInterface, params common.GetSchemaParams) (*c
 		return common.NewSuccessResponse(responseData), nil
 	}
 
-	return common.NewErrorResponse(fmt.Errorf(response.Error)), nil
+	return common.NewErrorResponse(errors.New(response.Error)), nil
 }
 
 // GetObjectTypes retrieves object types for a schema
@@ -74,7 +81,7 @@ func GetObjectTypes(client common.ClientInterface, schemaID string) (*common.Res
 		return common.NewSuccessResponse(responseData), nil
 	}
 
-	return common.NewErrorResponse(fmt.Errorf(response.Error)), nil
+	return common.NewErrorResponse(errors.New(response.Error)), nil
 }
 
 // CreateObjectType creates a new object type
@@ -98,11 +105,15 @@ func CreateObjectType(client common.ClientInterface, params common.CreateObjectT
 		responseData := response.Data.(map[string]interface{})
 		responseData["schema"] = params.Schema
 		responseData["operation"] = "create_object_type"
+
+		if objectType, ok := responseData["object_type"].(*models.ObjectTypeScheme); ok {
+			invalidateSchema(client, objectType.ObjectSchemaID)
+		}
 		
 		return common.NewSuccessResponse(responseData), nil
 	}
 
-	return common.NewErrorResponse(fmt.Errorf(response.Error)), nil
+	return common.NewErrorResponse(errors.New(response.Error)), nil
 }
 
 // GetObjectTypeAttributes retrieves attributes for an object type
@@ -113,7 +124,7 @@ func GetObjectTypeAttributes(client common.ClientInterface, params common.GetObj
 	}
 
 	ctx := context.Background()
-	response, err := client.GetObjectTypeAttributes(ctx, params.ObjectTypeID)
+	response, err := resolver.NewResolver(client).GetObjectTypeAttributes(ctx, params.ObjectTypeID)
 	if err != nil {
 		return common.NewErrorResponse(fmt.Errorf("failed to get object type attributes: %w", err)), nil
 	}
@@ -127,5 +138,421 @@ func GetObjectTypeAttributes(client common.ClientInterface, params common.GetObj
 		return common.NewSuccessResponse(responseData), nil
 	}
 
-	return common.NewErrorResponse(fmt.Errorf(response.Error)), nil
-}
\ No newline at end of file
+	return common.NewErrorResponse(errors.New(response.Error)), nil
+}
+
+// GetObjectType retrieves details of a specific object type
+func GetObjectType(client common.ClientInterface, objectTypeID string) (*common.Response, error) {
+	// Validate parameters
+	if objectTypeID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object type ID is required")), nil
+	}
+
+	ctx := context.Background()
+	response, err := client.GetObjectType(ctx, objectTypeID)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to get object type: %w", err)), nil
+	}
+
+	// Add metadata
+	if response.Success {
+		responseData := map[string]interface{}{
+			"object_type":    response.Data,
+			"object_type_id": objectTypeID,
+			"operation":      "get_object_type",
+		}
+		return common.NewSuccessResponse(responseData), nil
+	}
+
+	return common.NewErrorResponse(errors.New(response.Error)), nil
+}
+
+// ListIcons lists the icons object types can use
+func ListIcons(client common.ClientInterface) (*common.Response, error) {
+	ctx := context.Background()
+	response, err := client.ListIcons(ctx)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to list icons: %w", err)), nil
+	}
+	if !response.Success {
+		return common.NewErrorResponse(errors.New(response.Error)), nil
+	}
+
+	responseData := response.Data.(map[string]interface{})
+	responseData["operation"] = "list_icons"
+	return common.NewSuccessResponse(responseData), nil
+}
+
+// SetObjectTypeIcon changes the icon of an object type. The icon is named by ID or by
+// its name in any case.
+func SetObjectTypeIcon(client common.ClientInterface, params common.SetObjectTypeIconParams) (*common.Response, error) {
+	// Validate parameters
+	if params.ObjectTypeID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object type ID is required")), nil
+	}
+	if params.Icon == "" {
+		return common.NewErrorResponse(fmt.Errorf("icon is required")), nil
+	}
+
+	ctx := context.Background()
+	objectType, err := fetchObjectType(ctx, client, params.ObjectTypeID)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+
+	response, err := client.ListIcons(ctx)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to list icons: %w", err)), nil
+	}
+	if !response.Success {
+		return common.NewErrorResponse(fmt.Errorf("failed to list icons: %s", response.Error)), nil
+	}
+	icons, _ := response.Data.(map[string]interface{})["icons"].([]*models.IconScheme)
+	icon := findIcon(icons, params.Icon)
+	if icon == nil {
+		return common.NewErrorResponse(fmt.Errorf("icon not found: %s (list the available icons with 'assets set-icon --list')", params.Icon)), nil
+	}
+
+	previous := ""
+	if objectType.Icon != nil {
+		previous = objectType.Icon.ID
+	}
+
+	response, err = client.UpdateObjectType(ctx, objectType.ID, &models.ObjectTypePayloadScheme{
+		Name:           objectType.Name,
+		Description:    objectType.Description,
+		IconID:         icon.ID,
+		ObjectSchemaID: objectType.ObjectSchemaID,
+	})
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to update object type: %w", err)), nil
+	}
+	if !response.Success {
+		return common.NewErrorResponse(errors.New(response.Error)), nil
+	}
+
+	return common.NewSuccessResponse(map[string]interface{}{
+		"object_type_id":   objectType.ID,
+		"object_type_name": objectType.Name,
+		"icon":             icon,
+		"previous_icon_id": previous,
+		"operation":        "set_object_type_icon",
+	}), nil
+}
+
+// findIcon finds an icon by ID, or by name ignoring case
+func findIcon(icons []*models.IconScheme, nameOrID string) *models.IconScheme {
+	for _, icon := range icons {
+		if icon != nil && icon.ID == nameOrID {
+			return icon
+		}
+	}
+	for _, icon := range icons {
+		if icon != nil && strings.EqualFold(icon.Name, strings.TrimSpace(nameOrID)) {
+			return icon
+		}
+	}
+	return nil
+}
+
+// fetchObjectType gets the typed object type
+func fetchObjectType(ctx context.Context, client common.ClientInterface, objectTypeID string) (*models.ObjectTypeScheme, error) {
+	response, err := client.GetObjectType(ctx, objectTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object type: %w", err)
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("failed to get object type: %s", response.Error)
+	}
+	objectType, ok := response.Data.(*models.ObjectTypeScheme)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type response type: %T", response.Data)
+	}
+	return objectType, nil
+}
+
+// ObjectTypeDeletionPreview lists everything deleting an object type would destroy
+type ObjectTypeDeletionPreview struct {
+	ObjectType            *models.ObjectTypeScheme `json:"object_type"`
+	InstanceCount         int                      `json:"instance_count"`
+	ChildObjectTypes      []DeletedChildType       `json:"child_object_types"`
+	ReferencingAttributes []ReferencingAttribute   `json:"referencing_attributes"`
+	Token                 string                   `json:"confirmation_token"`
+}
+
+// DeletedChildType is an object type below the one being deleted
+type DeletedChildType struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	ParentID      string `json:"parent_id"`
+	InstanceCount int    `json:"instance_count"`
+}
+
+// ReferencingAttribute is a reference attribute in another object type that points
+// at the object type being deleted
+type ReferencingAttribute struct {
+	SchemaID       string `json:"schema_id"`
+	ObjectTypeID   string `json:"object_type_id"`
+	ObjectTypeName string `json:"object_type_name"`
+	AttributeID    string `json:"attribute_id"`
+	AttributeName  string `json:"attribute_name"`
+}
+
+// PreviewObjectTypeDeletion counts the instances of an object type and finds its
+// child object types and the attributes elsewhere that reference it. The preview's
+// token changes whenever any of these do.
+func PreviewObjectTypeDeletion(client common.ClientInterface, objectTypeID string) (*ObjectTypeDeletionPreview, error) {
+	ctx := context.Background()
+
+	response, err := client.GetObjectType(ctx, objectTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object type details: %w", err)
+	}
+	if !response.Success {
+		return nil, errors.New(response.Error)
+	}
+	objectType, ok := response.Data.(*models.ObjectTypeScheme)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type response type: %T", response.Data)
+	}
+
+	preview := &ObjectTypeDeletionPreview{
+		ObjectType:            objectType,
+		ChildObjectTypes:      []DeletedChildType{},
+		ReferencingAttributes: []ReferencingAttribute{},
+	}
+	if preview.InstanceCount, err = countInstances(ctx, client, objectTypeID); err != nil {
+		return nil, err
+	}
+
+	// Children and their descendants go with the object type
+	siblings, err := ListObjectTypes(client, objectType.ObjectSchemaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list object types: %w", err)
+	}
+	doomed := map[string]bool{objectTypeID: true}
+	for parents := []string{objectTypeID}; len(parents) > 0; {
+		var next []string
+		for _, parentID := range parents {
+			for _, candidate := range siblings {
+				if candidate.ParentObjectTypeID != parentID || doomed[candidate.ID] {
+					continue
+				}
+				count, err := countInstances(ctx, client, candidate.ID)
+				if err != nil {
+					return nil, err
+				}
+				doomed[candidate.ID] = true
+				next = append(next, candidate.ID)
+				preview.ChildObjectTypes = append(preview.ChildObjectTypes, DeletedChildType{
+					ID: candidate.ID, Name: candidate.Name, ParentID: parentID, InstanceCount: count,
+				})
+			}
+		}
+		parents = next
+	}
+
+	// References can come from any schema in the workspace
+	schemas, err := ListAllSchemas(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schemas: %w", err)
+	}
+	for _, schema := range schemas {
+		objectTypes, err := ListObjectTypes(client, schema.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list object types of schema %s: %w", schema.ID, err)
+		}
+		for _, other := range objectTypes {
+			if doomed[other.ID] {
+				continue
+			}
+			attributes, err := ListAttributes(client, other.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list attributes of object type %s: %w", other.ID, err)
+			}
+			for _, attr := range attributes {
+				if !referencesAny(attr, doomed) {
+					continue
+				}
+				preview.ReferencingAttributes = append(preview.ReferencingAttributes, ReferencingAttribute{
+					SchemaID: schema.ID, ObjectTypeID: other.ID, ObjectTypeName: other.Name,
+					AttributeID: attr.ID, AttributeName: attr.Name,
+				})
+			}
+		}
+	}
+
+	preview.Token, err = previewToken(preview)
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// DeleteObjectType deletes an object type and all of its instances. Without
+// confirmation it only returns the deletion preview. A confirmed deletion must carry
+// the token of a current preview unless it is forced; a forced deletion given an
+// expected instance count is refused when the object type has any other number.
+func DeleteObjectType(client common.ClientInterface, params common.DeleteObjectTypeParams) (*common.Response, error) {
+	if !client.IsDeleteAllowed() {
+		return common.NewErrorResponse(ErrDeleteDisabled), nil
+	}
+
+	// Validate parameters
+	if params.ID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object type ID is required")), nil
+	}
+
+	preview, err := PreviewObjectTypeDeletion(client, params.ID)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to preview object type deletion: %w", err)), nil
+	}
+
+	if !params.Confirm {
+		return common.NewSuccessResponse(map[string]interface{}{
+			"action":             "delete_object_type_preview",
+			"object_type_id":     params.ID,
+			"deleted":            false,
+			"preview":            preview,
+			"confirmation_token": preview.Token,
+			"message": fmt.Sprintf("Nothing was deleted. Deleting object type %s removes %d instances, %d child object types and %d referencing attributes; confirm with token %s",
+				params.ID, preview.InstanceCount, len(preview.ChildObjectTypes), len(preview.ReferencingAttributes), preview.Token),
+		}), nil
+	}
+
+	if params.Force {
+		if params.ExpectCount != nil && *params.ExpectCount != preview.InstanceCount {
+			return common.NewErrorResponse(fmt.Errorf("object type %s has %d instances, not the %d expected; nothing was deleted", params.ID, preview.InstanceCount, *params.ExpectCount)), nil
+		}
+	} else {
+		switch params.PreviewToken {
+		case "":
+			return common.NewErrorResponse(fmt.Errorf("deletion of object type %s requires explicit confirmation with the token from its preview", params.ID)), nil
+		case preview.Token:
+		default:
+			return common.NewErrorResponse(fmt.Errorf("object type %s has changed since the preview was taken (token %s is stale); review the new preview with token %s", params.ID, params.PreviewToken, preview.Token)), nil
+		}
+	}
+
+	ctx := context.Background()
+	response, err := client.DeleteObjectType(ctx, params.ID)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to delete object type: %w", err)), nil
+	}
+
+	// Add metadata
+	if response.Success {
+		invalidateObjectType(client, params.ID)
+
+		responseData := map[string]interface{}{
+			"action":         "delete_object_type",
+			"object_type_id": params.ID,
+			"confirm":        params.Confirm,
+			"deleted":        true,
+			"preview":        preview,
+			"message":        fmt.Sprintf("Successfully deleted object type %s", params.ID),
+		}
+		return common.NewSuccessResponse(responseData), nil
+	}
+
+	return common.NewErrorResponse(errors.New(response.Error)), nil
+}
+
+// countInstances returns how many objects an object type has
+func countInstances(ctx context.Context, client common.ClientInterface, objectTypeID string) (int, error) {
+	response, err := client.SearchObjects(ctx, fmt.Sprintf("objectTypeId = %s", objectTypeID), 1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count instances of object type %s: %w", objectTypeID, err)
+	}
+	if !response.Success {
+		return 0, fmt.Errorf("failed to count instances of object type %s: %s", objectTypeID, response.Error)
+	}
+
+	if total, ok := TotalFromData(response.Data); ok {
+		return total, nil
+	}
+	return 0, fmt.Errorf("search response has no total")
+}
+
+// referencesAny reports whether a reference attribute points at one of the object types
+func referencesAny(attr *models.ObjectTypeAttributeScheme, objectTypeIDs map[string]bool) bool {
+	if attr == nil || attr.Type != 1 {
+		return false
+	}
+	if objectTypeIDs[attr.ReferenceObjectTypeID] {
+		return true
+	}
+	return attr.ReferenceObjectType != nil && objectTypeIDs[attr.ReferenceObjectType.ID]
+}
+
+// previewToken fingerprints what a deletion would destroy
+func previewToken(preview *ObjectTypeDeletionPreview) (string, error) {
+	content, err := json.Marshal([]interface{}{
+		preview.ObjectType.ID, preview.InstanceCount, preview.ChildObjectTypes, preview.ReferencingAttributes,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint deletion preview: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:6]), nil
+}
+
+// SchemasFromData extracts the typed schema list from list schemas response data
+func SchemasFromData(data interface{}) ([]*models.ObjectSchemaScheme, error) {
+	responseData, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected schema response type: %T", data)
+	}
+
+	switch schemas := responseData["schemas"].(type) {
+	case []*models.ObjectSchemaScheme:
+		return schemas, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected schemas type: %T", schemas)
+	}
+}
+
+// ObjectTypesFromData extracts the typed object type list from object type response data
+func ObjectTypesFromData(data interface{}) ([]*models.ObjectTypeScheme, error) {
+	responseData, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type response type: %T", data)
+	}
+
+	switch objectTypes := responseData["object_types"].(type) {
+	case []*models.ObjectTypeScheme:
+		return objectTypes, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected object types type: %T", objectTypes)
+	}
+}
+
+// ListObjectTypes retrieves the typed object type list for a schema
+func ListObjectTypes(client common.ClientInterface, schemaID string) ([]*models.ObjectTypeScheme, error) {
+	response, err := GetObjectTypes(client, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, errors.New(response.Error)
+	}
+
+	return ObjectTypesFromData(response.Data)
+}
+
+// ListAllSchemas retrieves the typed schema list for the workspace
+func ListAllSchemas(client common.ClientInterface) ([]*models.ObjectSchemaScheme, error) {
+	response, err := ListSchemas(client)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, errors.New(response.Error)
+	}
+
+	return SchemasFromData(response.Data)
+}
@@ -0,0 +1,186 @@
+package foundation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/commontest"
+)
+
+func TestDeleteObjectType(t *testing.T) {
+	count := func(n int) *int { return &n }
+	tests := []struct {
+		name        string
+		params      common.DeleteObjectTypeParams
+		allowDelete bool
+		wantError   string
+	}{
+		{"deletes a forced object type", common.DeleteObjectTypeParams{ID: "65", Confirm: true, Force: true}, true, ""},
+		{"deletes a forced object type with the expected count", common.DeleteObjectTypeParams{ID: "65", Confirm: true, Force: true, ExpectCount: count(0)}, true, ""},
+		{"refuses a forced deletion with the wrong count", common.DeleteObjectTypeParams{ID: "65", Confirm: true, Force: true, ExpectCount: count(3)}, true, "has 0 instances, not the 3 expected"},
+		{"requires the preview token", common.DeleteObjectTypeParams{ID: "65", Confirm: true}, true, "requires explicit confirmation"},
+		{"refuses a stale preview token", common.DeleteObjectTypeParams{ID: "65", Confirm: true, PreviewToken: "0123456789ab"}, true, "has changed since the preview"},
+		{"requires an ID", common.DeleteObjectTypeParams{Confirm: true}, true, "object type ID is required"},
+		{"reports a missing object type", common.DeleteObjectTypeParams{ID: "99", Confirm: true}, true, "not found"},
+		{"refuses when deletes are disabled", common.DeleteObjectTypeParams{ID: "65", Confirm: true}, false, "delete operations are disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := commontest.NewMockClient()
+			client.Config.CacheDir = t.TempDir()
+			client.AllowDelete = tt.allowDelete
+			client.AddObjectType(&models.ObjectTypeScheme{ID: "65", Name: "Laptops", ObjectSchemaID: "7"})
+
+			response, err := DeleteObjectType(client, tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				if len(client.DeletedObjectTypes) > 0 {
+					t.Errorf("expected no deletions, got %v", client.DeletedObjectTypes)
+				}
+				return
+			}
+
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+			if len(client.DeletedObjectTypes) != 1 || client.DeletedObjectTypes[0] != tt.params.ID {
+				t.Errorf("deleted %v, want [%s]", client.DeletedObjectTypes, tt.params.ID)
+			}
+		})
+	}
+}
+
+func TestDeleteObjectTypePreview(t *testing.T) {
+	client := commontest.NewMockClient()
+	client.Config.CacheDir = t.TempDir()
+	client.Schemas = []*models.ObjectSchemaScheme{{ID: "7", Name: "IT"}, {ID: "8", Name: "Facilities"}}
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "65", Name: "Hardware", ObjectSchemaID: "7"},
+		&models.ObjectTypeAttributeScheme{ID: "1", Name: "Replaces", Type: 1, ReferenceObjectTypeID: "65"})
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "66", Name: "Laptops", ObjectSchemaID: "7", ParentObjectTypeID: "65"})
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "67", Name: "Gaming Laptops", ObjectSchemaID: "7", ParentObjectTypeID: "66"})
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "68", Name: "People", ObjectSchemaID: "7"},
+		&models.ObjectTypeAttributeScheme{ID: "2", Name: "Laptop", Type: 1, ReferenceObjectTypeID: "66"},
+		&models.ObjectTypeAttributeScheme{ID: "3", Name: "Email"})
+	client.AddObjectType(&models.ObjectTypeScheme{ID: "80", Name: "Desks", ObjectSchemaID: "8"},
+		&models.ObjectTypeAttributeScheme{ID: "4", Name: "Dock", Type: 1, ReferenceObjectType: &models.ObjectTypeScheme{ID: "65"}})
+	client.SearchResults = []*models.ObjectScheme{{ID: "1"}, {ID: "2"}}
+
+	// Without confirmation nothing is deleted and the preview lists the cascade
+	response, err := DeleteObjectType(client, common.DeleteObjectTypeParams{ID: "65"})
+	if err != nil || !response.Success {
+		t.Fatalf("preview failed: %v %v", err, response.Error)
+	}
+	if len(client.DeletedObjectTypes) > 0 {
+		t.Fatalf("preview deleted %v", client.DeletedObjectTypes)
+	}
+
+	preview := response.Data.(map[string]interface{})["preview"].(*ObjectTypeDeletionPreview)
+	if preview.InstanceCount != 2 {
+		t.Errorf("instance count = %d, want 2", preview.InstanceCount)
+	}
+	if len(preview.ChildObjectTypes) != 2 || preview.ChildObjectTypes[0].ID != "66" || preview.ChildObjectTypes[1].ParentID != "66" {
+		t.Errorf("child object types = %+v", preview.ChildObjectTypes)
+	}
+
+	// References from the deleted types themselves are not listed
+	var referencing []string
+	for _, attr := range preview.ReferencingAttributes {
+		referencing = append(referencing, attr.ObjectTypeName+"."+attr.AttributeName)
+	}
+	if strings.Join(referencing, ",") != "People.Laptop,Desks.Dock" {
+		t.Errorf("referencing attributes = %v", referencing)
+	}
+
+	// The preview's token confirms the deletion until something changes
+	params := common.DeleteObjectTypeParams{ID: "65", Confirm: true, PreviewToken: preview.Token}
+	client.SearchResults = append(client.SearchResults, &models.ObjectScheme{ID: "3"})
+	if response, _ := DeleteObjectType(client, params); response.Success {
+		t.Fatal("deletion succeeded with a stale token")
+	}
+	client.SearchResults = client.SearchResults[:2]
+	if response, _ := DeleteObjectType(client, params); !response.Success {
+		t.Fatalf("deletion with the preview token failed: %s", response.Error)
+	}
+	if len(client.DeletedObjectTypes) != 1 || client.DeletedObjectTypes[0] != "65" {
+		t.Errorf("deleted %v, want [65]", client.DeletedObjectTypes)
+	}
+}
+
+func TestListAllSchemas(t *testing.T) {
+	client := commontest.NewMockClient()
+	client.Schemas = []*models.ObjectSchemaScheme{{ID: "6", Name: "Facilities"}, {ID: "7", Name: "IT"}}
+
+	schemas, err := ListAllSchemas(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schemas) != 2 || schemas[1].Name != "IT" {
+		t.Errorf("unexpected schemas: %+v", schemas)
+	}
+
+	client.Failures["ListSchemas"] = "API error: 401"
+	if _, err := ListAllSchemas(client); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected API error, got %v", err)
+	}
+}
+
+func TestSetObjectTypeIcon(t *testing.T) {
+	tests := []struct {
+		name      string
+		icon      string
+		failures  map[string]string
+		wantIcon  string
+		wantError string
+	}{
+		{name: "sets an icon by name", icon: "server", wantIcon: "14"},
+		{name: "sets an icon by ID", icon: "13", wantIcon: "13"},
+		{name: "reports an unknown icon", icon: "Rocket", wantError: "icon not found: Rocket"},
+		{name: "requires an icon", wantError: "icon is required"},
+		{name: "reports update failures", icon: "Server", failures: map[string]string{"UpdateObjectType": "API error: 400"}, wantError: "API error: 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := commontest.NewMockClient()
+			client.Icons = []*models.IconScheme{{ID: "12", Name: "Laptop"}, {ID: "13", Name: "Printer"}, {ID: "14", Name: "Server"}}
+			laptops := &models.ObjectTypeScheme{ID: "65", Name: "Laptops", Description: "Portable computers", ObjectSchemaID: "7", Icon: &models.IconScheme{ID: "12"}}
+			client.AddObjectType(laptops)
+			if tt.failures != nil {
+				client.Failures = tt.failures
+			}
+
+			response, err := SetObjectTypeIcon(client, common.SetObjectTypeIconParams{ObjectTypeID: "65", Icon: tt.icon})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || !strings.Contains(response.Error, tt.wantError) {
+					t.Fatalf("expected error containing %q, got success=%v error=%q", tt.wantError, response.Success, response.Error)
+				}
+				if laptops.Icon.ID != "12" {
+					t.Errorf("icon changed to %s", laptops.Icon.ID)
+				}
+				return
+			}
+			if !response.Success {
+				t.Fatalf("expected success, got error %q", response.Error)
+			}
+			if laptops.Icon.ID != tt.wantIcon || laptops.Name != "Laptops" || laptops.Description != "Portable computers" {
+				t.Errorf("object type = %+v, want icon %s with name and description kept", laptops, tt.wantIcon)
+			}
+			if data := response.Data.(map[string]interface{}); data["previous_icon_id"] != "12" {
+				t.Errorf("previous icon = %v, want 12", data["previous_icon_id"])
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package foundation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/internal/snapshot"
+)
+
+// DefaultSnapshotPath returns the snapshot file in the client's cache directory
+func DefaultSnapshotPath(client common.ClientInterface) (string, error) {
+	cacheDir, err := client.GetConfig().GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return snapshot.DefaultPath(cacheDir), nil
+}
+
+// PullSnapshot downloads the object types, attributes and objects of schemas into a
+// snapshot file. Schemas pulled before are replaced and other schemas are kept; the
+// file is only written once every schema has been pulled.
+func PullSnapshot(client common.ClientInterface, params common.PullSnapshotParams) (*common.Response, error) {
+	if len(params.Schemas) == 0 {
+		return common.NewErrorResponse(fmt.Errorf("at least one schema is required")), nil
+	}
+	if params.File == "" {
+		return common.NewErrorResponse(fmt.Errorf("snapshot file is required")), nil
+	}
+	pageSize := params.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	snap, err := snapshot.Load(params.File)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		snap = snapshot.New(client.GetWorkspaceID(), client.GetConfig().Host)
+	case err != nil:
+		return common.NewErrorResponse(err), nil
+	case snap.WorkspaceID != client.GetWorkspaceID():
+		return common.NewErrorResponse(fmt.Errorf("snapshot %s belongs to workspace %s, not %s", params.File, snap.WorkspaceID, client.GetWorkspaceID())), nil
+	}
+
+	schemas, err := ListAllSchemas(client)
+	if err != nil {
+		return common.NewErrorResponse(fmt.Errorf("failed to list schemas: %w", err)), nil
+	}
+
+	ctx := context.Background()
+	var pulled []map[string]interface{}
+	for i, ref := range params.Schemas {
+		schema := findSchema(schemas, strings.TrimSpace(ref))
+		if schema == nil {
+			return common.NewErrorResponse(fmt.Errorf("schema not found: %s", ref)), nil
+		}
+
+		entry, err := pullSchema(ctx, client, schema, pageSize)
+		if err != nil {
+			return common.NewErrorResponse(fmt.Errorf("failed to pull schema %s: %w", schema.Name, err)), nil
+		}
+		snap.Put(entry)
+
+		pulled = append(pulled, map[string]interface{}{
+			"schema_id":    schema.ID,
+			"schema_name":  schema.Name,
+			"object_types": len(entry.ObjectTypes),
+			"objects":      len(entry.Objects),
+		})
+		if params.Progress != nil {
+			params.Progress(i+1, len(params.Schemas))
+		}
+	}
+
+	if err := snap.Save(params.File); err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+
+	return common.NewSuccessResponse(map[string]interface{}{
+		"action":       "snapshot_pull",
+		"file":         params.File,
+		"workspace_id": snap.WorkspaceID,
+		"pulled":       pulled,
+		"schema_count": len(snap.Schemas),
+		"message":      fmt.Sprintf("Pulled %d schema(s) into %s", len(pulled), params.File),
+	}), nil
+}
+
+// findSchema looks up a schema by ID or name
+func findSchema(schemas []*models.ObjectSchemaScheme, ref string) *models.ObjectSchemaScheme {
+	for _, schema := range schemas {
+		if schema.ID == ref || strings.EqualFold(schema.Name, ref) {
+			return schema
+		}
+	}
+	return nil
+}
+
+// pullSchema fetches everything the snapshot keeps for one schema
+func pullSchema(ctx context.Context, client common.ClientInterface, schema *models.ObjectSchemaScheme, pageSize int) (*snapshot.Schema, error) {
+	objectTypes, err := ListObjectTypes(client, schema.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object types: %w", err)
+	}
+
+	// Attributes are read from the API rather than the resolver cache so the snapshot is current
+	attributes := make(map[string][]*models.ObjectTypeAttributeScheme, len(objectTypes))
+	for _, objectType := range objectTypes {
+		response, err := client.GetObjectTypeAttributes(ctx, objectType.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get attributes of object type %s: %w", objectType.ID, err)
+		}
+		if !response.Success {
+			return nil, fmt.Errorf("failed to get attributes of object type %s: %s", objectType.ID, response.Error)
+		}
+		if attributes[objectType.ID], err = AttributesFromData(response.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	query := fmt.Sprintf("objectSchemaId = %s", schema.ID)
+	objects, _, err := searchAll(ctx, client, query, pageSize, func(int) error { return nil })
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot.Schema{
+		Schema:      schema,
+		ObjectTypes: objectTypes,
+		Attributes:  attributes,
+		Objects:     objects,
+		PulledAt:    time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,82 @@
+package foundation
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/internal/snapshot"
+)
+
+func TestPullSnapshot(t *testing.T) {
+	client := newArchiveClient()
+	client.Schemas = []*models.ObjectSchemaScheme{{ID: "1", Name: "Hardware"}, {ID: "2", Name: "Facilities"}}
+	client.SearchResults = []*models.ObjectScheme{client.Objects["100"], client.Objects["200"]}
+	path := filepath.Join(t.TempDir(), "snapshot", snapshot.DefaultFileName)
+
+	response, err := PullSnapshot(client, common.PullSnapshotParams{Schemas: []string{"hardware"}, File: path, PageSize: 1})
+	if err != nil || !response.Success {
+		t.Fatalf("PullSnapshot failed: %v %s", err, response.Error)
+	}
+	if query := client.SearchQueries[0]; query != "objectSchemaId = 1" {
+		t.Errorf("object query = %q", query)
+	}
+
+	snap, err := snapshot.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	offline := snapshot.NewClient(snap, client.Config)
+
+	// The read operations answer from the snapshot
+	objectID, err := resolveObjectID(offline, "EMP-1")
+	if err != nil || objectID != "200" {
+		t.Fatalf("resolveObjectID(EMP-1) = %q, %v", objectID, err)
+	}
+	search, err := SearchObjects(offline, common.SearchParams{Simple: "*", Status: "in use", Limit: 10})
+	if err != nil || !search.Success {
+		t.Fatalf("SearchObjects failed: %v %s", err, search.Error)
+	}
+	if objects, _ := ObjectsFromData(search.Data); len(objects) != 1 || objects[0].ID != "100" {
+		t.Errorf("offline search = %v, want HW-100", objects)
+	}
+	attributes, err := ListAttributes(offline, "7")
+	if err != nil || len(attributes) != 2 {
+		t.Errorf("offline attributes = %v, %v", attributes, err)
+	}
+
+	// Changes are refused
+	update, err := offline.UpdateObject(context.Background(), "100", "5", map[string]interface{}{"Name": "x"})
+	if err != nil || update.Success || !strings.Contains(update.Error, "not available offline") {
+		t.Errorf("offline update = %+v, %v; want it refused", update, err)
+	}
+}
+
+func TestPullSnapshotErrors(t *testing.T) {
+	client := newArchiveClient()
+	client.Schemas = []*models.ObjectSchemaScheme{{ID: "1", Name: "Hardware"}}
+	path := filepath.Join(t.TempDir(), snapshot.DefaultFileName)
+
+	if err := snapshot.New("ws-2", "").Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		params    common.PullSnapshotParams
+		wantError string
+	}{
+		{params: common.PullSnapshotParams{File: path}, wantError: "at least one schema is required"},
+		{params: common.PullSnapshotParams{Schemas: []string{"1"}}, wantError: "snapshot file is required"},
+		{params: common.PullSnapshotParams{Schemas: []string{"1"}, File: path}, wantError: "belongs to workspace ws-2"},
+		{params: common.PullSnapshotParams{Schemas: []string{"Software"}, File: filepath.Join(t.TempDir(), "new.db")}, wantError: "schema not found: Software"},
+	}
+	for _, tt := range tests {
+		response, err := PullSnapshot(client, tt.params)
+		if err != nil || response.Success || !strings.Contains(response.Error, tt.wantError) {
+			t.Errorf("PullSnapshot(%+v) = %+v, %v; want error %q", tt.params, response, err, tt.wantError)
+		}
+	}
+}
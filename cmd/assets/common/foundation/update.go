@@ -0,0 +1,414 @@
+package foundation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/internal/bulk"
+	"github.com/aaronsb/atlassian-assets/internal/property"
+)
+
+// ObjectChange lists the attribute values an update changes on one object
+type ObjectChange struct {
+	ObjectID     string          `json:"object_id"`
+	ObjectKey    string          `json:"object_key"`
+	Label        string          `json:"label"`
+	ObjectTypeID string          `json:"object_type_id"`
+	Changes      []AttributeDiff `json:"changes"`
+}
+
+// AttributeDiff is the current and new values of one attribute
+type AttributeDiff struct {
+	AttributeID string   `json:"attribute_id"`
+	Attribute   string   `json:"attribute"`
+	From        []string `json:"from"`
+	To          []string `json:"to"`
+}
+
+// attributeChange is a new value for an attribute of one object type, validated and
+// resolved to the form the API expects
+type attributeChange struct {
+	meta     *property.AttributeMetadata
+	input    []string    // The values as given, shown in diffs
+	resolved []string    // The values sent to the API
+	payload  interface{} // Update payload value; an empty list clears the attribute
+}
+
+// UpdateObject sets attribute values on one object
+func UpdateObject(client common.ClientInterface, params common.UpdateObjectParams) (*common.Response, error) {
+	if params.ID == "" {
+		return common.NewErrorResponse(fmt.Errorf("object ID is required")), nil
+	}
+	return UpdateInstances(client, common.UpdateInstancesParams{
+		IDs:     []string{params.ID},
+		Set:     params.Data,
+		Confirm: true,
+	})
+}
+
+// UpdateInstances sets attribute values on a list of objects, or on every object
+// matched by an AQL query. The new values are validated once for each object type
+// before anything is written, and nothing is written when one is invalid. Without
+// confirmation it only reports the changes it would make.
+func UpdateInstances(client common.ClientInterface, params common.UpdateInstancesParams) (*common.Response, error) {
+	// Validate parameters
+	if len(params.IDs) == 0 && params.Query == "" {
+		return common.NewErrorResponse(fmt.Errorf("either object IDs or a query is required")), nil
+	}
+	if len(params.Set) == 0 {
+		return common.NewErrorResponse(fmt.Errorf("at least one attribute value to set is required")), nil
+	}
+	if params.PageSize < 1 {
+		params.PageSize = defaultPageSize
+	}
+
+	ctx := context.Background()
+	objects, err := updateTargets(ctx, client, params)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+
+	// Resolve the new values once for each object type among the targets
+	pr := newPropertyResolver(client)
+	changesByType := make(map[string][]*attributeChange)
+	var invalid []string
+	for _, object := range objects {
+		if object.ObjectType == nil {
+			invalid = append(invalid, fmt.Sprintf("object %s: object type unknown", object.ID))
+			continue
+		}
+		typeID := object.ObjectType.ID
+		if _, done := changesByType[typeID]; done {
+			continue
+		}
+		changes, errs := resolveChanges(ctx, pr, typeID, params.Set)
+		changesByType[typeID] = changes
+		for _, err := range errs {
+			invalid = append(invalid, fmt.Sprintf("object type %s: %v", firstNonEmpty(object.ObjectType.Name, typeID), err))
+		}
+	}
+	if len(invalid) > 0 {
+		return common.NewErrorResponse(fmt.Errorf("invalid attribute values, nothing was updated: %s", strings.Join(invalid, "; "))), nil
+	}
+
+	// Work out what changes on each object, leaving out values that are already set
+	var changed []ObjectChange
+	payloads := make(map[string]map[string]interface{})
+	typeOf := make(map[string]string)
+	for _, object := range objects {
+		change := diffObject(object, changesByType[object.ObjectType.ID])
+		if len(change.Changes) == 0 {
+			continue
+		}
+		changed = append(changed, change)
+		typeOf[object.ID] = object.ObjectType.ID
+		payloads[object.ID] = updatePayload(change, changesByType[object.ObjectType.ID])
+	}
+
+	result := map[string]interface{}{
+		"action":          "update_instances",
+		"query":           params.Query,
+		"matched_count":   len(objects),
+		"changed_count":   len(changed),
+		"unchanged_count": len(objects) - len(changed),
+		"changes":         changed,
+		"dry_run":         !params.Confirm,
+	}
+
+	if !params.Confirm {
+		result["message"] = fmt.Sprintf("Dry run: %d of %d objects would change; confirm to apply the changes", len(changed), len(objects))
+		result["success"] = true
+		return common.NewSuccessResponse(result), nil
+	}
+
+	// A report that already has results resumes an interrupted run
+	report, err := openReport(params.Report, "update_instances", params.Query)
+	if err != nil {
+		return common.NewErrorResponse(err), nil
+	}
+	if report != nil {
+		defer report.Close()
+		if err := report.Start(bulk.Run{Operation: "update_instances", Query: params.Query}); err != nil {
+			return common.NewErrorResponse(err), nil
+		}
+	}
+
+	var pending []string
+	skipped := 0
+	for _, change := range changed {
+		if report != nil && report.Done(change.ObjectID) {
+			skipped++
+			continue
+		}
+		pending = append(pending, change.ObjectID)
+	}
+
+	outcomes := make(map[string]error, len(pending))
+	var reportErr error
+	bulk.Each(pending, params.Concurrency, func(objectID string) error {
+		response, err := client.UpdateObject(ctx, objectID, typeOf[objectID], payloads[objectID])
+		if err != nil {
+			return err
+		}
+		if !response.Success {
+			return errors.New(response.Error)
+		}
+		return nil
+	}, func(objectID string, err error) {
+		outcomes[objectID] = err
+		if report != nil {
+			if rerr := report.Record(objectID, err); rerr != nil && reportErr == nil {
+				reportErr = rerr
+			}
+		}
+		if params.Progress != nil {
+			params.Progress(len(outcomes), len(pending))
+		}
+	})
+
+	var errs []string
+	var updatedIDs []string
+	for _, objectID := range pending {
+		if err := outcomes[objectID]; err != nil {
+			errs = append(errs, fmt.Sprintf("Object %s: %v", objectID, err))
+			continue
+		}
+		updatedIDs = append(updatedIDs, objectID)
+	}
+	if reportErr != nil {
+		errs = append(errs, reportErr.Error())
+	}
+
+	result["updated_ids"] = updatedIDs
+	result["updated_count"] = len(updatedIDs)
+	result["success"] = len(errs) == 0
+	if report != nil {
+		result["report"] = report.Path()
+		result["skipped_count"] = skipped
+	}
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+
+	return common.NewSuccessResponse(result), nil
+}
+
+// updateTargets fetches the objects to update, by ID or every page of query matches
+func updateTargets(ctx context.Context, client common.ClientInterface, params common.UpdateInstancesParams) ([]*models.ObjectScheme, error) {
+	if params.Query != "" {
+		objects, total, err := searchAll(ctx, client, params.Query, params.PageSize, func(total int) error {
+			if total == 0 {
+				return fmt.Errorf("no objects match the query")
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(objects) != total {
+			return nil, fmt.Errorf("query matches changed while they were read (%d expected, %d read); nothing was updated", total, len(objects))
+		}
+		return objects, nil
+	}
+
+	ids, err := ResolveObjectIDs(client, params.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*models.ObjectScheme
+	for _, id := range ids {
+		response, err := client.GetObject(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get object %s: %w", id, err)
+		}
+		if !response.Success {
+			return nil, fmt.Errorf("failed to get object %s: %s", id, response.Error)
+		}
+		object, ok := response.Data.(*models.ObjectScheme)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object response type: %T", response.Data)
+		}
+		objects = append(objects, object)
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("no objects found to update")
+	}
+	return objects, nil
+}
+
+// resolveChanges validates the new values against an object type's attributes
+func resolveChanges(ctx context.Context, pr *property.PropertyResolver, objectTypeID string, set map[string]interface{}) ([]*attributeChange, []error) {
+	metadata, err := pr.GetObjectTypeMetadata(ctx, objectTypeID)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []*attributeChange
+	var errs []error
+	for _, name := range names {
+		meta, ok := property.LookupMetadata(metadata, name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown attribute: %s", name))
+			continue
+		}
+		if meta.System || !meta.Editable {
+			errs = append(errs, fmt.Errorf("attribute %s cannot be edited", meta.Name))
+			continue
+		}
+
+		resolved, err := pr.ResolveProperty(ctx, meta, set[name])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		change := &attributeChange{meta: meta, input: inputValues(set[name]), payload: []string{}}
+		if resolved != nil {
+			change.resolved = resolved.Values()
+			change.payload = resolved.Value
+		} else if meta.Required {
+			errs = append(errs, fmt.Errorf("attribute %s is required and cannot be cleared", meta.Name))
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes, errs
+}
+
+// inputValues lists a value to set as it was given, for diffs
+func inputValues(value interface{}) []string {
+	var items []interface{}
+	switch v := value.(type) {
+	case nil:
+	case []string:
+		for _, item := range v {
+			items = append(items, item)
+		}
+	case []interface{}:
+		items = v
+	default:
+		items = []interface{}{v}
+	}
+
+	values := []string{}
+	for _, item := range items {
+		if text := strings.TrimSpace(fmt.Sprint(item)); item != nil && text != "" {
+			values = append(values, text)
+		}
+	}
+	return values
+}
+
+// diffObject compares an object's current values with the new ones
+func diffObject(object *models.ObjectScheme, changes []*attributeChange) ObjectChange {
+	current := make(map[string]*models.ObjectAttributeScheme)
+	for _, attr := range object.Attributes {
+		if attr != nil {
+			current[attributeIDOf(attr)] = attr
+		}
+	}
+
+	result := ObjectChange{ObjectID: object.ID, ObjectKey: object.ObjectKey, Label: object.Label, ObjectTypeID: object.ObjectType.ID}
+	for _, change := range changes {
+		values := currentValues(current[change.meta.ID])
+		if hasValues(values, change) {
+			continue
+		}
+		result.Changes = append(result.Changes, AttributeDiff{
+			AttributeID: change.meta.ID,
+			Attribute:   change.meta.Name,
+			From:        displayValues(values),
+			To:          change.input,
+		})
+	}
+	return result
+}
+
+// currentValues returns the values an object holds for an attribute
+func currentValues(attr *models.ObjectAttributeScheme) []*models.ObjectTypeAssetAttributeValueScheme {
+	if attr == nil {
+		return nil
+	}
+	var values []*models.ObjectTypeAssetAttributeValueScheme
+	for _, v := range attr.ObjectAttributeValues {
+		if v != nil {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// hasValues reports whether an attribute already holds exactly the new values. A
+// value matches by its raw, display or search form, so references named by key or
+// label and statuses given by ID all compare equal.
+func hasValues(values []*models.ObjectTypeAssetAttributeValueScheme, change *attributeChange) bool {
+	if len(values) != len(change.resolved) {
+		return false
+	}
+	for i, want := range change.resolved {
+		given := ""
+		if i < len(change.input) {
+			given = change.input[i]
+		}
+		found := false
+		for _, v := range values {
+			if valueMatches(v, want) || (given != "" && valueMatches(v, given)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func valueMatches(v *models.ObjectTypeAssetAttributeValueScheme, want string) bool {
+	forms := []string{v.Value, v.DisplayValue, v.SearchValue}
+	if v.Status != nil {
+		forms = append(forms, v.Status.ID)
+	}
+	if v.Group != nil {
+		forms = append(forms, v.Group.Name)
+	}
+	for _, form := range forms {
+		if form != "" && strings.EqualFold(form, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// displayValues formats current values the way the Assets UI shows them
+func displayValues(values []*models.ObjectTypeAssetAttributeValueScheme) []string {
+	display := make([]string, 0, len(values))
+	for _, v := range values {
+		display = append(display, firstNonEmpty(v.DisplayValue, v.Value, v.SearchValue))
+	}
+	return display
+}
+
+// updatePayload keys the new values of the attributes that change on an object by attribute ID
+func updatePayload(change ObjectChange, changes []*attributeChange) map[string]interface{} {
+	byID := make(map[string]*attributeChange, len(changes))
+	for _, c := range changes {
+		byID[c.meta.ID] = c
+	}
+	payload := make(map[string]interface{}, len(change.Changes))
+	for _, diff := range change.Changes {
+		payload[diff.AttributeID] = byID[diff.AttributeID].payload
+	}
+	return payload
+}
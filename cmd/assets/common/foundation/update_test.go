@@ -0,0 +1,187 @@
+package foundation
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/commontest"
+)
+
+// newUpdateClient serves three laptops matched by every search; HW-2 is already retired
+func newUpdateClient() *commontest.MockClient {
+	client := commontest.NewMockClient()
+	laptops := &models.ObjectTypeScheme{ID: "5", Name: "Laptops", ObjectSchemaID: "1"}
+	client.AddObjectType(laptops,
+		&models.ObjectTypeAttributeScheme{ID: "51", Name: "Key", System: true, MaximumCardinality: 1},
+		&models.ObjectTypeAttributeScheme{ID: "52", Name: "Name", Editable: true, MinimumCardinality: 1, MaximumCardinality: 1},
+		&models.ObjectTypeAttributeScheme{ID: "53", Name: "Status", Editable: true, Type: 7, MaximumCardinality: 1, TypeValueMulti: []string{"1", "2"}},
+		&models.ObjectTypeAttributeScheme{ID: "54", Name: "Location", Editable: true, MaximumCardinality: 1},
+	)
+
+	laptop := func(id, status string) *models.ObjectScheme {
+		return &models.ObjectScheme{ID: id, ObjectKey: "HW-" + id, Label: "laptop-" + id, ObjectType: laptops,
+			Attributes: []*models.ObjectAttributeScheme{
+				{ObjectTypeAttributeID: "52", ObjectAttributeValues: []*models.ObjectTypeAssetAttributeValueScheme{{Value: "laptop-" + id}}},
+				{ObjectTypeAttributeID: "53", ObjectAttributeValues: []*models.ObjectTypeAssetAttributeValueScheme{
+					{DisplayValue: map[string]string{"1": "In use", "2": "Retired"}[status], Status: &models.ObjectTypeAssetAttributeStatusScheme{ID: status}}}},
+			}}
+	}
+	for _, object := range []*models.ObjectScheme{laptop("1", "1"), laptop("2", "2"), laptop("3", "1")} {
+		client.Objects[object.ID] = object
+		client.SearchResults = append(client.SearchResults, object)
+	}
+	return client
+}
+
+func TestUpdateInstancesDryRun(t *testing.T) {
+	client := newUpdateClient()
+
+	response, err := UpdateInstances(client, common.UpdateInstancesParams{
+		Query:    "objectType = Laptops",
+		Set:      map[string]interface{}{"Status": "2"},
+		PageSize: 2,
+	})
+	if err != nil || !response.Success {
+		t.Fatalf("UpdateInstances failed: %v %s", err, response.Error)
+	}
+	data := response.Data.(map[string]interface{})
+	if data["dry_run"] != true || data["matched_count"] != 3 || data["changed_count"] != 2 || data["unchanged_count"] != 1 {
+		t.Errorf("response = %v", data)
+	}
+	changes := data["changes"].([]ObjectChange)
+	want := []AttributeDiff{{AttributeID: "53", Attribute: "Status", From: []string{"In use"}, To: []string{"2"}}}
+	if len(changes) != 2 || changes[0].ObjectKey != "HW-1" || !reflect.DeepEqual(changes[0].Changes, want) {
+		t.Errorf("changes = %+v, want HW-1 and HW-3 moving to status 2", changes)
+	}
+	if len(client.UpdatedObjects) != 0 {
+		t.Errorf("dry run updated %v", client.UpdatedObjects)
+	}
+}
+
+func TestUpdateInstancesApply(t *testing.T) {
+	client := newUpdateClient()
+	report := filepath.Join(t.TempDir(), "update.jsonl")
+
+	var progress int
+	response, err := UpdateInstances(client, common.UpdateInstancesParams{
+		Query:    "objectType = Laptops",
+		Set:      map[string]interface{}{"status": "2", "location": "Warehouse 2"},
+		Confirm:  true,
+		Report:   report,
+		Progress: func(done, total int) { progress = done },
+	})
+	if err != nil || !response.Success {
+		t.Fatalf("UpdateInstances failed: %v %s", err, response.Error)
+	}
+	data := response.Data.(map[string]interface{})
+	if data["updated_count"] != 3 || data["success"] != true || data["report"] != report || progress != 3 {
+		t.Errorf("response = %v, progress = %d", data, progress)
+	}
+
+	// Only the attributes that change are written
+	if got, want := client.UpdatedObjects["2"], []map[string]interface{}{{"54": "Warehouse 2"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("object 2 updated with %v, want %v", got, want)
+	}
+	if got, want := client.UpdatedObjects["1"], []map[string]interface{}{{"53": "2", "54": "Warehouse 2"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("object 1 updated with %v, want %v", got, want)
+	}
+
+	// Objects the report records as updated are skipped when the run is repeated
+	client.UpdatedObjects = make(map[string][]map[string]interface{})
+	response, _ = UpdateInstances(client, common.UpdateInstancesParams{
+		Query:   "objectType = Laptops",
+		Set:     map[string]interface{}{"Location": "Warehouse 3"},
+		Confirm: true,
+		Report:  report,
+	})
+	if data := response.Data.(map[string]interface{}); data["skipped_count"] != 3 || len(client.UpdatedObjects) != 0 {
+		t.Errorf("repeated run = %v, updated %v, want every object skipped", data, client.UpdatedObjects)
+	}
+}
+
+func TestUpdateInstancesErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    common.UpdateInstancesParams
+		failures  map[string]string
+		wantError string
+	}{
+		{
+			name:      "requires targets",
+			params:    common.UpdateInstancesParams{Set: map[string]interface{}{"Location": "x"}},
+			wantError: "either object IDs or a query is required",
+		},
+		{
+			name:      "requires values",
+			params:    common.UpdateInstancesParams{Query: "Name = x"},
+			wantError: "at least one attribute value to set is required",
+		},
+		{
+			name:      "rejects unknown attributes",
+			params:    common.UpdateInstancesParams{Query: "Name = x", Set: map[string]interface{}{"Colour": "red"}, Confirm: true},
+			wantError: "unknown attribute: Colour",
+		},
+		{
+			name:      "rejects invalid values",
+			params:    common.UpdateInstancesParams{Query: "Name = x", Set: map[string]interface{}{"Status": "9"}, Confirm: true},
+			wantError: "invalid status value '9'",
+		},
+		{
+			name:      "rejects system attributes",
+			params:    common.UpdateInstancesParams{IDs: []string{"1"}, Set: map[string]interface{}{"Key": "HW-9"}, Confirm: true},
+			wantError: "attribute Key cannot be edited",
+		},
+		{
+			name:      "refuses to clear required attributes",
+			params:    common.UpdateInstancesParams{IDs: []string{"1"}, Set: map[string]interface{}{"Name": ""}, Confirm: true},
+			wantError: "Name",
+		},
+		{
+			name:      "reports unknown objects",
+			params:    common.UpdateInstancesParams{IDs: []string{"1", "404"}, Set: map[string]interface{}{"Location": "x"}, Confirm: true},
+			wantError: "failed to get object 404",
+		},
+		{
+			name:      "reports search failures",
+			params:    common.UpdateInstancesParams{Query: "Name = x", Set: map[string]interface{}{"Location": "x"}},
+			failures:  map[string]string{"SearchObjects": "API error: 500"},
+			wantError: "API error: 500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newUpdateClient()
+			for method, message := range tt.failures {
+				client.Failures[method] = message
+			}
+
+			response, err := UpdateInstances(client, tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if response.Success || !strings.Contains(response.Error, tt.wantError) {
+				t.Errorf("response = %+v, want error containing %q", response, tt.wantError)
+			}
+			if len(client.UpdatedObjects) != 0 {
+				t.Errorf("updated %v, want nothing written", client.UpdatedObjects)
+			}
+		})
+	}
+}
+
+func TestUpdateObject(t *testing.T) {
+	client := newUpdateClient()
+
+	response, err := UpdateObject(client, common.UpdateObjectParams{ID: "3", Data: map[string]interface{}{"Location": "Desk 4"}})
+	if err != nil || !response.Success {
+		t.Fatalf("UpdateObject failed: %v %s", err, response.Error)
+	}
+	if got, want := client.UpdatedObjects["3"], []map[string]interface{}{{"54": "Desk 4"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("updated %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package foundation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+)
+
+// Kinds of watch event
+const (
+	WatchAdded   = "added"
+	WatchRemoved = "removed"
+	WatchChanged = "changed"
+)
+
+// Most objects a watched query may match; every poll fetches all of them
+const watchMaxObjects = 5000
+
+// Ordering added to a watched query that has none, so its pages do not shift
+// between requests
+const watchOrder = " order by Key"
+
+// WatchEvent is one difference between two polls of a watched query
+type WatchEvent struct {
+	Event           string               `json:"event"`
+	Time            string               `json:"time"`
+	Query           string               `json:"query"`
+	ObjectID        string               `json:"object_id"`
+	ObjectKey       string               `json:"object_key,omitempty"`
+	Label           string               `json:"label,omitempty"`
+	ObjectType      string               `json:"object_type,omitempty"`
+	Updated         string               `json:"updated,omitempty"`
+	PreviousUpdated string               `json:"previous_updated,omitempty"`
+	Object          *models.ObjectScheme `json:"object,omitempty"`
+}
+
+// ObjectWatcher polls an AQL query and reports the objects that entered or left its
+// results, or whose updated timestamp moved, since the previous poll
+type ObjectWatcher struct {
+	client   common.ClientInterface
+	params   common.WatchObjectsParams
+	search   string
+	previous map[string]*models.ObjectScheme
+	now      func() time.Time
+}
+
+// NewObjectWatcher checks the parameters and returns a watcher that has not polled yet
+func NewObjectWatcher(client common.ClientInterface, params common.WatchObjectsParams) (*ObjectWatcher, error) {
+	if params.Query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = defaultPageSize
+	}
+	search := params.Query
+	if !strings.Contains(strings.ToLower(search), "order by") {
+		search += watchOrder
+	}
+	return &ObjectWatcher{client: client, params: params, search: search, now: time.Now}, nil
+}
+
+// Poll runs the query and returns the differences from the previous poll, ordered by
+// object ID. The first poll only records the results, unless Initial is set. After a
+// failed or incomplete poll the next one compares against the last successful one,
+// so a page that could not be fetched never reads as removed objects.
+func (w *ObjectWatcher) Poll(ctx context.Context) ([]*WatchEvent, error) {
+	objects, total, err := searchAll(ctx, w.client, w.search, w.params.PageSize, func(total int) error {
+		if total > watchMaxObjects {
+			return fmt.Errorf("query matches %d objects, more than the %d a watch can follow; narrow the query", total, watchMaxObjects)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]*models.ObjectScheme, len(objects))
+	for _, object := range objects {
+		current[object.ID] = object
+	}
+	if len(current) != total {
+		return nil, fmt.Errorf("poll fetched %d of %d matching objects, skipping it", len(current), total)
+	}
+
+	first := w.previous == nil
+	previous := w.previous
+	w.previous = current
+	if first && !w.params.Initial {
+		return nil, nil
+	}
+
+	at := w.now().UTC().Format(time.RFC3339)
+	var events []*WatchEvent
+	for id, object := range current {
+		before, ok := previous[id]
+		switch {
+		case !ok:
+			events = append(events, w.event(WatchAdded, at, object))
+		case object.Updated != before.Updated:
+			event := w.event(WatchChanged, at, object)
+			event.PreviousUpdated = before.Updated
+			events = append(events, event)
+		}
+	}
+	for id, object := range previous {
+		if _, ok := current[id]; !ok {
+			events = append(events, w.event(WatchRemoved, at, object))
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].ObjectID < events[j].ObjectID })
+	return events, nil
+}
+
+func (w *ObjectWatcher) event(kind, at string, object *models.ObjectScheme) *WatchEvent {
+	event := &WatchEvent{
+		Event:     kind,
+		Time:      at,
+		Query:     w.params.Query,
+		ObjectID:  object.ID,
+		ObjectKey: object.ObjectKey,
+		Label:     object.Label,
+		Updated:   object.Updated,
+		Object:    object,
+	}
+	if object.ObjectType != nil {
+		event.ObjectType = object.ObjectType.Name
+	}
+	return event
+}
@@ -0,0 +1,132 @@
+package foundation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/commontest"
+	"github.com/aaronsb/atlassian-assets/internal/client"
+)
+
+func TestObjectWatcher(t *testing.T) {
+	client := newArchiveClient()
+	servers := &models.ObjectTypeScheme{ID: "9", Name: "Servers"}
+	object := func(id, updated string) *models.ObjectScheme {
+		return &models.ObjectScheme{ID: id, ObjectKey: "SRV-" + id, Label: "srv-" + id, ObjectType: servers, Updated: updated}
+	}
+	client.SearchResults = []*models.ObjectScheme{object("1", "2025-08-30T10:00"), object("2", "2025-08-30T10:00")}
+
+	watcher, err := NewObjectWatcher(client, common.WatchObjectsParams{Query: `objectType = "Servers"`, PageSize: 1})
+	if err != nil {
+		t.Fatalf("NewObjectWatcher failed: %v", err)
+	}
+	watcher.now = func() time.Time { return time.Date(2025, 8, 30, 12, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	// The first poll is the baseline
+	if events, err := watcher.Poll(ctx); err != nil || len(events) != 0 {
+		t.Fatalf("first Poll = %v, %v; want no events", events, err)
+	}
+
+	// Object 1 changed, 2 left the results and 3 entered them
+	client.SearchResults = []*models.ObjectScheme{object("1", "2025-08-30T11:00"), object("3", "2025-08-30T11:00")}
+	events, err := watcher.Poll(ctx)
+	if err != nil || len(events) != 3 {
+		t.Fatalf("second Poll = %v, %v; want 3 events", events, err)
+	}
+	want := []struct{ event, id string }{{WatchChanged, "1"}, {WatchRemoved, "2"}, {WatchAdded, "3"}}
+	for i, w := range want {
+		if events[i].Event != w.event || events[i].ObjectID != w.id {
+			t.Errorf("event %d = %s %s, want %s %s", i, events[i].Event, events[i].ObjectID, w.event, w.id)
+		}
+	}
+	changed := events[0]
+	if changed.PreviousUpdated != "2025-08-30T10:00" || changed.Updated != "2025-08-30T11:00" ||
+		changed.ObjectKey != "SRV-1" || changed.ObjectType != "Servers" || changed.Time != "2025-08-30T12:00:00Z" {
+		t.Errorf("changed event = %+v", changed)
+	}
+
+	// Nothing moved, and a failed poll keeps the last results
+	client.Failures["SearchObjects"] = "rate limited"
+	if _, err := watcher.Poll(ctx); err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Errorf("failed Poll error = %v", err)
+	}
+	delete(client.Failures, "SearchObjects")
+	if events, err := watcher.Poll(ctx); err != nil || len(events) != 0 {
+		t.Errorf("unchanged Poll = %v, %v; want no events", events, err)
+	}
+}
+
+func TestObjectWatcherInitial(t *testing.T) {
+	client := newArchiveClient()
+	client.SearchResults = []*models.ObjectScheme{client.Objects["100"], client.Objects["200"]}
+
+	watcher, _ := NewObjectWatcher(client, common.WatchObjectsParams{Query: "objectSchemaId = 1", Initial: true})
+	events, err := watcher.Poll(context.Background())
+	if err != nil || len(events) != 2 || events[0].Event != WatchAdded || events[1].ObjectKey != "EMP-1" {
+		t.Errorf("initial Poll = %v, %v; want both objects added", events, err)
+	}
+
+	if _, err := NewObjectWatcher(client, common.WatchObjectsParams{}); err == nil {
+		t.Error("NewObjectWatcher without a query succeeded")
+	}
+}
+
+// laterPageClient fails, or comes back empty, for every page after the first
+type laterPageClient struct {
+	*commontest.MockClient
+	failure string
+	empty   bool
+}
+
+func (c *laterPageClient) SearchObjectsWithPagination(ctx context.Context, query string, limit int, offset int) (*client.Response, error) {
+	if offset > 0 && c.failure != "" {
+		return client.NewErrorResponse(errors.New(c.failure)), nil
+	}
+	if offset > 0 && c.empty {
+		return c.MockClient.SearchObjectsWithPagination(ctx, query, limit, len(c.SearchResults))
+	}
+	return c.MockClient.SearchObjectsWithPagination(ctx, query, limit, offset)
+}
+
+func TestObjectWatcherIncompletePoll(t *testing.T) {
+	mock := commontest.NewMockClient()
+	for _, id := range []string{"1", "2", "3"} {
+		mock.SearchResults = append(mock.SearchResults, &models.ObjectScheme{ID: id, Updated: "2025-08-30T10:00"})
+	}
+	pages := &laterPageClient{MockClient: mock}
+
+	watcher, _ := NewObjectWatcher(pages, common.WatchObjectsParams{Query: "objectSchemaId = 1", PageSize: 1})
+	ctx := context.Background()
+	if _, err := watcher.Poll(ctx); err != nil {
+		t.Fatalf("first Poll failed: %v", err)
+	}
+	if got := mock.SearchQueries[0]; got != "objectSchemaId = 1 order by Key" {
+		t.Errorf("watched query = %q, want it ordered by Key", got)
+	}
+
+	// A failed or short later page skips the tick instead of removing objects
+	pages.failure = "rate limited"
+	if events, err := watcher.Poll(ctx); err == nil || len(events) != 0 {
+		t.Errorf("Poll with a failed page = %v, %v; want an error and no events", events, err)
+	}
+	pages.failure, pages.empty = "", true
+	if events, err := watcher.Poll(ctx); err == nil || len(events) != 0 {
+		t.Errorf("Poll with a short page = %v, %v; want an error and no events", events, err)
+	}
+	pages.empty = false
+	if events, err := watcher.Poll(ctx); err != nil || len(events) != 0 {
+		t.Errorf("complete Poll = %v, %v; want no events", events, err)
+	}
+
+	// An ordering the query already has is kept
+	watcher, _ = NewObjectWatcher(mock, common.WatchObjectsParams{Query: "objectSchemaId = 1 ORDER BY Updated desc"})
+	if watcher.search != "objectSchemaId = 1 ORDER BY Updated desc" {
+		t.Errorf("watched query = %q, want it unchanged", watcher.search)
+	}
+}
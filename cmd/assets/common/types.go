@@ -2,7 +2,11 @@ package common
 
 import (
 	"context"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
 	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+	"github.com/aaronsb/atlassian-assets/internal/snapshot"
 )
 
 // Parameter structures for foundation functions
@@ -29,6 +33,12 @@ type GetParams struct {
 	ID string // Object ID
 }
 
+type ObjectHistoryParams struct {
+	ID        string // Object ID or key
+	Attribute string // Only changes to this attribute, by name or ID
+	Limit     int    // Maximum entries, newest first (0 for all)
+}
+
 type CreateObjectTypeParams struct {
 	Schema      string  // Schema ID or name
 	Name        string  // Object type name
@@ -82,6 +92,148 @@ type TraceRelationshipsParams struct {
 	Depth    int    // Relationship depth
 }
 
+type CopyAttributesParams struct {
+	From         string // Source object type ID
+	To           string // Destination object type ID
+	DryRun       bool   // Plan the copy without creating attributes
+	SkipExisting bool   // Skip attributes that already exist on the destination
+}
+
+type ExtractAttributesParams struct {
+	ObjectID          string // Object ID to extract attribute values from
+	ObjectTypeID      string // Object type ID to extract the attribute schema from
+	ResolveReferences bool   // Resolve reference targets
+	IncludeSystem     bool   // Include system attributes (Created, Updated, Key)
+}
+
+type ApplyAttributesParams struct {
+	ObjectTypeID      string                   // Target object type ID
+	Attributes        []map[string]interface{} // Extracted attribute definitions
+	Select            []string                 // Attribute names to apply (all when empty)
+	SkipReferences    bool                     // Skip reference attributes
+	ReferenceMappings map[string]string        // Source -> target referenced object type IDs
+	DryRun            bool                     // Plan the application without creating attributes
+	ForceOverwrite    bool                     // Apply attributes whose names already exist
+}
+
+type TraceReferenceParams struct {
+	AttributeID   string // Attribute ID to trace
+	AttributeName string // Attribute name to trace (requires ObjectTypeID)
+	ObjectTypeID  string // Object type ID containing the attribute
+	SchemaID      string // Schema to search when only the attribute ID is known
+}
+
+type TraceDependenciesParams struct {
+	ObjectTypeID string // Object type ID to analyze
+	SchemaID     string // Schema ID
+	AllSchemas   bool   // Analyze across all schemas
+}
+
+type CatalogAttributesParams struct {
+	Pattern string // Case-insensitive regex matched against names and descriptions
+	Schema  string // Limit to a schema ID
+	Page    int    // Page number (1-based)
+	PerPage int    // Results per page
+	All     bool   // Disable pagination
+}
+
+type ResolveParams struct {
+	Kind   string // schema, object_type or object
+	Name   string // Name to resolve to an ID
+	ID     string // ID to resolve to a name
+	Schema string // Schema name or ID for object type resolution
+	Ref    string // Object reference (ID, key, or schema/key)
+}
+
+type SchemaSummaryParams struct {
+	SchemaID string // Schema ID
+}
+
+type RemoveAttributeParams struct {
+	ObjectTypeID  string // Object type ID to remove the attribute from
+	AttributeID   string // Attribute ID to remove
+	AttributeName string // Attribute name to remove
+	Confirm       bool   // Explicit confirmation for the removal
+}
+
+type RemoveRelationshipParams struct {
+	ObjectID         string // Object ID to remove the relationship from
+	RelationshipID   string // Relationship ID to remove
+	RelationshipType string // Relationship type to remove
+	TargetID         string // Target object ID for removal by type
+	Confirm          bool   // Explicit confirmation for the removal
+}
+
+type RemovePropertyParams struct {
+	ObjectID      string   // Object ID to remove properties from
+	PropertyID    string   // Property ID to remove
+	PropertyNames []string // Property names to remove
+	Confirm       bool     // Explicit confirmation for the removal
+}
+
+type DeleteObjectTypeParams struct {
+	ID           string // Object type ID
+	Confirm      bool   // Explicit confirmation for the deletion
+	PreviewToken string // Token from the deletion preview, required with Confirm
+	Force        bool   // Delete without checking the preview token
+	ExpectCount  *int   // Number of instances a forced deletion must find (not checked when nil)
+}
+
+type DeleteInstancesParams struct {
+	IDs         []string              // Object IDs to delete
+	Query       string                // AQL query selecting objects to delete
+	Limit       int                   // Maximum number of objects a query may match
+	ExpectCount *int                  // Number of objects the query must match, required with a query
+	Confirm     bool                  // Explicit confirmation for the deletion
+	Archive     string                // Archive file to snapshot objects into before deleting them (none when empty)
+	Concurrency int                   // Number of objects deleted at once
+	PageSize    int                   // Number of objects fetched per search page
+	Report      string                // Report file for per-object results; an existing report is resumed
+	Progress    func(done, total int) // Called after each object is processed
+}
+
+type UpdateInstancesParams struct {
+	IDs         []string               // Object IDs to update
+	Query       string                 // AQL query selecting objects to update
+	Set         map[string]interface{} // New values by attribute name; an empty value clears the attribute
+	Confirm     bool                   // Apply the changes; without it they are only reported
+	Concurrency int                    // Number of objects updated at once
+	PageSize    int                    // Number of objects fetched per search page
+	Report      string                 // Report file for per-object results; an existing report is resumed
+	Progress    func(done, total int)  // Called after each object is processed
+}
+
+type SetObjectTypeIconParams struct {
+	ObjectTypeID string // Object type ID
+	Icon         string // Icon ID or name
+}
+
+type CopyObjectTypeParams struct {
+	SourceID    string // Object type to copy
+	Name        string // Name of the new object type
+	Description string // Description of the new object type (the source's when empty)
+	AsChild     bool   // Create the copy as a child of the source instead of a sibling
+	DryRun      bool   // Plan the copy without creating anything
+}
+
+type RestoreObjectsParams struct {
+	Archive string   // Archive file written by a deletion
+	IDs     []string // Original IDs of the objects to restore (all unrestored objects when empty)
+}
+
+type PullSnapshotParams struct {
+	Schemas  []string              // Schema IDs or names to pull
+	File     string                // Snapshot file; schemas already in it are replaced
+	PageSize int                   // Number of objects fetched per search page
+	Progress func(done, total int) // Called after each schema is pulled
+}
+
+type WatchObjectsParams struct {
+	Query    string // AQL query whose results are watched
+	PageSize int    // Number of objects fetched per search page
+	Initial  bool   // Report the objects of the first poll as added
+}
+
 // Response wrapper for consistent output
 type Response struct {
 	Success bool        `json:"success"`
@@ -107,15 +259,43 @@ func NewErrorResponse(err error) *Response {
 
 // Client interface for dependency injection
 type ClientInterface interface {
+	SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error)
 	SearchObjectsWithPagination(ctx context.Context, query string, limit int, offset int) (*client.Response, error)
+	ListObjects(ctx context.Context, schemaID string, limit int) (*client.Response, error)
 	ListObjectsWithPagination(ctx context.Context, schemaID string, limit int, offset int) (*client.Response, error)
 	GetObject(ctx context.Context, objectID string) (*client.Response, error)
+	GetObjectHistory(ctx context.Context, objectID string) (*client.Response, error)
+	SearchUsers(ctx context.Context, query string, limit int) (*client.Response, error)
+	SearchGroups(ctx context.Context, query string, limit int) (*client.Response, error)
+	GetStatusTypes(ctx context.Context, schemaID string) (*client.Response, error)
 	CreateObjectType(ctx context.Context, schemaID, name, description, iconID string, parentObjectTypeID *string) (*client.Response, error)
 	CreateObject(ctx context.Context, objectTypeID string, attributes map[string]interface{}) (*client.Response, error)
+	UpdateObject(ctx context.Context, objectID, objectTypeID string, attributes map[string]interface{}) (*client.Response, error)
 	DeleteObject(ctx context.Context, objectID string) (*client.Response, error)
 	GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error)
 	ListSchemas(ctx context.Context) (*client.Response, error)
+	CreateSchema(ctx context.Context, name, description string) (*client.Response, error)
 	GetSchema(ctx context.Context, schemaID string) (*client.Response, error)
 	GetObjectTypes(ctx context.Context, schemaID string) (*client.Response, error)
+	GetObjectType(ctx context.Context, objectTypeID string) (*client.Response, error)
+	UpdateObjectType(ctx context.Context, objectTypeID string, payload *models.ObjectTypePayloadScheme) (*client.Response, error)
+	DeleteObjectType(ctx context.Context, objectTypeID string) (*client.Response, error)
+	ListIcons(ctx context.Context) (*client.Response, error)
+	CreateObjectTypeAttribute(ctx context.Context, objectTypeID string, payload *models.ObjectTypeAttributePayloadScheme) (*client.Response, error)
+	RemoveAttribute(ctx context.Context, objectTypeID, attributeID string) (*client.Response, error)
+	RemoveRelationship(ctx context.Context, objectID, relationshipID string) (*client.Response, error)
+	RemoveRelationshipByType(ctx context.Context, objectID, relationshipType, targetID string) (*client.Response, error)
+	RemoveProperty(ctx context.Context, objectID, propertyID string) (*client.Response, error)
+	RemovePropertyByName(ctx context.Context, objectID, propertyName string) (*client.Response, error)
+	GetWorkspaceID() string
+	GetConfig() *config.Config
+	IsDeleteAllowed() bool
+	TestConnection(ctx context.Context) error
 	Close() error
-}
\ No newline at end of file
+}
+
+// Ensure the real and offline clients satisfy the interface shared by the CLI and MCP server
+var (
+	_ ClientInterface = (*client.AssetsClient)(nil)
+	_ ClientInterface = (*snapshot.Client)(nil)
+)
\ No newline at end of file
@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
 	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
 	"github.com/aaronsb/atlassian-assets/internal/client"
 )
 
@@ -61,8 +64,6 @@ func runCreateObjectTypeCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	ctx := context.Background()
-	
 	// Resolve parent name to ID if needed
 	var parentPtr *string
 	if createObjectTypeParent != "" {
@@ -70,24 +71,35 @@ func runCreateObjectTypeCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create the object type
-	response, err := client.CreateObjectType(ctx, createObjectTypeSchema, createObjectTypeName, createObjectTypeDescription, createObjectTypeIcon, parentPtr)
+	response, err := sharedResult(foundation.CreateObjectType(client, common.CreateObjectTypeParams{
+		Schema:      createObjectTypeSchema,
+		Name:        createObjectTypeName,
+		Description: createObjectTypeDescription,
+		Parent:      parentPtr,
+		Icon:        createObjectTypeIcon,
+	}))
 	if err != nil {
-		return fmt.Errorf("failed to create object type: %w", err)
+		return err
 	}
 
 	// Add contextual hints
-	if response.Success {
-		enhancedResponse := addNextStepHints(response, "create_object_type", map[string]interface{}{
-			"object_type_name": createObjectTypeName,
-			"schema_id":        createObjectTypeSchema,
-			"has_parent":       parentPtr != nil,
-			"has_description":  createObjectTypeDescription != "",
-			"has_custom_icon":  createObjectTypeIcon != "",
-		})
-		return outputResult(enhancedResponse)
-	}
+	enhancedResponse := addNextStepHints(response, "create_object_type", map[string]interface{}{
+		"object_type_name": createObjectTypeName,
+		"object_type_id":   createdObjectTypeID(sharedData(response)),
+		"schema_id":        createObjectTypeSchema,
+		"has_parent":       parentPtr != nil,
+		"has_description":  createObjectTypeDescription != "",
+		"has_custom_icon":  createObjectTypeIcon != "",
+	})
+	return outputResult(enhancedResponse)
+}
 
-	return outputResult(response)
+// createdObjectTypeID returns the ID of the object type a create call returned
+func createdObjectTypeID(data map[string]interface{}) string {
+	if objectType, ok := data["object_type"].(*models.ObjectTypeScheme); ok {
+		return objectType.ID
+	}
+	return ""
 }
 
 // CREATE INSTANCE subcommand (legacy compatibility)
@@ -141,10 +153,13 @@ func createAsset(ctx context.Context, client *client.AssetsClient, schema, objec
 		return fmt.Errorf("failed to parse JSON data: %w", err)
 	}
 
-	// 2. Create the object using our client
-	response, err := client.CreateObject(ctx, objectType, attributes)
+	// 2. Create the object, subject to the validation rules
+	response, err := sharedResult(foundation.CreateObject(client, common.CreateObjectParams{
+		ObjectTypeID: objectType,
+		Attributes:   attributes,
+	}))
 	if err != nil {
-		return fmt.Errorf("failed to create object: %w", err)
+		return err
 	}
 
 	// 3. Add hint suggesting better approach
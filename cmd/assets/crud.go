@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+	"github.com/aaronsb/atlassian-assets/internal/bulk"
 	"github.com/aaronsb/atlassian-assets/internal/validation"
 )
 
@@ -48,7 +51,7 @@ func init() {
 }
 
 func runListCmd(cmd *cobra.Command, args []string) error {
-	client, err := getClient()
+	client, err := getReadClient()
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
@@ -76,21 +79,24 @@ func runListCmd(cmd *cobra.Command, args []string) error {
 var getCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a specific asset object",
-	Long: `Get details of a specific asset object by its ID.`,
-	Example: `  # Get asset by ID
-  assets get --id OBJ-123`,
+	Long: `Get details of a specific asset object by its ID, key, or schema/key or schema/label reference.`,
+	Example: `  # Get asset by key
+  assets get --id OBJ-123
+
+  # Get asset by label within a schema
+  assets get --id "IT/Laptop 42"`,
 	RunE: runGetCmd,
 }
 
 var getID string
 
 func init() {
-	getCmd.Flags().StringVar(&getID, "id", "", "Object ID (required)")
+	getCmd.Flags().StringVar(&getID, "id", "", "Object ID, key or schema/label reference (required)")
 	getCmd.MarkFlagRequired("id")
 }
 
 func runGetCmd(cmd *cobra.Command, args []string) error {
-	client, err := getClient()
+	client, err := getReadClient()
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
@@ -108,26 +114,57 @@ func runGetCmd(cmd *cobra.Command, args []string) error {
 // UPDATE command
 var updateCmd = &cobra.Command{
 	Use:   "update",
-	Short: "Update an existing asset object",
-	Long: `Update an existing asset object with new data.
-	
-The data should be provided as a JSON string containing the attributes to update.`,
-	Example: `  # Update asset owner
-  assets update --id OBJ-123 --data '{"owner":"jane.doe"}'`,
+	Short: "Update asset objects",
+	Long: `Set attribute values on asset objects, chosen by ID or by an AQL query.
+
+Values are given with --set 'Attribute=Value', once per attribute; repeat an
+attribute to give a multi-value attribute several values, and leave the value
+empty to clear it. --data takes the same changes as a JSON object. Values are
+checked against each object type's attributes before anything is written, as
+they are on create; status attributes take a status name or ID.
+
+By default nothing is written: the command prints the changes it would make to
+each object, leaving out objects that already have the new values. Add --confirm
+to apply them. Objects are updated concurrently, with a progress bar on a
+terminal, and the outcome for each object is written to a report file that
+--resume picks up after an interruption.`,
+	Example: `  # Preview setting the status on everything a query matches
+  assets update --query "objectType = Laptops AND Location = 'Warehouse 1'" --set 'Status=Retired'
+  
+  # Apply several changes
+  assets update --query "objectType = Laptops AND Location = 'Warehouse 1'" --set 'Status=Retired' --set 'Location=Warehouse 2' --confirm
+  
+  # Update one object from JSON
+  assets update --id 123 --data '{"Owner":"jane.doe@example.com"}' --confirm`,
 	RunE: runUpdateCmd,
 }
 
 var (
-	updateID   string
-	updateData string
+	updateID          string
+	updateQuery       string
+	updateSet         []string
+	updateData        string
+	updateConfirm     bool
+	updateConcurrency int
+	updateBatchSize   int
+	updateReport      string
+	updateResume      string
 )
 
 func init() {
-	updateCmd.Flags().StringVar(&updateID, "id", "", "Object ID (required)")
-	updateCmd.Flags().StringVar(&updateData, "data", "", "Updated data as JSON string (required)")
-	
-	updateCmd.MarkFlagRequired("id")
-	updateCmd.MarkFlagRequired("data")
+	updateCmd.Flags().StringVar(&updateID, "id", "", "Object IDs or keys to update (comma-separated)")
+	updateCmd.Flags().StringVar(&updateQuery, "query", "", "AQL query selecting the objects to update")
+	updateCmd.Flags().StringArrayVar(&updateSet, "set", nil, "Attribute value to set as 'Attribute=Value' (repeatable)")
+	updateCmd.Flags().StringVar(&updateData, "data", "", "Attribute values to set as a JSON object")
+	updateCmd.Flags().BoolVar(&updateConfirm, "confirm", false, "Apply the changes instead of previewing them")
+	updateCmd.Flags().IntVar(&updateConcurrency, "concurrency", bulk.DefaultConcurrency, "Number of objects updated at once")
+	updateCmd.Flags().IntVar(&updateBatchSize, "batch-size", 100, "Number of matches fetched per search page")
+	updateCmd.Flags().StringVar(&updateReport, "report", "", "Report file for per-object results (default: a new file under the cache directory)")
+	updateCmd.Flags().StringVar(&updateResume, "resume", "", "Report of an interrupted run to resume")
+
+	updateCmd.MarkFlagsMutuallyExclusive("id", "query")
+	updateCmd.MarkFlagsOneRequired("id", "query")
+	updateCmd.MarkFlagsMutuallyExclusive("report", "resume")
 }
 
 func runUpdateCmd(cmd *cobra.Command, args []string) error {
@@ -137,15 +174,99 @@ func runUpdateCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	response := NewSuccessResponse(map[string]interface{}{
-		"action": "update",
-		"id":     updateID,
-		"data":   updateData,
-		"status": "not_implemented",
-		"message": "Asset update will be implemented using go-atlassian SDK",
-	})
+	values, err := parseUpdateValues(updateData, updateSet)
+	if err != nil {
+		return err
+	}
 
-	return outputResult(response)
+	var ids []string
+	if updateID != "" {
+		ids = strings.Split(updateID, ",")
+	}
+
+	reportPath := updateReport
+	if updateResume != "" {
+		if _, err := os.Stat(updateResume); err != nil {
+			return fmt.Errorf("cannot resume: %w", err)
+		}
+		reportPath = updateResume
+	}
+	if reportPath == "" && updateConfirm {
+		cacheDir, err := client.GetConfig().GetCacheDir()
+		if err != nil {
+			return fmt.Errorf("failed to locate report directory: %w", err)
+		}
+		reportPath = bulk.DefaultReportPath(cacheDir, "update-instances")
+	}
+
+	var progress func(done, total int)
+	if updateConfirm {
+		progress = newProgressBar("Updating")
+	}
+
+	response, err := sharedResult(foundation.UpdateInstances(client, common.UpdateInstancesParams{
+		IDs:         ids,
+		Query:       updateQuery,
+		Set:         values,
+		Confirm:     updateConfirm,
+		Concurrency: updateConcurrency,
+		PageSize:    updateBatchSize,
+		Report:      reportPath,
+		Progress:    progress,
+	}))
+	if err != nil {
+		return err
+	}
+
+	data := sharedData(response)
+	_, hasErrors := data["errors"]
+	succeeded, _ := data["success"].(bool)
+	dryRun, _ := data["dry_run"].(bool)
+
+	hintVars := map[string]interface{}{
+		"success":    succeeded && !dryRun,
+		"has_errors": hasErrors,
+		"dry_run":    dryRun && data["changed_count"] != 0,
+		"report":     data["report"],
+	}
+	if changes, ok := data["changes"].([]foundation.ObjectChange); ok && len(changes) > 0 {
+		hintVars["object_id"] = changes[0].ObjectID
+	}
+
+	return outputResult(addNextStepHints(response, "update_instances", hintVars))
+}
+
+// parseUpdateValues merges --data and --set into attribute values by name. A repeated
+// --set attribute collects several values.
+func parseUpdateValues(data string, set []string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &values); err != nil {
+			return nil, fmt.Errorf("invalid --data JSON: %w", err)
+		}
+	}
+
+	repeated := make(map[string][]string)
+	for _, assignment := range set {
+		name, value, ok := strings.Cut(assignment, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --set %q, use 'Attribute=Value'", assignment)
+		}
+		repeated[name] = append(repeated[name], value)
+	}
+	for name, list := range repeated {
+		if len(list) == 1 {
+			values[name] = list[0]
+		} else {
+			values[name] = list
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("nothing to update: give attribute values with --set or --data")
+	}
+	return values, nil
 }
 
 // DELETE command is now implemented in delete.go
@@ -203,7 +324,7 @@ func init() {
 	searchCmd.Flags().StringVar(&searchSchema, "schema", "", "Limit search to specific schema (ID or name)")
 	searchCmd.Flags().StringVar(&searchType, "type", "", "Limit search to specific object type")
 	searchCmd.Flags().StringVar(&searchStatus, "status", "", "Filter by status (Active, Inactive, etc.)")
-	searchCmd.Flags().StringVar(&searchOwner, "owner", "", "Filter by owner/assignee")
+	searchCmd.Flags().StringVar(&searchOwner, "owner", "", "Filter by owner/assignee (email, display name or account ID)")
 	searchCmd.Flags().IntVar(&searchLimit, "limit", 50, "Maximum number of results to return (1-1000)")
 	searchCmd.Flags().IntVar(&searchOffset, "offset", 0, "Number of results to skip (for pagination)")
 	
@@ -217,173 +338,42 @@ func runSearchCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --query or --simple must be provided")
 	}
 
-	client, err := getClient()
+	client, err := getReadClient()
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 	defer client.Close()
 
-	ctx := context.Background()
-	
-	// Validate pagination parameters
-	if searchLimit < 1 || searchLimit > 1000 {
-		return fmt.Errorf("limit must be between 1 and 1000, got %d", searchLimit)
-	}
-	if searchOffset < 0 {
-		return fmt.Errorf("offset must be 0 or greater, got %d", searchOffset)
-	}
-	
-	// Build AQL query based on input type
-	var finalQuery string
-	var queryType string
-	
-	if searchQuery != "" {
-		// Use direct AQL query
-		finalQuery = searchQuery
-		queryType = "aql"
-	} else {
-		// Build AQL from simple search terms
-		finalQuery, err = buildSimpleSearchQuery(searchSimple, searchSchema, searchType, searchStatus, searchOwner)
-		if err != nil {
-			return fmt.Errorf("failed to build search query: %w", err)
-		}
-		queryType = "simple"
-	}
-	
-	response, err := client.SearchObjectsWithPagination(ctx, finalQuery, searchLimit, searchOffset)
+	response, err := sharedResult(foundation.SearchObjects(client, common.SearchParams{
+		Query:  searchQuery,
+		Simple: searchSimple,
+		Schema: searchSchema,
+		Type:   searchType,
+		Status: searchStatus,
+		Owner:  searchOwner,
+		Limit:  searchLimit,
+		Offset: searchOffset,
+	}))
 	if err != nil {
-		return fmt.Errorf("failed to search objects: %w", err)
+		return err
 	}
 
+	data := sharedData(response)
+	total, _ := data["total"].(int)
+
 	// Add contextual hints
 	enhancedResponse := addNextStepHints(response, "search_objects", map[string]interface{}{
-		"search_query":     finalQuery,
-		"query_type":       queryType,
+		"search_query":     data["query"],
+		"query_type":       data["query_type"],
 		"simple_term":      searchSimple,
-		"search_filters":   buildFilterSummary(),
+		"search_filters":   data["search_filters"],
 		"success":          response.Success,
-		"has_results":      getResultCount(response) > 0,
+		"has_results":      total > 0,
 	})
 
 	return outputResult(enhancedResponse)
 }
 
-// buildSimpleSearchQuery converts simple search terms into AQL with regex-inspired filters
-func buildSimpleSearchQuery(term, schema, objectType, status, owner string) (string, error) {
-	var conditions []string
-	
-	// Add schema filter if provided
-	if schema != "" {
-		conditions = append(conditions, fmt.Sprintf("objectSchemaId = %s", schema))
-	}
-	
-	// Add object type filter if provided
-	if objectType != "" {
-		conditions = append(conditions, fmt.Sprintf("objectType = \"%s\"", objectType))
-	}
-	
-	// Add status filter if provided
-	if status != "" {
-		conditions = append(conditions, fmt.Sprintf("Status = \"%s\"", status))
-	}
-	
-	// Add owner filter if provided
-	if owner != "" {
-		conditions = append(conditions, fmt.Sprintf("Owner = \"%s\"", owner))
-	}
-	
-	// Add simple term search with regex-inspired patterns
-	if term != "" {
-		termCondition := buildTermSearchCondition(term)
-		conditions = append(conditions, termCondition)
-	}
-	
-	// If no conditions, return error
-	if len(conditions) == 0 {
-		return "", fmt.Errorf("no search conditions provided")
-	}
-	
-	// Combine all conditions with AND
-	query := conditions[0]
-	for _, cond := range conditions[1:] {
-		query += " AND " + cond
-	}
-	
-	return query, nil
-}
-
-// buildTermSearchCondition creates AQL search condition with basic patterns (LIKE queries are non-functional)
-func buildTermSearchCondition(term string) string {
-	// Determine search pattern based on term format
-	var nameCondition, keyCondition string
-	
-	switch {
-	case term == "*":
-		// Wildcard - match all non-empty values
-		return "(Name != \"\" OR Key != \"\")"
-		
-	case strings.HasPrefix(term, "^") && strings.HasSuffix(term, "$"):
-		// Exact match: ^exact$ -> exact match
-		exactTerm := strings.TrimSuffix(strings.TrimPrefix(term, "^"), "$")
-		nameCondition = fmt.Sprintf("Name = \"%s\"", exactTerm)
-		keyCondition = fmt.Sprintf("Key = \"%s\"", exactTerm)
-		
-	case strings.HasPrefix(term, "="):
-		// Exact match: =value -> exact match
-		exactTerm := strings.TrimPrefix(term, "=")
-		nameCondition = fmt.Sprintf("Name = \"%s\"", exactTerm)
-		keyCondition = fmt.Sprintf("Key = \"%s\"", exactTerm)
-		
-	default:
-		// Default: exact match only (LIKE queries don't work in this AQL implementation)
-		// This is a limitation - partial matches are not supported
-		nameCondition = fmt.Sprintf("Name = \"%s\"", term)
-		keyCondition = fmt.Sprintf("Key = \"%s\"", term)
-	}
-	
-	// Return combined condition
-	return fmt.Sprintf("(%s OR %s)", nameCondition, keyCondition)
-}
-
-// buildFilterSummary creates a summary of active filters for hints
-func buildFilterSummary() map[string]string {
-	filters := make(map[string]string)
-	
-	if searchSchema != "" {
-		filters["schema"] = searchSchema
-	}
-	if searchType != "" {
-		filters["type"] = searchType
-	}
-	if searchStatus != "" {
-		filters["status"] = searchStatus
-	}
-	if searchOwner != "" {
-		filters["owner"] = searchOwner
-	}
-	
-	return filters
-}
-
-// getResultCount extracts result count from response  
-func getResultCount(response *client.Response) int {
-	if !response.Success || response.Data == nil {
-		return 0
-	}
-	
-	data, ok := response.Data.(map[string]interface{})
-	if !ok {
-		return 0
-	}
-	
-	total, ok := data["total"].(int)
-	if !ok {
-		return 0
-	}
-	
-	return total
-}
-
 // ATTRIBUTES command
 var attributesCmd = &cobra.Command{
 	Use:   "attributes",
@@ -413,14 +403,12 @@ func init() {
 }
 
 func runAttributesCmd(cmd *cobra.Command, args []string) error {
-	client, err := getClient()
+	client, err := getReadClient()
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 	defer client.Close()
 
-	ctx := context.Background()
-	
 	// For now, require direct object type ID to avoid cache timeout issues
 	// TODO: Fix resolver cache performance issues before enabling name resolution
 	objectTypeID := attributesType
@@ -428,9 +416,11 @@ func runAttributesCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("name resolution temporarily disabled due to cache timeout issues. Please use object type ID directly (e.g., --type 133)")
 	}
 	
-	response, err := client.GetObjectTypeAttributes(ctx, objectTypeID)
+	response, err := sharedResult(foundation.GetObjectTypeAttributes(client, common.GetObjectTypeAttributesParams{
+		ObjectTypeID: objectTypeID,
+	}))
 	if err != nil {
-		return fmt.Errorf("failed to get object type attributes: %w", err)
+		return err
 	}
 
 	return outputResult(response)
@@ -3,9 +3,14 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+	"github.com/aaronsb/atlassian-assets/internal/bulk"
+	"github.com/aaronsb/atlassian-assets/internal/resolver"
 )
 
 // DELETE command with subcommands - for permanent deletion of entities
@@ -36,15 +41,28 @@ var deleteObjectTypeCmd = &cobra.Command{
 	Long: `Delete an object type from a schema.
 	
 WARNING: This will also delete all instances of this object type.
-Use with caution as this operation cannot be undone.`,
-	Example: `  # Delete object type by ID
+Use with caution as this operation cannot be undone.
+
+Without --confirm the command only previews the deletion: the number of
+instances, the child object types and the attributes in other object types that
+reference this one. The preview ends with a token. Pass it with --confirm to
+delete; if anything has changed since the preview, the token is refused.
+
+--force deletes without the token, for scripts that cannot take it from a
+preview. It still requires --expect-count, which must equal the number of
+instances the object type has; otherwise nothing is deleted and the actual
+number is reported.`,
+	Example: `  # Preview the deletion of an object type by ID
   assets delete object-type --id 123
   
-  # Delete object type by name within schema
-  assets delete object-type --name "Old Servers" --schema 6
+  # Preview by name within a schema
+  assets delete object-type --name "Old Servers" --schema "IT Assets"
   
-  # Force delete without confirmation
-  assets delete object-type --id 123 --force`,
+  # Delete after reviewing the preview
+  assets delete object-type --id 123 --confirm --token 3f9a1c2b7d4e
+  
+  # Delete without a preview token, stating how many instances go with it
+  assets delete object-type --id 123 --force --expect-count 42`,
 	RunE: runDeleteObjectTypeCmd,
 }
 
@@ -55,15 +73,35 @@ var deleteInstanceCmd = &cobra.Command{
 	Long: `Delete one or more object instances.
 	
 Can delete single instances or multiple instances at once.
-Provides confirmation prompts for safety.`,
+Provides confirmation prompts for safety.
+
+Deleting by --query covers every page of matches. --expect-count is required
+and must equal the live number of matches, and --limit is a hard cap on that
+number; otherwise nothing is deleted. Objects are deleted concurrently, with a
+progress bar on a terminal, and the outcome for each object is written to a
+report file. If a run is interrupted, pass its report to --resume: objects it
+records as deleted are skipped and count towards --expect-count.
+
+Deleted objects cannot be recovered from Assets. With --archive each object is
+first snapshotted into a local archive (a JSON-lines file in the cache
+directory): its type, every attribute value and the attributes of other objects
+that reference it. An object that cannot be snapshotted is not deleted.
+Recreate archived objects with 'assets restore'.`,
 	Example: `  # Delete single instance
   assets delete instance --id 456
   
   # Delete multiple instances
   assets delete instance --id 456,789
   
-  # Delete instances by AQL query
-  assets delete instance --query "Name like 'temp%'"
+  # Delete every instance matched by an AQL query, stating how many match
+  assets delete instance --query "Name like 'temp%'" --expect-count 240 --limit 500 --confirm
+  
+  # Resume an interrupted bulk delete from its report
+  assets delete instance --query "Name like 'temp%'" --expect-count 240 --limit 500 --confirm \
+    --resume ~/.cache/atlassian-assets/reports/delete-instances-20250101-120000.jsonl
+  
+  # Archive instances before deleting them, so they can be restored
+  assets delete instance --query "objectTypeId = 23" --expect-count 42 --limit 50 --confirm --archive
   
   # Force delete without confirmation
   assets delete instance --id 456 --force`,
@@ -75,32 +113,52 @@ var (
 	deleteID      string
 	deleteForce   bool
 	deleteConfirm bool
+	deleteToken   string
 	
 	// Object type specific flags
 	deleteObjectTypeName   string
 	deleteObjectTypeSchema string
+	deleteObjectTypeExpect int
 	
 	// Instance specific flags
-	deleteInstanceQuery string
-	deleteInstanceLimit int
+	deleteInstanceQuery       string
+	deleteInstanceLimit       int
+	deleteInstanceArchive     bool
+	deleteInstanceArchiveFile string
+	deleteInstanceExpect      int
+	deleteInstanceConcurrency int
+	deleteInstanceBatchSize   int
+	deleteInstanceReport      string
+	deleteInstanceResume      string
 )
 
 func init() {
 	// Common flags
 	deleteObjectTypeCmd.Flags().StringVar(&deleteID, "id", "", "Object type ID to delete")
-	deleteObjectTypeCmd.Flags().BoolVar(&deleteForce, "force", false, "Force deletion without confirmation")
+	deleteObjectTypeCmd.Flags().BoolVar(&deleteForce, "force", false, "Delete without the preview token (requires --expect-count)")
 	deleteObjectTypeCmd.Flags().BoolVar(&deleteConfirm, "confirm", false, "Confirm deletion")
 	
 	// Object type specific flags
 	deleteObjectTypeCmd.Flags().StringVar(&deleteObjectTypeName, "name", "", "Object type name to delete")
-	deleteObjectTypeCmd.Flags().StringVar(&deleteObjectTypeSchema, "schema", "", "Schema ID when deleting by name")
+	deleteObjectTypeCmd.Flags().StringVar(&deleteObjectTypeSchema, "schema", "", "Schema name or ID when deleting by name")
+	deleteObjectTypeCmd.Flags().StringVar(&deleteToken, "token", "", "Confirmation token from the deletion preview")
+	deleteObjectTypeCmd.Flags().IntVar(&deleteObjectTypeExpect, "expect-count", -1, "Number of instances the object type must have (required with --force)")
+	deleteObjectTypeCmd.MarkFlagsMutuallyExclusive("id", "name")
 	
 	// Instance flags
-	deleteInstanceCmd.Flags().StringVar(&deleteID, "id", "", "Instance ID(s) to delete (comma-separated)")
+	deleteInstanceCmd.Flags().StringVar(&deleteID, "id", "", "Instance IDs or keys to delete (comma-separated)")
 	deleteInstanceCmd.Flags().StringVar(&deleteInstanceQuery, "query", "", "AQL query to select instances for deletion")
-	deleteInstanceCmd.Flags().IntVar(&deleteInstanceLimit, "limit", 10, "Maximum number of instances to delete with query")
+	deleteInstanceCmd.Flags().IntVar(&deleteInstanceLimit, "limit", 10, "Maximum number of instances a query may match; more aborts the deletion")
+	deleteInstanceCmd.Flags().IntVar(&deleteInstanceExpect, "expect-count", -1, "Number of instances the query must match (required with --query)")
+	deleteInstanceCmd.Flags().IntVar(&deleteInstanceConcurrency, "concurrency", bulk.DefaultConcurrency, "Number of instances deleted at once")
+	deleteInstanceCmd.Flags().IntVar(&deleteInstanceBatchSize, "batch-size", 100, "Number of matches fetched per search page")
+	deleteInstanceCmd.Flags().StringVar(&deleteInstanceReport, "report", "", "Report file for per-instance results (default: a new file under the cache directory)")
+	deleteInstanceCmd.Flags().StringVar(&deleteInstanceResume, "resume", "", "Report of an interrupted run to resume")
 	deleteInstanceCmd.Flags().BoolVar(&deleteForce, "force", false, "Force deletion without confirmation")
 	deleteInstanceCmd.Flags().BoolVar(&deleteConfirm, "confirm", false, "Confirm deletion")
+	deleteInstanceCmd.Flags().BoolVar(&deleteInstanceArchive, "archive", false, "Snapshot instances into the local archive before deleting them")
+	deleteInstanceCmd.Flags().StringVar(&deleteInstanceArchiveFile, "archive-file", "", "Archive file to use with --archive (default: deleted-objects.jsonl in the cache directory)")
+	deleteInstanceCmd.MarkFlagsMutuallyExclusive("report", "resume")
 	
 	// Add subcommands
 	deleteCmd.AddCommand(deleteObjectTypeCmd)
@@ -114,11 +172,9 @@ func runDeleteObjectTypeCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	ctx := context.Background()
-	
 	// Check if deletions are allowed
 	if !client.IsDeleteAllowed() {
-		return fmt.Errorf("delete operations are disabled - set ATLASSIAN_ASSETS_ALLOW_DELETE=true in environment to enable")
+		return foundation.ErrDeleteDisabled
 	}
 	
 	// Validate input
@@ -131,53 +187,64 @@ func runDeleteObjectTypeCmd(cmd *cobra.Command, args []string) error {
 	}
 	
 	// Resolve object type ID if needed
-	var objectTypeID string
-	if deleteID != "" {
-		objectTypeID = deleteID
-	} else {
-		// TODO: Implement name-to-ID resolution
-		return fmt.Errorf("deletion by name not yet implemented")
-	}
-	
-	// Get object type details for confirmation
-	objectType, err := client.GetObjectType(ctx, objectTypeID)
-	if err != nil {
-		return fmt.Errorf("failed to get object type details: %w", err)
-	}
-	
-	if !objectType.Success {
-		return fmt.Errorf("failed to get object type: %v", objectType.Error)
+	objectTypeID := deleteID
+	if objectTypeID == "" {
+		objectTypeID, err = resolver.NewResolver(client).ResolveObjectTypeID(context.Background(), deleteObjectTypeSchema, deleteObjectTypeName)
+		if err != nil {
+			return fmt.Errorf("failed to resolve object type: %w", err)
+		}
 	}
 	
-	// Safety confirmation
-	if !deleteForce && !deleteConfirm {
-		return fmt.Errorf("deletion requires --confirm or --force flag for safety")
+	// A forced deletion skips the token but must still state what it destroys
+	var expectCount *int
+	if deleteForce {
+		if deleteObjectTypeExpect < 0 {
+			preview, err := foundation.PreviewObjectTypeDeletion(client, objectTypeID)
+			if err != nil {
+				return fmt.Errorf("failed to preview object type deletion: %w", err)
+			}
+			return fmt.Errorf("--expect-count is required with --force: object type %s has %d instances; pass --expect-count %d to delete it with them",
+				objectTypeID, preview.InstanceCount, preview.InstanceCount)
+		}
+		expectCount = &deleteObjectTypeExpect
 	}
 	
-	// Perform deletion
-	response, err := client.DeleteObjectType(ctx, objectTypeID)
+	// Without confirmation this only previews what would be destroyed
+	response, err := sharedResult(foundation.DeleteObjectType(client, common.DeleteObjectTypeParams{
+		ID:           objectTypeID,
+		Confirm:      deleteConfirm || deleteForce,
+		PreviewToken: deleteToken,
+		Force:        deleteForce,
+		ExpectCount:  expectCount,
+	}))
 	if err != nil {
-		return fmt.Errorf("failed to delete object type: %w", err)
+		return err
 	}
-	
-	if !response.Success {
-		return fmt.Errorf("delete operation failed: %v", response.Error)
-	}
-	
-	result := map[string]interface{}{
-		"action":         "delete_object_type",
-		"object_type_id": objectTypeID,
-		"force":          deleteForce,
-		"confirm":        deleteConfirm,
-		"deleted":        true,
+	data := sharedData(response)
+	data["force"] = deleteForce
+	
+	if deleted, _ := data["deleted"].(bool); !deleted {
+		preview := data["preview"].(*foundation.ObjectTypeDeletionPreview)
+		return outputResult(addNextStepHints(response, "delete_object_type_preview", map[string]interface{}{
+			"object_type_id":     objectTypeID,
+			"confirmation_token": preview.Token,
+			"has_children":       len(preview.ChildObjectTypes) > 0,
+			"has_references":     len(preview.ReferencingAttributes) > 0,
+		}))
 	}
 	
-	// Add contextual hints
-	enhancedResponse := addNextStepHints(NewSuccessResponse(result), "delete_object_type", map[string]interface{}{
+	// Add contextual hints; the cleanup hint names the first attribute left empty
+	hintVars := map[string]interface{}{
 		"object_type_id": objectTypeID,
 		"success":        true,
 		"force":          deleteForce,
-	})
+	}
+	if preview, ok := data["preview"].(*foundation.ObjectTypeDeletionPreview); ok && len(preview.ReferencingAttributes) > 0 {
+		hintVars["has_references"] = true
+		hintVars["referencing_type_id"] = preview.ReferencingAttributes[0].ObjectTypeID
+		hintVars["referencing_attribute_id"] = preview.ReferencingAttributes[0].AttributeID
+	}
+	enhancedResponse := addNextStepHints(response, "delete_object_type", hintVars)
 	
 	return outputResult(enhancedResponse)
 }
@@ -189,105 +256,76 @@ func runDeleteInstanceCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	ctx := context.Background()
-	
-	// Check if deletions are allowed
-	if !client.IsDeleteAllowed() {
-		return fmt.Errorf("delete operations are disabled - set ATLASSIAN_ASSETS_ALLOW_DELETE=true in environment to enable")
-	}
-	
 	// Validate input
 	if deleteID == "" && deleteInstanceQuery == "" {
 		return fmt.Errorf("must specify either --id or --query")
 	}
 	
 	var instanceIDs []string
-	var deletedCount int
-	
 	if deleteID != "" {
-		// Parse comma-separated IDs
 		instanceIDs = strings.Split(deleteID, ",")
-		for i, id := range instanceIDs {
-			instanceIDs[i] = strings.TrimSpace(id)
-		}
-	} else {
-		// Query-based deletion
-		searchResponse, err := client.SearchObjects(ctx, deleteInstanceQuery, deleteInstanceLimit)
-		if err != nil {
-			return fmt.Errorf("failed to search instances: %w", err)
-		}
-		
-		if !searchResponse.Success {
-			return fmt.Errorf("search failed: %v", searchResponse.Error)
-		}
-		
-		// Extract instance IDs from search results
-		data := searchResponse.Data.(map[string]interface{})
-		if objects, ok := data["objects"].([]interface{}); ok {
-			for _, obj := range objects {
-				if objectMap, ok := obj.(map[string]interface{}); ok {
-					if id, ok := objectMap["id"]; ok {
-						instanceIDs = append(instanceIDs, fmt.Sprintf("%v", id))
-					}
-				}
-			}
-		}
 	}
 	
-	if len(instanceIDs) == 0 {
-		return fmt.Errorf("no instances found to delete")
+	var expectCount *int
+	if deleteInstanceQuery != "" {
+		if deleteInstanceExpect < 0 {
+			return fmt.Errorf("--expect-count is required with --query: run 'assets search --query' first to see how many instances match")
+		}
+		expectCount = &deleteInstanceExpect
 	}
 	
-	// Safety confirmation
-	if !deleteForce && !deleteConfirm {
-		return fmt.Errorf("deletion of %d instances requires --confirm or --force flag for safety", len(instanceIDs))
+	// Every run keeps a report so that it can be resumed
+	reportPath := deleteInstanceReport
+	if deleteInstanceResume != "" {
+		if _, err := os.Stat(deleteInstanceResume); err != nil {
+			return fmt.Errorf("cannot resume: %w", err)
+		}
+		reportPath = deleteInstanceResume
 	}
-	
-	// Delete instances
-	var errors []string
-	var deletedIDs []string
-	
-	for _, instanceID := range instanceIDs {
-		response, err := client.DeleteObject(ctx, instanceID)
+	if reportPath == "" && (deleteForce || deleteConfirm) {
+		cacheDir, err := client.GetConfig().GetCacheDir()
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("Instance %s: %v", instanceID, err))
-			continue
+			return fmt.Errorf("failed to locate report directory: %w", err)
 		}
-		
-		if !response.Success {
-			errors = append(errors, fmt.Sprintf("Instance %s: %v", instanceID, response.Error))
-			continue
-		}
-		
-		deletedIDs = append(deletedIDs, instanceID)
-		deletedCount++
+		reportPath = bulk.DefaultReportPath(cacheDir, "delete-instances")
 	}
 	
-	result := map[string]interface{}{
-		"action":         "delete_instances",
-		"requested_ids":  instanceIDs,
-		"deleted_ids":    deletedIDs,
-		"deleted_count":  deletedCount,
-		"total_count":    len(instanceIDs),
-		"force":          deleteForce,
-		"confirm":        deleteConfirm,
-		"query":          deleteInstanceQuery,
+	archivePath := deleteInstanceArchiveFile
+	if deleteInstanceArchive && archivePath == "" {
+		if archivePath, err = foundation.DefaultArchivePath(client); err != nil {
+			return fmt.Errorf("failed to locate archive: %w", err)
+		}
 	}
 	
-	if len(errors) > 0 {
-		result["errors"] = errors
-		result["success"] = false
-	} else {
-		result["success"] = true
+	response, err := sharedResult(foundation.DeleteInstances(client, common.DeleteInstancesParams{
+		IDs:         instanceIDs,
+		Query:       deleteInstanceQuery,
+		Limit:       deleteInstanceLimit,
+		Confirm:     deleteForce || deleteConfirm,
+		Archive:     archivePath,
+		ExpectCount: expectCount,
+		Concurrency: deleteInstanceConcurrency,
+		PageSize:    deleteInstanceBatchSize,
+		Report:      reportPath,
+		Progress:    newProgressBar("Deleting"),
+	}))
+	if err != nil {
+		return err
 	}
 	
+	data := sharedData(response)
+	data["force"] = deleteForce
+	_, hasErrors := data["errors"]
+	
 	// Add contextual hints
-	enhancedResponse := addNextStepHints(NewSuccessResponse(result), "delete_instances", map[string]interface{}{
-		"deleted_count": deletedCount,
-		"total_count":   len(instanceIDs),
-		"success":       len(errors) == 0,
-		"has_errors":    len(errors) > 0,
+	enhancedResponse := addNextStepHints(response, "delete_instances", map[string]interface{}{
+		"deleted_count": data["deleted_count"],
+		"total_count":   data["total_count"],
+		"success":       !hasErrors,
+		"has_errors":    hasErrors,
+		"archive":       archivePath,
+		"report":        data["report"],
 	})
 	
 	return outputResult(enhancedResponse)
-}
\ No newline at end of file
+}
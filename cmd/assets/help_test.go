@@ -42,6 +42,9 @@ func TestHelpCommands(t *testing.T) {
 		{"apply help", []string{"apply", "--help"}, "Universal attribute application"},
 		{"test help", []string{"test", "--help"}, "Tools for creating and managing test environments"},
 		{"summary help", []string{"summary", "--help"}, "Composite commands that provide friendly summaries"},
+		{"restore help", []string{"restore", "--help"}, "Recreate object instances"},
+		{"set-icon help", []string{"set-icon", "--help"}, "Set the icon of an object type"},
+		{"copy-object-type help", []string{"copy-object-type", "--help"}, "Create a new object type with the icon"},
 	}
 	
 	for _, tt := range tests {
@@ -80,8 +83,8 @@ func TestCommandStructure(t *testing.T) {
 	
 	expectedCommands := []string{
 		"apply", "attributes", "browse", "catalog", "complete", "completion",
-		"config", "copy-attributes", "create", "delete", "extract", "get",
-		"help", "list", "remove", "resolve", "schema", "search", "summary", "test",
+		"config", "copy-attributes", "copy-object-type", "create", "delete", "extract", "get",
+		"help", "list", "remove", "resolve", "schema", "search", "set-icon", "summary", "test",
 		"trace", "update", "validate", "workflows",
 	}
 	
@@ -111,34 +114,34 @@ func TestSubcommandStructure(t *testing.T) {
 	}{
 		{"schema", "list", "List all schemas"},
 		{"schema", "get", "Get schema details"},
-		{"schema", "types", "List object types"},
-		{"create", "object-type", "Create object type"},
+		{"schema", "types", "List all object types available in a specific schema"},
+		{"create", "object-type", "Create a new object type in a schema"},
 		{"create", "instance", "Create instance"},
 		{"delete", "object-type", "Delete an object type"},
-		{"delete", "instance", "Delete object instances"},
-		{"remove", "attribute", "Remove an attribute from an object type"},
-		{"remove", "relationship", "Remove a relationship from an object"},
-		{"remove", "property", "Remove a property value from an object"},
-		{"browse", "hierarchy", "Browse hierarchy"},
-		{"browse", "children", "Browse children"},
-		{"browse", "attrs", "Browse attributes"},
-		{"workflows", "list", "List workflows"},
-		{"workflows", "show", "Show workflow"},
-		{"workflows", "simulate", "Simulate workflow"},
-		{"catalog", "attributes", "Browse attributes"},
+		{"delete", "instance", "Delete one or more object instances"},
+		{"remove", "attribute", "Remove an attribute definition from an object type"},
+		{"remove", "relationship", "Remove a relationship connection between objects"},
+		{"remove", "property", "Remove a specific property value from an object instance"},
+		{"browse", "hierarchy", "Display the parent-child relationships of object types"},
+		{"browse", "children", "List all child object types of a parent type"},
+		{"browse", "attrs", "Show attribute comparison between object types"},
+		{"workflows", "list", "List all available workflows"},
+		{"workflows", "show", "Show detailed information about a specific workflow"},
+		{"workflows", "simulate", "Simulate contextual hints"},
+		{"catalog", "attributes", "Global attribute catalog"},
 		{"extract", "attributes", "Extract attributes"},
-		{"apply", "attributes", "Apply attributes"},
+		{"apply", "attributes", "Apply a set of extracted attributes"},
 		{"trace", "reference", "Trace reference"},
-		{"trace", "dependencies", "Trace dependencies"},
+		{"trace", "dependencies", "Recursively discover all reference dependencies"},
 		{"test", "create-schema", "Create test schema"},
-		{"test", "cleanup", "Clean up test schemas"},
-		{"config", "show", "Show configuration"},
+		{"test", "cleanup", "Remove test schemas"},
+		{"config", "show", "Display the current configuration settings"},
 		{"config", "test", "Test connection"},
 		{"resolve", "schema", "Resolve schema"},
 		{"resolve", "type", "Resolve type"},
-		{"resolve", "stats", "Get statistics"},
-		{"summary", "completion", "Completion summary"},
-		{"summary", "schema", "Schema summary"},
+		{"resolve", "stats", "Show statistics about the resolver cache"},
+		{"summary", "completion", "show what completion would achieve"},
+		{"summary", "schema", "high-level overview of a schema"},
 	}
 	
 	for _, tt := range subcommandTests {
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+)
+
+// HISTORY command - who changed what on an object
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the change history of an asset object",
+	Long: `Show the changes Assets recorded for an object, newest first: who made each
+change, when, and the attribute's value before and after. Attributes are shown by
+name; each entry says whether a value was added, changed or removed, or whether
+it is an event of the object itself such as its creation.
+
+The history comes from Assets, so it covers changes made outside this tool too.
+For changes made through this tool, see 'assets audit list'.`,
+	Example: `  # Full history of an object
+  assets history --id OBJ-123
+
+  # Who changed the owner
+  assets history --id OBJ-123 --attribute Owner
+
+  # The five most recent changes
+  assets history --id "IT/Laptop 42" --limit 5`,
+	RunE: runHistoryCmd,
+}
+
+var (
+	historyID        string
+	historyAttribute string
+	historyLimit     int
+)
+
+func init() {
+	historyCmd.Flags().StringVar(&historyID, "id", "", "Object ID, key or schema/label reference (required)")
+	historyCmd.Flags().StringVar(&historyAttribute, "attribute", "", "Only changes to this attribute (name or ID)")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 0, "Show only the N most recent changes (0 for all)")
+	historyCmd.MarkFlagRequired("id")
+}
+
+func runHistoryCmd(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	response, err := sharedResult(foundation.GetObjectHistory(client, common.ObjectHistoryParams{
+		ID:        historyID,
+		Attribute: historyAttribute,
+		Limit:     historyLimit,
+	}))
+	if err != nil {
+		return err
+	}
+
+	data := sharedData(response)
+	total, _ := data["total"].(int)
+	hintVars := map[string]interface{}{
+		"success":     response.Success,
+		"object_id":   data["object_id"],
+		"has_results": total > 0,
+		"no_results":  total == 0,
+	}
+
+	return outputResult(addNextStepHints(response, "object_history", hintVars))
+}
@@ -6,17 +6,22 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
 	"github.com/aaronsb/atlassian-assets/internal/config"
 	"github.com/aaronsb/atlassian-assets/internal/client"
 	"github.com/aaronsb/atlassian-assets/internal/logger"
+	"github.com/aaronsb/atlassian-assets/internal/snapshot"
 	"github.com/aaronsb/atlassian-assets/internal/version"
 )
 
 var (
-	cfgFile     string
-	profile     string
-	workspaceID string
-	output      string
+	cfgFile      string
+	profile      string
+	workspaceID  string
+	output       string
+	offline      bool
+	snapshotFile string
+	logFormat    string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -31,6 +36,17 @@ to serve as a prototype for an MCP (Model Context Protocol) interface
 for AI agents.
 
 Version: %s`, version.GetInfo().Short()),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Each invocation gets its own correlation ID in the logs
+		if cmd.Flags().Changed("log-format") {
+			if err := logger.SetFormat(logFormat); err != nil {
+				return err
+			}
+		}
+		logger.SetCommand(cmd.CommandPath())
+		logger.SetRequestID(logger.NewRequestID())
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -49,19 +65,29 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "configuration profile to use")
 	rootCmd.PersistentFlags().StringVar(&workspaceID, "workspace-id", "", "Atlassian Assets workspace ID")
 	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "json", "output format (json, yaml, table)")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "answer read commands from the local snapshot instead of Assets")
+	rootCmd.PersistentFlags().StringVar(&snapshotFile, "snapshot", "", "snapshot file (default is snapshot/workspace.db in the cache directory)")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "log format on stderr (text, json); also ATLASSIAN_ASSETS_LOG_FORMAT")
 
 	// Add subcommands
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(getCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(watchCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(deleteCmd)
+	rootCmd.AddCommand(restoreCmd)
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(auditCmd)
 	rootCmd.AddCommand(removeCmd)
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(attributesCmd)
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(completeCmd)
 	rootCmd.AddCommand(copyAttributesCmd)
+	rootCmd.AddCommand(copyObjectTypeCmd)
+	rootCmd.AddCommand(setIconCmd)
 	rootCmd.AddCommand(browseCmd)
 	rootCmd.AddCommand(summaryCmd)
 	rootCmd.AddCommand(schemaCmd)
@@ -83,6 +109,10 @@ func initConfig() {
 
 // getClient creates and returns a configured Assets client
 func getClient() (*client.AssetsClient, error) {
+	if offline {
+		return nil, fmt.Errorf("this command needs a connection to Assets; --offline only applies to list, get, search, attributes, browse, catalog and trace")
+	}
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
@@ -104,6 +134,51 @@ func getClient() (*client.AssetsClient, error) {
 	return client, nil
 }
 
+// getReadClient returns the client for commands that only read, which answer from the
+// local snapshot with --offline
+func getReadClient() (common.ClientInterface, error) {
+	if offline {
+		return getOfflineClient()
+	}
+	return getClient()
+}
+
+// getOfflineClient loads the snapshot file; no credentials are needed
+func getOfflineClient() (*snapshot.Client, error) {
+	cfg, err := config.LoadLocalConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if workspaceID != "" {
+		cfg.WorkspaceID = workspaceID
+	}
+	if profile != "" {
+		cfg.Profile = profile
+	}
+
+	path := snapshotFile
+	if path == "" {
+		cacheDir, err := cfg.GetCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		path = snapshot.DefaultPath(cacheDir)
+	}
+
+	snap, err := snapshot.Load(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no snapshot at %s - pull one with 'assets snapshot pull --schema <schema>' while online", path)
+		}
+		return nil, err
+	}
+	if cfg.WorkspaceID != "" && cfg.WorkspaceID != snap.WorkspaceID {
+		return nil, fmt.Errorf("snapshot %s belongs to workspace %s, not %s", path, snap.WorkspaceID, cfg.WorkspaceID)
+	}
+
+	return snapshot.NewClient(snap, cfg), nil
+}
+
 // Response wrapper for consistent output
 type Response struct {
 	Success bool        `json:"success"`
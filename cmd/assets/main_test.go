@@ -65,11 +65,11 @@ func TestMain(m *testing.M) {
 	}
 	defer os.Remove(binaryPath)
 	
-	// Create test environment
+	// Create test environment; without a reachable tenant only the tests that
+	// need none are run
 	testEnv, err = setupTestEnvironment(binaryPath)
 	if err != nil {
-		fmt.Printf("Failed to setup test environment: %v\n", err)
-		os.Exit(1)
+		fmt.Printf("Skipping live tests, failed to setup test environment: %v\n", err)
 	}
 	
 	// Run tests
@@ -83,6 +83,14 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// requireTestEnv skips a test that needs the live test environment when there is none
+func requireTestEnv(t *testing.T) {
+	t.Helper()
+	if testEnv == nil {
+		t.Skip("no live Assets test environment")
+	}
+}
+
 // buildBinary builds the assets CLI binary for testing
 func buildBinary(binaryPath string) error {
 	cmd := exec.Command("go", "build", "-o", binaryPath, ".")
@@ -252,6 +260,8 @@ func rateLimit() {
 
 // TestHelp tests the help system
 func TestHelp(t *testing.T) {
+	requireTestEnv(t)
+	
 	tests := []struct {
 		name     string
 		args     []string
@@ -284,6 +294,8 @@ func TestHelp(t *testing.T) {
 
 // TestSchemaOperations tests schema-related operations
 func TestSchemaOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("list schemas", func(t *testing.T) {
 		output, err := testEnv.execCommand("schema", "list")
 		result := assertSuccess(t, output, err)
@@ -344,6 +356,8 @@ func TestSchemaOperations(t *testing.T) {
 
 // TestObjectOperations tests object-related operations
 func TestObjectOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("list objects", func(t *testing.T) {
 		output, err := testEnv.execCommand("list", "--schema", testEnv.TestSchema.ID)
 		result := assertSuccess(t, output, err)
@@ -382,6 +396,8 @@ func TestObjectOperations(t *testing.T) {
 
 // TestAttributeOperations tests attribute-related operations
 func TestAttributeOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	// Get a test object type
 	var testTypeID string
 	for _, objType := range testEnv.TestSchema.ObjectTypes {
@@ -422,6 +438,8 @@ func TestAttributeOperations(t *testing.T) {
 
 // TestBrowseOperations tests browse operations
 func TestBrowseOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("browse hierarchy", func(t *testing.T) {
 		output, err := testEnv.execCommand("browse", "hierarchy", "--schema", testEnv.TestSchema.ID)
 		result := assertSuccess(t, output, err)
@@ -438,6 +456,8 @@ func TestBrowseOperations(t *testing.T) {
 
 // TestWorkflowOperations tests workflow and intelligence features
 func TestWorkflowOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("list workflows", func(t *testing.T) {
 		output, err := testEnv.execCommand("workflows", "list")
 		result := assertSuccess(t, output, err)
@@ -477,6 +497,8 @@ func TestWorkflowOperations(t *testing.T) {
 
 // TestConfigOperations tests configuration operations
 func TestConfigOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("show config", func(t *testing.T) {
 		output, err := testEnv.execCommand("config", "show")
 		result := assertSuccess(t, output, err)
@@ -506,6 +528,8 @@ func TestConfigOperations(t *testing.T) {
 
 // TestValidationOperations tests validation operations
 func TestValidationOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	// Get a test object type
 	var testTypeID string
 	for _, objType := range testEnv.TestSchema.ObjectTypes {
@@ -534,6 +558,8 @@ func TestValidationOperations(t *testing.T) {
 
 // TestCompletionOperations tests completion operations
 func TestCompletionOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	// Get a test object type
 	var testTypeID string
 	for _, objType := range testEnv.TestSchema.ObjectTypes {
@@ -562,6 +588,8 @@ func TestCompletionOperations(t *testing.T) {
 
 // TestResolverOperations tests resolver operations
 func TestResolverOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("resolve schema by name", func(t *testing.T) {
 		output, err := testEnv.execCommand("resolve", "schema", "--name", testEnv.TestSchema.Name)
 		result := assertSuccess(t, output, err)
@@ -591,6 +619,8 @@ func TestResolverOperations(t *testing.T) {
 
 // TestErrorHandling tests error handling
 func TestErrorHandling(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("invalid command", func(t *testing.T) {
 		output, err := testEnv.execCommand("invalid-command")
 		if err == nil {
@@ -635,6 +665,8 @@ func TestErrorHandling(t *testing.T) {
 
 // TestContextualHints tests contextual hints functionality
 func TestContextualHints(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("hints in successful operations", func(t *testing.T) {
 		output, err := testEnv.execCommand("schema", "list")
 		result := assertSuccess(t, output, err)
@@ -701,6 +733,8 @@ func BenchmarkOperations(b *testing.B) {
 
 // TestDeleteOperations tests delete operations with safety checks
 func TestDeleteOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("delete without permission", func(t *testing.T) {
 		// Should fail when ATLASSIAN_ASSETS_ALLOW_DELETE is not set
 		output, err := testEnv.execCommand("delete", "object-type", "--id", "123", "--confirm")
@@ -757,6 +791,8 @@ func TestDeleteOperations(t *testing.T) {
 
 // TestRemoveOperations tests remove operations
 func TestRemoveOperations(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("remove help structure", func(t *testing.T) {
 		output, err := testEnv.execCommand("remove", "--help")
 		if err != nil && len(output) == 0 {
@@ -830,6 +866,8 @@ func TestRemoveOperations(t *testing.T) {
 
 // TestDeleteRemoveContextualHints tests contextual hints for delete/remove operations
 func TestDeleteRemoveContextualHints(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("delete hints in workflows", func(t *testing.T) {
 		output, err := testEnv.execCommand("workflows", "simulate", "--context", "delete_object_type", "--variables", `{"success":true,"object_type_id":"123","force":false}`)
 		result := assertSuccess(t, output, err)
@@ -877,6 +915,8 @@ func TestDeleteRemoveContextualHints(t *testing.T) {
 
 // TestDeleteRemoveErrorHandling tests error handling for delete/remove operations
 func TestDeleteRemoveErrorHandling(t *testing.T) {
+	requireTestEnv(t)
+	
 	t.Run("delete missing confirmation", func(t *testing.T) {
 		// Should fail without confirmation flags
 		output, err := testEnv.execCommand("delete", "object-type", "--id", "123")
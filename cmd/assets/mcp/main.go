@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/modelcontextprotocol/go-sdk/jsonschema"
@@ -15,12 +16,22 @@ import (
 	"github.com/aaronsb/atlassian-assets/internal/config"
 	"github.com/aaronsb/atlassian-assets/internal/hints"
 	"github.com/aaronsb/atlassian-assets/internal/logger"
+	"github.com/aaronsb/atlassian-assets/internal/resolver"
 	"github.com/aaronsb/atlassian-assets/internal/version"
 )
 
 // Global client for tool handlers
 var assetsClient common.ClientInterface
 
+// toolClient returns the client for one tool call, bound to the call's request ID and
+// tool so that overlapping calls are logged and audited apart
+func toolClient(ctx context.Context) common.ClientInterface {
+	if c, ok := assetsClient.(*client.AssetsClient); ok {
+		return c.ForRequest(ctx)
+	}
+	return assetsClient
+}
+
 // Initialize MCP server
 func NewMCPServer() (*mcp.Server, error) {
 	// Load configuration (from .env file or environment variables)
@@ -29,8 +40,8 @@ func NewMCPServer() (*mcp.Server, error) {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
-	// Validate critical configuration
-	if cfg.Email == "" || cfg.APIToken == "" || cfg.Host == "" {
+	// Validate critical configuration; replays run without a tenant
+	if cfg.ReplayFile == "" && (cfg.Email == "" || cfg.APIToken == "" || cfg.Host == "") {
 		return nil, fmt.Errorf("missing required configuration: email, api_token, and host must be set in .env file or environment variables")
 	}
 
@@ -40,6 +51,15 @@ func NewMCPServer() (*mcp.Server, error) {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 	assetsClient = client
+	assetsResolver = resolver.NewResolver(client)
+
+	// Changes are audited under one session per server process
+	client.SetAuditSession(logger.NewRequestID())
+
+	// Load the tool safety policy before any tool is registered
+	if err := loadServerPolicy(); err != nil {
+		return nil, fmt.Errorf("failed to load MCP policy: %w", err)
+	}
 
 	// Create MCP server
 	versionInfo := version.GetInfo()
@@ -48,9 +68,10 @@ func NewMCPServer() (*mcp.Server, error) {
 		Version: versionInfo.Version,
 	}, nil)
 
-	// Register tools and resources
+	// Register tools, resources and prompts
 	registerTools(server)
 	registerResources(server)
+	registerPrompts(server)
 
 	return server, nil
 }
@@ -58,7 +79,7 @@ func NewMCPServer() (*mcp.Server, error) {
 // Register all available tools
 func registerTools(server *mcp.Server) {
 	// Foundation tools
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_search",
 		Description: "Search for assets using exact matches or AQL queries",
 		InputSchema: &jsonschema.Schema{
@@ -74,7 +95,7 @@ func registerTools(server *mcp.Server) {
 		},
 	}, handleSearchTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_list",
 		Description: "List all assets in a schema with pagination",
 		InputSchema: &jsonschema.Schema{
@@ -88,19 +109,33 @@ func registerTools(server *mcp.Server) {
 		},
 	}, handleListTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_get",
 		Description: "Get complete details of a specific asset object",
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
-				"id": {Type: "string", Description: "Unique asset object ID (like OBJ-123)"},
+				"id": {Type: "string", Description: "Asset object ID, key (like OBJ-123) or schema/label reference"},
 			},
 			Required: []string{"id"},
 		},
 	}, handleGetTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
+		Name: "assets_object_history",
+		Description: "Get the change history of an asset object: who changed which attribute, when, and its value before and after",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"id": {Type: "string", Description: "Asset object ID, key (like OBJ-123) or schema/label reference"},
+				"attribute": {Type: "string", Description: "Only changes to this attribute, by name (like Owner) or ID"},
+				"limit": {Type: "integer", Description: "Maximum number of changes, newest first (default: all)"},
+			},
+			Required: []string{"id"},
+		},
+	}, handleObjectHistoryTool)
+	
+	addTool(server, &mcp.Tool{
 		Name: "assets_create_object",
 		Description: "Create a new asset object instance",
 		InputSchema: &jsonschema.Schema{
@@ -113,19 +148,19 @@ func registerTools(server *mcp.Server) {
 		},
 	}, handleCreateObjectTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_delete",
 		Description: "Delete an asset object by ID",
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
-				"id": {Type: "string", Description: "Unique asset object ID to delete"},
+				"id": {Type: "string", Description: "Asset object ID or key to delete"},
 			},
 			Required: []string{"id"},
 		},
 	}, handleDeleteTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_list_schemas",
 		Description: "List all available schemas in the workspace",
 		InputSchema: &jsonschema.Schema{
@@ -134,7 +169,7 @@ func registerTools(server *mcp.Server) {
 		},
 	}, handleListSchemasTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_get_schema",
 		Description: "Get details of a specific schema",
 		InputSchema: &jsonschema.Schema{
@@ -146,7 +181,7 @@ func registerTools(server *mcp.Server) {
 		},
 	}, handleGetSchemaTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_create_object_type",
 		Description: "Create a new object type within a schema",
 		InputSchema: &jsonschema.Schema{
@@ -162,7 +197,7 @@ func registerTools(server *mcp.Server) {
 		},
 	}, handleCreateObjectTypeTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_get_object_type_attributes",
 		Description: "Get attributes for a specific object type",
 		InputSchema: &jsonschema.Schema{
@@ -175,7 +210,7 @@ func registerTools(server *mcp.Server) {
 	}, handleGetObjectTypeAttributesTool)
 	
 	// Composite tools
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_browse_schema",
 		Description: "Explore schema structure, object types, and asset distribution",
 		InputSchema: &jsonschema.Schema{
@@ -187,7 +222,7 @@ func registerTools(server *mcp.Server) {
 		},
 	}, handleBrowseSchemaTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_validate",
 		Description: "Validate object data against object type requirements",
 		InputSchema: &jsonschema.Schema{
@@ -200,7 +235,7 @@ func registerTools(server *mcp.Server) {
 		},
 	}, handleValidateTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_complete_object",
 		Description: "Intelligently complete asset creation with validation and defaults",
 		InputSchema: &jsonschema.Schema{
@@ -213,7 +248,7 @@ func registerTools(server *mcp.Server) {
 		},
 	}, handleCompleteObjectTool)
 	
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name: "assets_trace_relationships",
 		Description: "Trace object relationships and dependencies",
 		InputSchema: &jsonschema.Schema{
@@ -225,6 +260,161 @@ func registerTools(server *mcp.Server) {
 			Required: []string{"object_id"},
 		},
 	}, handleTraceRelationshipsTool)
+	
+	// Attribute marketplace tools
+	addTool(server, &mcp.Tool{
+		Name: "assets_copy_attributes",
+		Description: "Copy attribute definitions from one object type to another",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"from": {Type: "string", Description: "Source object type ID"},
+				"to": {Type: "string", Description: "Destination object type ID"},
+				"dry_run": {Type: "boolean", Description: "Show what would be copied without making changes (default: false)"},
+				"skip_existing": {Type: "boolean", Description: "Skip attributes that already exist in destination (default: true)"},
+			},
+			Required: []string{"from", "to"},
+		},
+	}, handleCopyAttributesTool)
+	
+	addTool(server, &mcp.Tool{
+		Name: "assets_extract_attributes",
+		Description: "Extract attributes from an object instance or the attribute schema of an object type",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"object_id": {Type: "string", Description: "Object ID to extract attribute values from"},
+				"object_type_id": {Type: "string", Description: "Object type ID to extract the attribute schema from"},
+				"resolve_references": {Type: "boolean", Description: "Resolve reference targets (default: false)"},
+				"include_system": {Type: "boolean", Description: "Include system attributes such as Created, Updated and Key (default: false)"},
+			},
+		},
+	}, handleExtractAttributesTool)
+	
+	addTool(server, &mcp.Tool{
+		Name: "assets_apply_attributes",
+		Description: "Apply extracted attributes to a target object type",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"object_type_id": {Type: "string", Description: "Target object type ID"},
+				"attributes": {Type: "array", Description: "Attributes as returned by assets_extract_attributes", Items: &jsonschema.Schema{Type: "object"}},
+				"select": {Type: "array", Description: "Attribute names to apply (default: all)", Items: &jsonschema.Schema{Type: "string"}},
+				"skip_references": {Type: "boolean", Description: "Skip reference attributes (default: false)"},
+				"reference_mappings": {Type: "object", Description: "Map of source to target referenced object type IDs"},
+				"dry_run": {Type: "boolean", Description: "Show what would be applied without creating (default: false)"},
+				"force_overwrite": {Type: "boolean", Description: "Apply attributes whose names already exist (default: false)"},
+			},
+			Required: []string{"object_type_id", "attributes"},
+		},
+	}, handleApplyAttributesTool)
+	
+	addTool(server, &mcp.Tool{
+		Name: "assets_catalog_attributes",
+		Description: "Browse and search attributes across all schemas with pagination",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"pattern": {Type: "string", Description: "Case-insensitive regex matched against attribute names and descriptions"},
+				"schema": {Type: "string", Description: "Limit to a specific schema ID"},
+				"page": {Type: "integer", Description: "Page number, 1-based (default: 1)"},
+				"per_page": {Type: "integer", Description: "Results per page (default: 25)"},
+				"all": {Type: "boolean", Description: "Return all results without pagination (default: false)"},
+			},
+		},
+	}, handleCatalogAttributesTool)
+	
+	// Reference discovery tools
+	addTool(server, &mcp.Tool{
+		Name: "assets_trace_reference",
+		Description: "Trace where a reference attribute points",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"attribute_id": {Type: "string", Description: "Attribute ID to trace"},
+				"attribute_name": {Type: "string", Description: "Attribute name to trace (requires object_type_id)"},
+				"object_type_id": {Type: "string", Description: "Object type ID containing the attribute"},
+				"schema_id": {Type: "string", Description: "Schema ID to search when only attribute_id is known (default: all schemas)"},
+			},
+		},
+	}, handleTraceReferenceTool)
+	
+	addTool(server, &mcp.Tool{
+		Name: "assets_trace_dependencies",
+		Description: "Discover the reference dependencies of an object type",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"object_type_id": {Type: "string", Description: "Object type ID to analyze"},
+				"schema_id": {Type: "string", Description: "Schema ID (required unless all_schemas is set)"},
+				"all_schemas": {Type: "boolean", Description: "Analyze across all schemas (default: false)"},
+			},
+			Required: []string{"object_type_id"},
+		},
+	}, handleTraceDependenciesTool)
+	
+	addTool(server, &mcp.Tool{
+		Name: "assets_resolve",
+		Description: "Resolve between human-readable names and internal IDs for schemas, object types and objects",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"kind": {Type: "string", Description: "What to resolve", Enum: []any{"schema", "object_type", "object"}},
+				"name": {Type: "string", Description: "Name to resolve to an ID"},
+				"id": {Type: "string", Description: "ID to resolve to a name"},
+				"schema": {Type: "string", Description: "Schema name or ID (required for object type names)"},
+				"ref": {Type: "string", Description: "Object reference: ID, key, or schema/key"},
+			},
+			Required: []string{"kind"},
+		},
+	}, handleResolveTool)
+	
+	addTool(server, &mcp.Tool{
+		Name: "assets_summary_schema",
+		Description: "Summarize a schema's object type counts and hierarchy",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"schema_id": {Type: "string", Description: "Schema ID"},
+			},
+			Required: []string{"schema_id"},
+		},
+	}, handleSummarySchemaTool)
+	
+	// Removal and deletion tools
+	addTool(server, &mcp.Tool{
+		Name: "assets_remove",
+		Description: "Remove an attribute from an object type, or a relationship or property value from an object",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"target": {Type: "string", Description: "What to remove", Enum: []any{"attribute", "relationship", "property"}},
+				"object_type_id": {Type: "string", Description: "Object type ID (attribute removal)"},
+				"attribute_id": {Type: "string", Description: "Attribute ID (attribute removal)"},
+				"attribute_name": {Type: "string", Description: "Attribute name (attribute removal)"},
+				"object_id": {Type: "string", Description: "Object ID or key (relationship and property removal)"},
+				"relationship_id": {Type: "string", Description: "Relationship ID (relationship removal)"},
+				"relationship_type": {Type: "string", Description: "Relationship type, used with target_id (relationship removal)"},
+				"target_id": {Type: "string", Description: "Target object ID or key, used with relationship_type (relationship removal)"},
+				"property_id": {Type: "string", Description: "Property ID (property removal)"},
+				"property_names": {Type: "array", Description: "Property names (property removal)", Items: &jsonschema.Schema{Type: "string"}},
+				"confirm": {Type: "boolean", Description: "Must be true to perform the removal"},
+			},
+			Required: []string{"target", "confirm"},
+		},
+	}, handleRemoveTool)
+	
+	addTool(server, &mcp.Tool{
+		Name: "assets_delete_object_type",
+		Description: "Delete an object type with all of its instances, child object types and referencing attributes",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"id": {Type: "string", Description: "Object type ID to delete"},
+			},
+			Required: []string{"id"},
+		},
+	}, handleDeleteObjectTypeTool)
 }
 
 // Register resources
@@ -242,6 +432,9 @@ func registerResources(server *mcp.Server) {
 		MIMEType: "application/json",
 		URI:      "capabilities://tools",
 	}, handleCapabilitiesResource)
+
+	// Schema, object type and object resources served through the resolver cache
+	registerAssetResources(server)
 }
 
 
@@ -300,14 +493,14 @@ func handleSearchTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.Ca
 		Offset: getIntParam(args, "offset", 0),
 	}
 	
-	response, err := foundation.SearchObjects(assetsClient, searchParams)
+	response, err := foundation.SearchObjects(toolClient(ctx), searchParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_search", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_search", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -326,14 +519,14 @@ func handleListTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.Call
 		Offset: getIntParam(args, "offset", 0),
 	}
 	
-	response, err := foundation.ListObjects(assetsClient, listParams)
+	response, err := foundation.ListObjects(toolClient(ctx), listParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_list", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_list", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -350,14 +543,40 @@ func handleGetTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallT
 		ID: getStringParam(args, "id", ""),
 	}
 	
-	response, err := foundation.GetObject(assetsClient, getParams)
+	response, err := foundation.GetObject(toolClient(ctx), getParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_get", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_get", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleObjectHistoryTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	
+	historyParams := common.ObjectHistoryParams{
+		ID:        getStringParam(args, "id", ""),
+		Attribute: getStringParam(args, "attribute", ""),
+		Limit:     getIntParam(args, "limit", 0),
+	}
+	
+	response, err := foundation.GetObjectHistory(toolClient(ctx), historyParams)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_object_history", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -375,14 +594,14 @@ func handleCreateObjectTool(ctx context.Context, ss *mcp.ServerSession, params *
 		Attributes:   getMapParam(args, "attributes"),
 	}
 	
-	response, err := foundation.CreateObject(assetsClient, createParams)
+	response, err := foundation.CreateObject(toolClient(ctx), createParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_create_object", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_create_object", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -399,14 +618,14 @@ func handleDeleteTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.Ca
 		ID: getStringParam(args, "id", ""),
 	}
 	
-	response, err := foundation.DeleteObject(assetsClient, deleteParams)
+	response, err := foundation.DeleteObject(toolClient(ctx), deleteParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_delete", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_delete", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -419,14 +638,14 @@ func handleListSchemasTool(ctx context.Context, ss *mcp.ServerSession, params *m
 	// Use the parsed arguments directly
 	args := params.Arguments
 	
-	response, err := foundation.ListSchemas(assetsClient)
+	response, err := foundation.ListSchemas(toolClient(ctx))
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_list_schemas", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_list_schemas", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -443,14 +662,14 @@ func handleGetSchemaTool(ctx context.Context, ss *mcp.ServerSession, params *mcp
 		SchemaID: getStringParam(args, "schema_id", ""),
 	}
 	
-	response, err := foundation.GetSchema(assetsClient, schemaParams)
+	response, err := foundation.GetSchema(toolClient(ctx), schemaParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_get_schema", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_get_schema", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -474,14 +693,14 @@ func handleCreateObjectTypeTool(ctx context.Context, ss *mcp.ServerSession, para
 		createParams.Parent = &parent
 	}
 	
-	response, err := foundation.CreateObjectType(assetsClient, createParams)
+	response, err := foundation.CreateObjectType(toolClient(ctx), createParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_create_object_type", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_create_object_type", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -498,14 +717,14 @@ func handleGetObjectTypeAttributesTool(ctx context.Context, ss *mcp.ServerSessio
 		ObjectTypeID: getStringParam(args, "object_type_id", ""),
 	}
 	
-	response, err := foundation.GetObjectTypeAttributes(assetsClient, attrParams)
+	response, err := foundation.GetObjectTypeAttributes(toolClient(ctx), attrParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_get_object_type_attributes", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_get_object_type_attributes", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -522,14 +741,14 @@ func handleBrowseSchemaTool(ctx context.Context, ss *mcp.ServerSession, params *
 		SchemaID: getStringParam(args, "schema_id", ""),
 	}
 	
-	response, err := composite.BrowseSchema(assetsClient, browseParams)
+	response, err := composite.BrowseSchema(toolClient(ctx), browseParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_browse_schema", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_browse_schema", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -547,14 +766,14 @@ func handleValidateTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.
 		Data:         getMapParam(args, "data"),
 	}
 	
-	response, err := composite.ValidateObject(assetsClient, validateParams)
+	response, err := composite.ValidateObject(toolClient(ctx), validateParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_validate", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_validate", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -572,14 +791,14 @@ func handleCompleteObjectTool(ctx context.Context, ss *mcp.ServerSession, params
 		Data:         getMapParam(args, "data"),
 	}
 	
-	response, err := composite.CompleteObject(assetsClient, completeParams)
+	response, err := composite.CompleteObject(toolClient(ctx), completeParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_complete_object", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_complete_object", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -597,14 +816,320 @@ func handleTraceRelationshipsTool(ctx context.Context, ss *mcp.ServerSession, pa
 		Depth:    getIntParam(args, "depth", 1),
 	}
 	
-	response, err := composite.TraceRelationships(assetsClient, traceParams)
+	response, err := composite.TraceRelationships(toolClient(ctx), traceParams)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Add AI guidance
 	context := buildToolContext(args, response)
-	responseWithGuidance := addAIGuidance(response, "assets_trace_relationships", context)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_trace_relationships", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleCopyAttributesTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	
+	copyParams := common.CopyAttributesParams{
+		From:         getStringParam(args, "from", ""),
+		To:           getStringParam(args, "to", ""),
+		DryRun:       getBoolParam(args, "dry_run", false),
+		SkipExisting: getBoolParam(args, "skip_existing", true),
+	}
+	
+	response, err := composite.CopyAttributes(toolClient(ctx), copyParams)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_copy_attributes", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleExtractAttributesTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	
+	extractParams := common.ExtractAttributesParams{
+		ObjectID:          getStringParam(args, "object_id", ""),
+		ObjectTypeID:      getStringParam(args, "object_type_id", ""),
+		ResolveReferences: getBoolParam(args, "resolve_references", false),
+		IncludeSystem:     getBoolParam(args, "include_system", false),
+	}
+	
+	response, err := composite.ExtractAttributes(toolClient(ctx), extractParams)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_extract_attributes", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleApplyAttributesTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	
+	var response *common.Response
+	attributes, err := composite.ExtractedAttributesFromDocument(args["attributes"])
+	if err != nil {
+		response = common.NewErrorResponse(err)
+	} else {
+		applyParams := common.ApplyAttributesParams{
+			ObjectTypeID:      getStringParam(args, "object_type_id", ""),
+			Attributes:        attributes,
+			Select:            getStringSliceParam(args, "select"),
+			SkipReferences:    getBoolParam(args, "skip_references", false),
+			ReferenceMappings: getStringMapParam(args, "reference_mappings"),
+			DryRun:            getBoolParam(args, "dry_run", false),
+			ForceOverwrite:    getBoolParam(args, "force_overwrite", false),
+		}
+		
+		response, err = composite.ApplyAttributes(toolClient(ctx), applyParams)
+		if err != nil {
+			return nil, err
+		}
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	delete(context, "attributes")
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_apply_attributes", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleCatalogAttributesTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	
+	catalogParams := common.CatalogAttributesParams{
+		Pattern: getStringParam(args, "pattern", ""),
+		Schema:  getStringParam(args, "schema", ""),
+		Page:    getIntParam(args, "page", 1),
+		PerPage: getIntParam(args, "per_page", 25),
+		All:     getBoolParam(args, "all", false),
+	}
+	
+	response, err := composite.CatalogAttributes(toolClient(ctx), catalogParams)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_catalog_attributes", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleTraceReferenceTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	
+	traceParams := common.TraceReferenceParams{
+		AttributeID:   getStringParam(args, "attribute_id", ""),
+		AttributeName: getStringParam(args, "attribute_name", ""),
+		ObjectTypeID:  getStringParam(args, "object_type_id", ""),
+		SchemaID:      getStringParam(args, "schema_id", ""),
+	}
+	
+	response, err := composite.TraceReference(toolClient(ctx), traceParams)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_trace_reference", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleTraceDependenciesTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	
+	traceParams := common.TraceDependenciesParams{
+		ObjectTypeID: getStringParam(args, "object_type_id", ""),
+		SchemaID:     getStringParam(args, "schema_id", ""),
+		AllSchemas:   getBoolParam(args, "all_schemas", false),
+	}
+	
+	response, err := composite.TraceDependencies(toolClient(ctx), traceParams)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_trace_dependencies", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleResolveTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	
+	resolveParams := common.ResolveParams{
+		Kind:   getStringParam(args, "kind", ""),
+		Name:   getStringParam(args, "name", ""),
+		ID:     getStringParam(args, "id", ""),
+		Schema: getStringParam(args, "schema", ""),
+		Ref:    getStringParam(args, "ref", ""),
+	}
+	
+	response, err := foundation.Resolve(toolClient(ctx), resolveParams)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_resolve", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleSummarySchemaTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	
+	summaryParams := common.SchemaSummaryParams{
+		SchemaID: getStringParam(args, "schema_id", ""),
+	}
+	
+	response, err := composite.SummarizeSchema(toolClient(ctx), summaryParams)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_summary_schema", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleRemoveTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	confirm := getBoolParam(args, "confirm", false)
+	
+	var response *common.Response
+	var err error
+	
+	switch target := getStringParam(args, "target", ""); target {
+	case "attribute":
+		response, err = foundation.RemoveAttribute(toolClient(ctx), common.RemoveAttributeParams{
+			ObjectTypeID:  getStringParam(args, "object_type_id", ""),
+			AttributeID:   getStringParam(args, "attribute_id", ""),
+			AttributeName: getStringParam(args, "attribute_name", ""),
+			Confirm:       confirm,
+		})
+	case "relationship":
+		response, err = foundation.RemoveRelationship(toolClient(ctx), common.RemoveRelationshipParams{
+			ObjectID:         getStringParam(args, "object_id", ""),
+			RelationshipID:   getStringParam(args, "relationship_id", ""),
+			RelationshipType: getStringParam(args, "relationship_type", ""),
+			TargetID:         getStringParam(args, "target_id", ""),
+			Confirm:          confirm,
+		})
+	case "property":
+		response, err = foundation.RemoveProperty(toolClient(ctx), common.RemovePropertyParams{
+			ObjectID:      getStringParam(args, "object_id", ""),
+			PropertyID:    getStringParam(args, "property_id", ""),
+			PropertyNames: getStringSliceParam(args, "property_names"),
+			Confirm:       confirm,
+		})
+	default:
+		response = common.NewErrorResponse(fmt.Errorf("unknown removal target '%s' (expected attribute, relationship or property)", target))
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_remove", context)
+	
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}, nil
+}
+
+func handleDeleteObjectTypeTool(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+	// Use the parsed arguments directly
+	args := params.Arguments
+	
+	// When the server policy demands a confirmation token, the caller has been shown
+	// the deletion preview and its token must still match what would be deleted.
+	// Otherwise the policy has opted out of previews.
+	deleteParams := common.DeleteObjectTypeParams{
+		ID:      getStringParam(args, "id", ""),
+		Confirm: true,
+	}
+	if destructiveCallsPreviewed() {
+		deleteParams.PreviewToken = getStringParam(args, "preview_token", "")
+	} else {
+		deleteParams.Force = true
+	}
+	
+	response, err := foundation.DeleteObjectType(toolClient(ctx), deleteParams)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add AI guidance
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, "assets_delete_object_type", context)
 	
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -648,6 +1173,51 @@ func getMapParam(params map[string]interface{}, key string) map[string]interface
 	return make(map[string]interface{})
 }
 
+func getBoolParam(params map[string]interface{}, key string, defaultValue bool) bool {
+	if value, ok := params[key]; ok {
+		switch v := value.(type) {
+		case bool:
+			return v
+		case string:
+			if boolVal, err := strconv.ParseBool(v); err == nil {
+				return boolVal
+			}
+		}
+	}
+	return defaultValue
+}
+
+func getStringSliceParam(params map[string]interface{}, key string) []string {
+	var result []string
+	switch v := params[key].(type) {
+	case []interface{}:
+		for _, item := range v {
+			if str, ok := item.(string); ok && str != "" {
+				result = append(result, str)
+			}
+		}
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+func getStringMapParam(params map[string]interface{}, key string) map[string]string {
+	mapVal, ok := params[key].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	result := make(map[string]string, len(mapVal))
+	for k, v := range mapVal {
+		result[k] = fmt.Sprintf("%v", v)
+	}
+	return result
+}
+
 func buildToolContext(params map[string]interface{}, response *common.Response) map[string]interface{} {
 	context := map[string]interface{}{
 		"success": response.Success,
@@ -670,7 +1240,7 @@ func buildToolContext(params map[string]interface{}, response *common.Response)
 	return context
 }
 
-func addAIGuidance(response *common.Response, toolName string, context map[string]interface{}) *ResponseWithHints {
+func addAIGuidance(ctx context.Context, response *common.Response, toolName string, context map[string]interface{}) *ResponseWithHints {
 	// Add success to context for guidance evaluation
 	context["success"] = response.Success
 	
@@ -692,12 +1262,14 @@ func addAIGuidance(response *common.Response, toolName string, context map[strin
 	return &ResponseWithHints{
 		Response:   response,
 		AIGuidance: aiGuidance,
+		RequestID:  logger.RequestIDFrom(ctx),
 	}
 }
 
 type ResponseWithHints struct {
 	*common.Response
 	AIGuidance *hints.AIGuidance `json:"ai_guidance,omitempty"`
+	RequestID  string            `json:"request_id,omitempty"`
 }
 
 func formatResponse(response *ResponseWithHints) string {
@@ -0,0 +1,416 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/go-sdk/jsonschema"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+	"github.com/aaronsb/atlassian-assets/internal/policy"
+)
+
+// Server policy and the tokens issued for destructive calls
+var (
+	serverPolicy  = policy.Default()
+	confirmations = policy.NewConfirmationStore(serverPolicy.Confirmation.TTL)
+)
+
+type toolHandler = mcp.ToolHandlerFor[map[string]interface{}, any]
+
+// scopeKind says how a tool argument identifies a schema
+type scopeKind int
+
+const (
+	scopeSchema scopeKind = iota
+	scopeObjectType
+	scopeObject
+)
+
+// scopeArg is a tool argument that places the call in a schema
+type scopeArg struct {
+	name     string
+	kind     scopeKind
+	readOnly bool   // The argument is only read, even by a write tool
+	query    string // AQL argument confined to this argument's schema under schema rules
+}
+
+// toolRule classifies a tool and lists the arguments that scope it to a schema
+type toolRule struct {
+	kind   policy.ToolKind
+	scopes []scopeArg
+}
+
+// Tools missing from this table are treated as write tools with no schema scope. Under
+// schema rules a call must give at least one of its tool's scope arguments.
+var toolRules = map[string]toolRule{
+	"assets_search":                     {policy.ToolRead, []scopeArg{{name: "schema", kind: scopeSchema, query: "query"}}},
+	"assets_list":                       {policy.ToolRead, []scopeArg{{name: "schema", kind: scopeSchema}}},
+	"assets_get":                        {policy.ToolRead, []scopeArg{{name: "id", kind: scopeObject}}},
+	"assets_object_history":             {policy.ToolRead, []scopeArg{{name: "id", kind: scopeObject}}},
+	"assets_list_schemas":               {policy.ToolRead, nil},
+	"assets_get_schema":                 {policy.ToolRead, []scopeArg{{name: "schema_id", kind: scopeSchema}}},
+	"assets_get_object_type_attributes": {policy.ToolRead, []scopeArg{{name: "object_type_id", kind: scopeObjectType}}},
+	"assets_browse_schema":              {policy.ToolRead, []scopeArg{{name: "schema_id", kind: scopeSchema}}},
+	"assets_validate":                   {policy.ToolRead, []scopeArg{{name: "object_type_id", kind: scopeObjectType}}},
+	"assets_trace_relationships":        {policy.ToolRead, []scopeArg{{name: "object_id", kind: scopeObject}}},
+	"assets_extract_attributes": {policy.ToolRead, []scopeArg{
+		{name: "object_id", kind: scopeObject},
+		{name: "object_type_id", kind: scopeObjectType},
+	}},
+	"assets_catalog_attributes": {policy.ToolRead, []scopeArg{{name: "schema", kind: scopeSchema}}},
+	"assets_trace_reference": {policy.ToolRead, []scopeArg{
+		{name: "object_type_id", kind: scopeObjectType},
+		{name: "schema_id", kind: scopeSchema},
+	}},
+	"assets_trace_dependencies": {policy.ToolRead, []scopeArg{
+		{name: "object_type_id", kind: scopeObjectType},
+		{name: "schema_id", kind: scopeSchema},
+	}},
+	"assets_resolve":        {policy.ToolRead, []scopeArg{{name: "schema", kind: scopeSchema}}},
+	"assets_summary_schema": {policy.ToolRead, []scopeArg{{name: "schema_id", kind: scopeSchema}}},
+
+	"assets_create_object":      {policy.ToolWrite, []scopeArg{{name: "object_type_id", kind: scopeObjectType}}},
+	"assets_create_object_type": {policy.ToolWrite, []scopeArg{{name: "schema", kind: scopeSchema}}},
+	"assets_complete_object":    {policy.ToolWrite, []scopeArg{{name: "object_type_id", kind: scopeObjectType}}},
+	"assets_copy_attributes": {policy.ToolWrite, []scopeArg{
+		{name: "from", kind: scopeObjectType, readOnly: true},
+		{name: "to", kind: scopeObjectType},
+	}},
+	"assets_apply_attributes": {policy.ToolWrite, []scopeArg{{name: "object_type_id", kind: scopeObjectType}}},
+
+	"assets_delete": {policy.ToolDestructive, []scopeArg{{name: "id", kind: scopeObject}}},
+	"assets_remove": {policy.ToolDestructive, []scopeArg{
+		{name: "object_type_id", kind: scopeObjectType},
+		{name: "object_id", kind: scopeObject},
+	}},
+	"assets_delete_object_type": {policy.ToolDestructive, []scopeArg{{name: "id", kind: scopeObjectType}}},
+}
+
+// loadServerPolicy loads the policy file and resets the confirmation store to its TTL
+func loadServerPolicy() error {
+	p, err := policy.LoadDefault()
+	if err != nil {
+		return err
+	}
+
+	serverPolicy = p
+	confirmations = policy.NewConfirmationStore(p.Confirmation.TTL)
+
+	if p.Source != "" {
+		logger.Info("Loaded MCP policy from %s (read_only=%t)", p.Source, p.ReadOnly)
+	}
+	return nil
+}
+
+// addTool registers a tool subject to the server policy. Tools the policy does not
+// allow are never registered, so clients cannot see or call them.
+func addTool(server *mcp.Server, tool *mcp.Tool, handler toolHandler) {
+	rule, ok := toolRules[tool.Name]
+	if !ok {
+		rule = toolRule{kind: policy.ToolWrite}
+	}
+
+	if !serverPolicy.ToolAllowed(tool.Name, rule.kind) {
+		logger.Debug("Tool %s disabled by MCP policy", tool.Name)
+		return
+	}
+
+	if serverPolicy.RequiresConfirmation(rule.kind) {
+		requireConfirmationToken(tool)
+	}
+
+	mcp.AddTool(server, tool, traceTool(tool.Name, guardTool(tool.Name, rule, handler)))
+}
+
+// traceTool gives every tool call its own request ID, carried with the tool name in the
+// call's context, logs its duration and returns the ID in the result's _meta so a
+// client can match a response to the server logs
+func traceTool(toolName string, handler toolHandler) toolHandler {
+	return func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+		requestID := logger.NewRequestID()
+		ctx = logger.WithRequestID(logger.WithCommand(ctx, toolName), requestID)
+
+		start := time.Now()
+		result, err := handler(ctx, ss, params)
+
+		fields := logger.Fields{"tool": toolName, "duration_ms": time.Since(start).Milliseconds()}
+		switch {
+		case err != nil:
+			fields["error"] = err.Error()
+		case result != nil && result.IsError:
+			fields["status"] = "error"
+		default:
+			fields["status"] = "ok"
+		}
+		logger.LogContext(ctx, logger.INFO, "Tool call completed", fields)
+
+		if result != nil {
+			if result.Meta == nil {
+				result.Meta = mcp.Meta{}
+			}
+			result.Meta["request_id"] = requestID
+		}
+		return result, err
+	}
+}
+
+// requireConfirmationToken swaps a tool's confirm flag for a confirmation token
+func requireConfirmationToken(tool *mcp.Tool) {
+	tool.Description += ". Two-step: the first call returns a preview and a confirmation_token; call again with the same arguments and the token to execute"
+	if tool.InputSchema == nil {
+		return
+	}
+
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = map[string]*jsonschema.Schema{}
+	}
+	delete(tool.InputSchema.Properties, "confirm")
+	tool.InputSchema.Properties["confirmation_token"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Token returned by the preview call; omit it to get a preview",
+	}
+
+	required := tool.InputSchema.Required[:0]
+	for _, name := range tool.InputSchema.Required {
+		if name != "confirm" {
+			required = append(required, name)
+		}
+	}
+	tool.InputSchema.Required = required
+}
+
+// guardTool wraps a handler with the schema access and confirmation checks
+func guardTool(toolName string, rule toolRule, handler toolHandler) toolHandler {
+	return func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]interface{}]) (*mcp.CallToolResult, error) {
+		args := params.Arguments
+		if args == nil {
+			args = map[string]interface{}{}
+			params.Arguments = args
+		}
+
+		if err := checkSchemaAccess(ctx, rule, args); err != nil {
+			return policyResult(ctx, toolName, args, common.NewErrorResponse(err)), nil
+		}
+
+		if !serverPolicy.RequiresConfirmation(rule.kind) {
+			return handler(ctx, ss, params)
+		}
+
+		token := getStringParam(args, "confirmation_token", "")
+		if token == "" {
+			return previewDestructiveCall(ctx, toolName, rule, args), nil
+		}
+
+		state, err := confirmations.Redeem(token, toolName, args)
+		if err != nil {
+			return policyResult(ctx, toolName, args, common.NewErrorResponse(fmt.Errorf("%w; call %s without a token to get a new preview", err, toolName))), nil
+		}
+
+		delete(args, "confirmation_token")
+		args["confirm"] = true
+		if state != "" {
+			args["preview_token"] = state
+		}
+		return handler(ctx, ss, params)
+	}
+}
+
+// destructiveCallsPreviewed reports whether destructive tools only run after the
+// caller has seen a preview and redeemed its confirmation token
+func destructiveCallsPreviewed() bool {
+	return serverPolicy.RequiresConfirmation(policy.ToolDestructive)
+}
+
+// checkSchemaAccess verifies the policy grants the access the call needs on every
+// schema it touches
+func checkSchemaAccess(ctx context.Context, rule toolRule, args map[string]interface{}) error {
+	if !serverPolicy.HasSchemaRules() {
+		return nil
+	}
+
+	scoped := false
+	for _, scope := range rule.scopes {
+		ref := getStringParam(args, scope.name, "")
+		if ref == "" {
+			continue
+		}
+		scoped = true
+
+		schemaID, schemaName, err := scopeSchemaOf(ctx, scope.kind, ref)
+		if err != nil {
+			return fmt.Errorf("MCP policy restricts schema access and the schema for %s %q could not be determined: %w", scope.name, ref, err)
+		}
+
+		required := policy.AccessWrite
+		if rule.kind == policy.ToolRead || scope.readOnly {
+			required = policy.AccessRead
+		}
+
+		granted := serverPolicy.SchemaAccess(schemaID, schemaName)
+		if !granted.Allows(required) {
+			return fmt.Errorf("MCP policy denies %s access to schema %s (%s); granted: %s", required, schemaName, schemaID, granted)
+		}
+
+		// A query could otherwise name any schema
+		if scope.query != "" {
+			if query := getStringParam(args, scope.query, ""); query != "" {
+				args[scope.query] = confineQuery(query, schemaID)
+			}
+		}
+	}
+
+	if len(rule.scopes) > 0 && !scoped {
+		names := make([]string, len(rule.scopes))
+		for i, scope := range rule.scopes {
+			names[i] = scope.name
+		}
+		return fmt.Errorf("MCP policy restricts schema access and the call names no schema; give %s", strings.Join(names, " or "))
+	}
+
+	return nil
+}
+
+// Start of an AQL order by clause, which has to stay at the end of a query
+var orderByPattern = regexp.MustCompile(`(?i)(^|\s)order\s+by\s`)
+
+// confineQuery limits an AQL query to the objects of one schema
+func confineQuery(query, schemaID string) string {
+	clause := ""
+	if matches := orderByPattern.FindAllStringIndex(query, -1); len(matches) > 0 {
+		start := matches[len(matches)-1][0]
+		query, clause = query[:start], " "+strings.TrimSpace(query[start:])
+	}
+
+	filter := fmt.Sprintf("objectSchemaId = %s", schemaID)
+	if query = strings.TrimSpace(query); query != "" {
+		filter += " AND (" + query + ")"
+	}
+	return filter + clause
+}
+
+// scopeSchemaOf resolves a scoping argument to its schema ID and name
+func scopeSchemaOf(ctx context.Context, kind scopeKind, ref string) (string, string, error) {
+	var schemaID string
+	var err error
+
+	switch kind {
+	case scopeSchema:
+		schemaID, err = assetsResolver.ResolveSchemaID(ctx, ref)
+	case scopeObjectType:
+		var schemaName string
+		if _, schemaName, err = assetsResolver.ResolveObjectTypeName(ctx, ref); err == nil {
+			schemaID, err = assetsResolver.ResolveSchemaID(ctx, schemaName)
+		}
+	case scopeObject:
+		var objectID string
+		if objectID, err = resolveObjectKey(ctx, ref); err == nil {
+			info, infoErr := assetsResolver.GetObjectInfo(ctx, objectID)
+			if infoErr != nil {
+				err = infoErr
+			} else {
+				schemaID = info.SchemaID
+			}
+		}
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	schemaName, err := assetsResolver.ResolveSchemaName(ctx, schemaID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return schemaID, schemaName, nil
+}
+
+// previewDestructiveCall describes what a destructive call would affect and issues
+// the token that approves it
+func previewDestructiveCall(ctx context.Context, toolName string, rule toolRule, args map[string]interface{}) *mcp.CallToolResult {
+	preview, state := destructivePreview(ctx, toolName, rule, args)
+	token, expiresAt, err := confirmations.Issue(toolName, args, state)
+	if err != nil {
+		return policyResult(ctx, toolName, args, common.NewErrorResponse(err))
+	}
+
+	arguments := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if key != "confirm" {
+			arguments[key] = value
+		}
+	}
+
+	return policyResult(ctx, toolName, args, common.NewSuccessResponse(map[string]interface{}{
+		"action":             "confirmation_required",
+		"tool":               toolName,
+		"arguments":          arguments,
+		"preview":            preview,
+		"confirmation_token": token,
+		"expires_at":         expiresAt.Format(time.RFC3339),
+		"message":            fmt.Sprintf("Nothing has been changed. Call %s again with the same arguments and confirmation_token to proceed.", toolName),
+	}))
+}
+
+// destructivePreview fetches the objects and object types a destructive call targets.
+// It also returns the state the confirmed call must still find, if the tool has one.
+func destructivePreview(ctx context.Context, toolName string, rule toolRule, args map[string]interface{}) (map[string]interface{}, string) {
+	preview := map[string]interface{}{}
+	state := ""
+
+	// Deleting an object type cascades, so list everything that goes with it
+	if toolName == "assets_delete_object_type" {
+		if cascade, err := foundation.PreviewObjectTypeDeletion(toolClient(ctx), getStringParam(args, "id", "")); err != nil {
+			preview["cascade"] = map[string]interface{}{"error": err.Error()}
+		} else {
+			preview["cascade"] = cascade
+			state = cascade.Token
+		}
+	}
+
+	for _, scope := range rule.scopes {
+		ref := getStringParam(args, scope.name, "")
+		if ref == "" {
+			continue
+		}
+
+		var response *common.Response
+		var err error
+		switch scope.kind {
+		case scopeSchema:
+			response, err = foundation.GetSchema(toolClient(ctx), common.GetSchemaParams{SchemaID: ref})
+		case scopeObjectType:
+			response, err = foundation.GetObjectType(toolClient(ctx), ref)
+		case scopeObject:
+			response, err = foundation.GetObject(toolClient(ctx), common.GetParams{ID: ref})
+		}
+
+		switch {
+		case err != nil:
+			preview[scope.name] = map[string]interface{}{"error": err.Error()}
+		case !response.Success:
+			preview[scope.name] = map[string]interface{}{"error": response.Error}
+		default:
+			preview[scope.name] = response.Data
+		}
+	}
+
+	return preview, state
+}
+
+// policyResult formats a response with AI guidance, as the tool handlers do
+func policyResult(ctx context.Context, toolName string, args map[string]interface{}, response *common.Response) *mcp.CallToolResult {
+	context := buildToolContext(args, response)
+	responseWithGuidance := addAIGuidance(ctx, response, toolName, context)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatResponse(responseWithGuidance)},
+		},
+	}
+}
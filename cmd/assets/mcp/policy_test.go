@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/commontest"
+	"github.com/aaronsb/atlassian-assets/internal/policy"
+	"github.com/aaronsb/atlassian-assets/internal/resolver"
+)
+
+// useRestrictedPolicy denies the Finance schema and serves Hardware and Finance from a mock
+func useRestrictedPolicy(t *testing.T) {
+	t.Helper()
+	client := commontest.NewMockClient()
+	client.Schemas = []*models.ObjectSchemaScheme{{ID: "1", Name: "Hardware"}, {ID: "2", Name: "Finance"}}
+
+	previousPolicy, previousResolver := serverPolicy, assetsResolver
+	serverPolicy = &policy.Policy{DefaultSchemaAccess: policy.AccessRead, Schemas: map[string]policy.Access{"Finance": policy.AccessNone}}
+	assetsResolver = resolver.NewResolver(client)
+	t.Cleanup(func() { serverPolicy, assetsResolver = previousPolicy, previousResolver })
+}
+
+func TestCheckSchemaAccessRefusesUnscopedCalls(t *testing.T) {
+	useRestrictedPolicy(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		tool      string
+		args      map[string]interface{}
+		wantError string
+	}{
+		{tool: "assets_search", args: map[string]interface{}{"query": "objectSchemaId = 2"}, wantError: "names no schema; give schema"},
+		{tool: "assets_list", args: map[string]interface{}{}, wantError: "names no schema; give schema"},
+		{tool: "assets_trace_reference", args: map[string]interface{}{"attribute": "Owner"}, wantError: "give object_type_id or schema_id"},
+		{tool: "assets_search", args: map[string]interface{}{"schema": "Finance", "query": "Name = x"}, wantError: "denies read access to schema Finance"},
+	}
+	for _, tt := range tests {
+		err := checkSchemaAccess(ctx, toolRules[tt.tool], tt.args)
+		if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+			t.Errorf("%s(%v) error = %v, want %q", tt.tool, tt.args, err, tt.wantError)
+		}
+	}
+}
+
+func TestCheckSchemaAccessConfinesQueries(t *testing.T) {
+	useRestrictedPolicy(t)
+
+	// A query naming a denied schema only searches the allowed one
+	args := map[string]interface{}{"schema": "Hardware", "query": "objectSchemaId = 2 OR Name = x order by label"}
+	if err := checkSchemaAccess(context.Background(), toolRules["assets_search"], args); err != nil {
+		t.Fatalf("checkSchemaAccess failed: %v", err)
+	}
+	if want := "objectSchemaId = 1 AND (objectSchemaId = 2 OR Name = x) order by label"; args["query"] != want {
+		t.Errorf("query = %q, want %q", args["query"], want)
+	}
+
+	// Without schema rules calls are left alone
+	serverPolicy = policy.Default()
+	args = map[string]interface{}{"query": "objectSchemaId = 2"}
+	if err := checkSchemaAccess(context.Background(), toolRules["assets_search"], args); err != nil || args["query"] != "objectSchemaId = 2" {
+		t.Errorf("unrestricted search = %v, %v", args, err)
+	}
+}
+
+func TestConfineQuery(t *testing.T) {
+	tests := []struct{ query, want string }{
+		{query: "Name = x", want: "objectSchemaId = 7 AND (Name = x)"},
+		{query: "ORDER BY Name", want: "objectSchemaId = 7 ORDER BY Name"},
+		{query: "Name = \"order by\" order by Name desc", want: "objectSchemaId = 7 AND (Name = \"order by\") order by Name desc"},
+	}
+	for _, tt := range tests {
+		if got := confineQuery(tt.query, "7"); got != tt.want {
+			t.Errorf("confineQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestResourcesFollowSchemaAccess(t *testing.T) {
+	useRestrictedPolicy(t)
+	ctx := context.Background()
+	if err := assetsResolver.RefreshCache(ctx); err != nil {
+		t.Fatalf("RefreshCache failed: %v", err)
+	}
+
+	for _, uri := range []string{"assets://schema/2", "assets://schema/Finance"} {
+		_, err := handleSchemaResource(ctx, nil, &mcp.ReadResourceParams{URI: uri})
+		if err == nil || !strings.Contains(err.Error(), "denies read access to schema Finance") {
+			t.Errorf("reading %s error = %v, want a policy denial", uri, err)
+		}
+	}
+
+	// Only the readable schema is published
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	syncSchemaResources(server)
+	t.Cleanup(func() { schemaResources.names = map[string]string{} })
+	if _, ok := schemaResources.names["assets://schema/1"]; !ok || len(schemaResources.names) != 1 {
+		t.Errorf("published schemas = %v, want only assets://schema/1", schemaResources.names)
+	}
+}
+
+func TestDeleteObjectTypeChecksConfirmedCascade(t *testing.T) {
+	mock := commontest.NewMockClient()
+	mock.Config.CacheDir = t.TempDir()
+	mock.AddObjectType(&models.ObjectTypeScheme{ID: "65", Name: "Laptops", ObjectSchemaID: "7"})
+	mock.SearchResults = []*models.ObjectScheme{{ID: "1"}}
+
+	previousClient, previousPolicy, previousConfirmations := assetsClient, serverPolicy, confirmations
+	assetsClient, serverPolicy = mock, policy.Default()
+	confirmations = policy.NewConfirmationStore(time.Minute)
+	t.Cleanup(func() { assetsClient, serverPolicy, confirmations = previousClient, previousPolicy, previousConfirmations })
+
+	tool := guardTool("assets_delete_object_type", toolRules["assets_delete_object_type"], handleDeleteObjectTypeTool)
+	call := func(args map[string]interface{}) string {
+		t.Helper()
+		result, err := tool(context.Background(), nil, &mcp.CallToolParamsFor[map[string]interface{}]{Arguments: args})
+		if err != nil {
+			t.Fatalf("tool call failed: %v", err)
+		}
+		return result.Content[0].(*mcp.TextContent).Text
+	}
+	token := func(text string) string {
+		t.Helper()
+		match := regexp.MustCompile(`"confirmation_token":\s*"([0-9a-f]+)"`).FindStringSubmatch(text)
+		if match == nil {
+			t.Fatalf("no confirmation token in %s", text)
+		}
+		return match[1]
+	}
+
+	// An instance created after the preview makes its token stale
+	first := token(call(map[string]interface{}{"id": "65"}))
+	mock.SearchResults = append(mock.SearchResults, &models.ObjectScheme{ID: "2"})
+	if text := call(map[string]interface{}{"id": "65", "confirmation_token": first}); !strings.Contains(text, "has changed since the preview") {
+		t.Errorf("confirmed call after a change = %s, want it refused", text)
+	}
+	if len(mock.DeletedObjectTypes) > 0 {
+		t.Fatalf("deleted %v after the cascade changed", mock.DeletedObjectTypes)
+	}
+
+	second := token(call(map[string]interface{}{"id": "65"}))
+	call(map[string]interface{}{"id": "65", "confirmation_token": second})
+	if len(mock.DeletedObjectTypes) != 1 {
+		t.Errorf("deleted %v, want object type 65", mock.DeletedObjectTypes)
+	}
+}
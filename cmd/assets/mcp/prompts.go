@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/aaronsb/atlassian-assets/internal/hints"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+)
+
+// registerPrompts exposes every AI workflow that declares a prompt as an MCP prompt
+func registerPrompts(server *mcp.Server) {
+	workflows, err := hints.GetWorkflowPrompts()
+	if err != nil {
+		logger.Warning("failed to load workflow prompts: %v", err)
+		return
+	}
+
+	workflowIDs := make([]string, 0, len(workflows))
+	for id := range workflows {
+		workflowIDs = append(workflowIDs, id)
+	}
+	sort.Strings(workflowIDs)
+
+	for _, workflowID := range workflowIDs {
+		workflow := workflows[workflowID]
+
+		arguments := make([]*mcp.PromptArgument, 0, len(workflow.Prompt.Arguments))
+		for _, arg := range workflow.Prompt.Arguments {
+			arguments = append(arguments, &mcp.PromptArgument{
+				Name:        arg.Name,
+				Description: arg.Description,
+				Required:    arg.Required,
+			})
+		}
+
+		server.AddPrompt(&mcp.Prompt{
+			Name:        workflow.Prompt.Name,
+			Title:       workflow.Prompt.Title,
+			Description: workflow.Description,
+			Arguments:   arguments,
+		}, workflowPromptHandler(workflowID, workflow.Description))
+	}
+}
+
+// workflowPromptHandler renders a workflow prompt with the arguments supplied by the client
+func workflowPromptHandler(workflowID, description string) mcp.PromptHandler {
+	return func(ctx context.Context, ss *mcp.ServerSession, params *mcp.GetPromptParams) (*mcp.GetPromptResult, error) {
+		text, err := hints.RenderWorkflowPrompt(workflowID, params.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render prompt %s: %w", params.Name, err)
+		}
+
+		return &mcp.GetPromptResult{
+			Description: description,
+			Messages: []*mcp.PromptMessage{
+				{
+					Role:    "user",
+					Content: &mcp.TextContent{Text: text},
+				},
+			},
+		}, nil
+	}
+}
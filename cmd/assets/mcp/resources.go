@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+	"github.com/aaronsb/atlassian-assets/internal/policy"
+	"github.com/aaronsb/atlassian-assets/internal/resolver"
+)
+
+const (
+	assetsURIScheme = "assets://"
+
+	// resourceRefreshInterval matches the resolver's in-memory cache TTL
+	resourceRefreshInterval = 5 * time.Minute
+)
+
+// Shared resolver backing the assets:// resources and policy checks
+var assetsResolver *resolver.Resolver
+
+// schemaResources tracks the concrete schema resources currently published so
+// that a cache refresh only adds or removes what actually changed
+var schemaResources = struct {
+	sync.Mutex
+	names map[string]string // URI -> resource name
+}{names: make(map[string]string)}
+
+// registerAssetResources registers the assets:// resource templates and keeps the
+// concrete schema resources in step with the resolver cache
+func registerAssetResources(server *mcp.Server) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "Assets Schema",
+		Description: "Schema details and its object types, by schema ID or name",
+		MIMEType:    "application/json",
+		URITemplate: assetsURIScheme + "schema/{id}",
+	}, handleSchemaResource)
+
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "Object Type Attributes",
+		Description: "Attribute definitions for an object type, by object type ID",
+		MIMEType:    "application/json",
+		URITemplate: assetsURIScheme + "objecttype/{id}/attributes",
+	}, handleObjectTypeAttributesResource)
+
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "Assets Object",
+		Description: "A single object, by object key (e.g. HW-123) or numeric ID",
+		MIMEType:    "application/json",
+		URITemplate: assetsURIScheme + "object/{key}",
+	}, handleObjectResource)
+
+	// Every cache refresh republishes the schema list, which makes the SDK send
+	// notifications/resources/list_changed to connected sessions
+	assetsResolver.OnRefresh(func() {
+		syncSchemaResources(server)
+	})
+
+	go refreshResourcesPeriodically()
+}
+
+// refreshResourcesPeriodically warms the resolver cache and refreshes it on the
+// cache TTL so that long-running sessions see schema changes
+func refreshResourcesPeriodically() {
+	ctx := context.Background()
+	if err := assetsResolver.RefreshCache(ctx); err != nil {
+		logger.Warning("failed to warm resolver cache: %v", err)
+	}
+
+	ticker := time.NewTicker(resourceRefreshInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := assetsResolver.RefreshCache(ctx); err != nil {
+			logger.Warning("failed to refresh resolver cache: %v", err)
+		}
+	}
+}
+
+// syncSchemaResources publishes one assets://schema/{id} resource per cached schema
+// the policy lets clients read
+func syncSchemaResources(server *mcp.Server) {
+	schemaResources.Lock()
+	defer schemaResources.Unlock()
+
+	current := make(map[string]string)
+	for _, schema := range assetsResolver.CachedSchemas() {
+		if !serverPolicy.SchemaAccess(schema.ID, schema.Name).Allows(policy.AccessRead) {
+			continue
+		}
+		current[assetsURIScheme+"schema/"+schema.ID] = schema.Name
+	}
+
+	var stale []string
+	for uri := range schemaResources.names {
+		if _, ok := current[uri]; !ok {
+			stale = append(stale, uri)
+		}
+	}
+	if len(stale) > 0 {
+		server.RemoveResources(stale...)
+	}
+
+	for uri, name := range current {
+		if schemaResources.names[uri] == name {
+			continue
+		}
+		server.AddResource(&mcp.Resource{
+			Name:     fmt.Sprintf("Schema: %s", name),
+			MIMEType: "application/json",
+			URI:      uri,
+		}, handleSchemaResource)
+	}
+
+	schemaResources.names = current
+}
+
+// handleSchemaResource serves assets://schema/{id}
+func handleSchemaResource(ctx context.Context, ss *mcp.ServerSession, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) {
+	ref, ok := resourceSegment(params.URI, "schema/", "")
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(params.URI)
+	}
+	if err := checkResourceAccess(ctx, scopeSchema, ref); err != nil {
+		return nil, err
+	}
+
+	schemaID, err := assetsResolver.ResolveSchemaID(ctx, ref)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(params.URI)
+	}
+
+	schemaResponse, err := foundation.GetSchema(assetsClient, common.GetSchemaParams{SchemaID: schemaID})
+	if err != nil {
+		return nil, err
+	}
+	if !schemaResponse.Success {
+		return nil, fmt.Errorf("failed to get schema %s: %s", schemaID, schemaResponse.Error)
+	}
+
+	objectTypes, err := assetsResolver.ListResolvedObjectTypes(ctx, schemaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list object types: %w", err)
+	}
+	sort.Slice(objectTypes, func(i, j int) bool {
+		return strings.ToLower(objectTypes[i].Name) < strings.ToLower(objectTypes[j].Name)
+	})
+
+	types := make([]map[string]interface{}, 0, len(objectTypes))
+	for _, objType := range objectTypes {
+		types = append(types, map[string]interface{}{
+			"id":             objType.ID,
+			"name":           objType.Name,
+			"attributes_uri": assetsURIScheme + "objecttype/" + objType.ID + "/attributes",
+		})
+	}
+
+	schemaData := schemaResponse.Data.(map[string]interface{})
+	return jsonResource(params.URI, map[string]interface{}{
+		"schema":       schemaData["schema"],
+		"object_types": types,
+	})
+}
+
+// handleObjectTypeAttributesResource serves assets://objecttype/{id}/attributes
+func handleObjectTypeAttributesResource(ctx context.Context, ss *mcp.ServerSession, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) {
+	typeID, ok := resourceSegment(params.URI, "objecttype/", "/attributes")
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(params.URI)
+	}
+	if err := checkResourceAccess(ctx, scopeObjectType, typeID); err != nil {
+		return nil, err
+	}
+
+	typeName, schemaName, err := assetsResolver.ResolveObjectTypeName(ctx, typeID)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(params.URI)
+	}
+	schemaID, err := assetsResolver.ResolveSchemaID(ctx, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve schema for object type %s: %w", typeID, err)
+	}
+
+	attributes, err := foundation.ListAttributes(assetsClient, typeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResource(params.URI, map[string]interface{}{
+		"object_type_id":   typeID,
+		"object_type_name": typeName,
+		"schema_id":        schemaID,
+		"schema_name":      schemaName,
+		"schema_uri":       assetsURIScheme + "schema/" + schemaID,
+		"attributes":       attributes,
+	})
+}
+
+// handleObjectResource serves assets://object/{key}
+func handleObjectResource(ctx context.Context, ss *mcp.ServerSession, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) {
+	key, ok := resourceSegment(params.URI, "object/", "")
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(params.URI)
+	}
+	if err := checkResourceAccess(ctx, scopeObject, key); err != nil {
+		return nil, err
+	}
+
+	objectID, err := resolveObjectKey(ctx, key)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(params.URI)
+	}
+
+	info, err := assetsResolver.GetObjectInfo(ctx, objectID)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(params.URI)
+	}
+
+	objectResponse, err := foundation.GetObject(assetsClient, common.GetParams{ID: objectID})
+	if err != nil {
+		return nil, err
+	}
+	if !objectResponse.Success {
+		return nil, fmt.Errorf("failed to get object %s: %s", objectID, objectResponse.Error)
+	}
+
+	objectData := objectResponse.Data.(map[string]interface{})
+	return jsonResource(params.URI, map[string]interface{}{
+		"object_id":      info.ID,
+		"object_key":     info.Name,
+		"display_name":   info.DisplayName,
+		"schema_uri":     assetsURIScheme + "schema/" + info.SchemaID,
+		"attributes_uri": assetsURIScheme + "objecttype/" + info.ParentID + "/attributes",
+		"object":         objectData["object"],
+	})
+}
+
+// checkResourceAccess applies the policy's schema rules to a resource read, as
+// checkSchemaAccess does for the read tools
+func checkResourceAccess(ctx context.Context, kind scopeKind, ref string) error {
+	rule := toolRule{kind: policy.ToolRead, scopes: []scopeArg{{name: "resource", kind: kind}}}
+	return checkSchemaAccess(ctx, rule, map[string]interface{}{"resource": ref})
+}
+
+// resolveObjectKey resolves an object key through the resolver cache, falling back
+// to an AQL key lookup for keys that have not been cached yet
+func resolveObjectKey(ctx context.Context, key string) (string, error) {
+	if objectID, err := assetsResolver.ResolveObjectID(ctx, key); err == nil {
+		return objectID, nil
+	}
+	if _, err := strconv.Atoi(key); err == nil {
+		return "", fmt.Errorf("object not found: %s", key)
+	}
+
+	response, err := foundation.SearchObjects(assetsClient, common.SearchParams{
+		Query: fmt.Sprintf("Key = %q", key),
+		Limit: 1,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !response.Success {
+		return "", fmt.Errorf("failed to look up object key %s: %s", key, response.Error)
+	}
+
+	objects, err := foundation.ObjectsFromData(response.Data)
+	if err != nil {
+		return "", err
+	}
+	if len(objects) == 0 {
+		return "", fmt.Errorf("object not found: %s", key)
+	}
+
+	return objects[0].ID, nil
+}
+
+// resourceSegment extracts the templated segment from an assets:// URI
+func resourceSegment(uri, prefix, suffix string) (string, bool) {
+	rest, ok := strings.CutPrefix(uri, assetsURIScheme+prefix)
+	if !ok {
+		return "", false
+	}
+	rest, ok = strings.CutSuffix(rest, suffix)
+	if !ok || rest == "" || strings.Contains(rest, "/") {
+		return "", false
+	}
+	return rest, true
+}
+
+// jsonResource wraps a value as a single JSON resource content
+func jsonResource(uri string, value interface{}) (*mcp.ReadResourceResult, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource %s: %w", uri, err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: "application/json",
+				Text:     string(content),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/composite"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+	"github.com/aaronsb/atlassian-assets/internal/resolver"
+)
+
+// SET-ICON command
+var setIconCmd = &cobra.Command{
+	Use:   "set-icon",
+	Short: "Set the icon of an object type",
+	Long: `Set the icon of an object type.
+
+The icon can be given by ID or by name. Use --list to see the available icons.`,
+	Example: `  # List the available icons
+  assets set-icon --list
+
+  # Set an icon by name
+  assets set-icon --id 141 --icon 'Server'
+
+  # Resolve the object type by name
+  assets set-icon --name "Laptops" --schema IT --icon 'Laptop'`,
+	RunE: runSetIconCmd,
+}
+
+var (
+	setIconID     string
+	setIconName   string
+	setIconSchema string
+	setIconIcon   string
+	setIconList   bool
+)
+
+func init() {
+	setIconCmd.Flags().StringVar(&setIconID, "id", "", "Object type ID")
+	setIconCmd.Flags().StringVar(&setIconName, "name", "", "Object type name (requires --schema)")
+	setIconCmd.Flags().StringVar(&setIconSchema, "schema", "", "Schema ID or name (required with --name)")
+	setIconCmd.Flags().StringVar(&setIconIcon, "icon", "", "Icon ID or name")
+	setIconCmd.Flags().BoolVar(&setIconList, "list", false, "List the available icons")
+
+	setIconCmd.MarkFlagsMutuallyExclusive("id", "name")
+}
+
+func runSetIconCmd(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	if setIconList {
+		response, err := sharedResult(foundation.ListIcons(client))
+		if err != nil {
+			return err
+		}
+		return outputResult(response)
+	}
+
+	// Validate input
+	if setIconIcon == "" {
+		return fmt.Errorf("must specify --icon (or --list to see the available icons)")
+	}
+	objectTypeID, err := resolveObjectTypeFlag(client, setIconID, setIconName, setIconSchema, "--id")
+	if err != nil {
+		return err
+	}
+
+	response, err := sharedResult(foundation.SetObjectTypeIcon(client, common.SetObjectTypeIconParams{
+		ObjectTypeID: objectTypeID,
+		Icon:         setIconIcon,
+	}))
+	if err != nil {
+		return err
+	}
+
+	return outputResult(response)
+}
+
+// COPY-OBJECT-TYPE command
+var copyObjectTypeCmd = &cobra.Command{
+	Use:   "copy-object-type",
+	Short: "Create an object type from an existing one",
+	Long: `Create a new object type with the icon, description and attributes of an existing one.
+
+The copy is created under the same parent as the source, or as a child of the
+source with --as-child. Attributes the new type already inherits are skipped.`,
+	Example: `  # Create a child type with the same attributes
+  assets copy-object-type --source 141 --name 'Gaming Laptops' --as-child
+
+  # Create a sibling, resolving the source by name
+  assets copy-object-type --source "Laptops" --schema IT --name 'Tablets'
+
+  # Preview what would be created
+  assets copy-object-type --source 141 --name 'Tablets' --dry-run`,
+	RunE: runCopyObjectTypeCmd,
+}
+
+var (
+	copyObjectTypeSource      string
+	copyObjectTypeSchema      string
+	copyObjectTypeName        string
+	copyObjectTypeDescription string
+	copyObjectTypeAsChild     bool
+	copyObjectTypeDryRun      bool
+)
+
+func init() {
+	copyObjectTypeCmd.Flags().StringVar(&copyObjectTypeSource, "source", "", "Source object type ID, or name with --schema (required)")
+	copyObjectTypeCmd.Flags().StringVar(&copyObjectTypeSchema, "schema", "", "Schema ID or name used to resolve a source name")
+	copyObjectTypeCmd.Flags().StringVar(&copyObjectTypeName, "name", "", "Name of the new object type (required)")
+	copyObjectTypeCmd.Flags().StringVar(&copyObjectTypeDescription, "description", "", "Description (defaults to the source's)")
+	copyObjectTypeCmd.Flags().BoolVar(&copyObjectTypeAsChild, "as-child", false, "Create the new type as a child of the source")
+	copyObjectTypeCmd.Flags().BoolVar(&copyObjectTypeDryRun, "dry-run", false, "Show what would be created without making changes")
+
+	copyObjectTypeCmd.MarkFlagRequired("source")
+	copyObjectTypeCmd.MarkFlagRequired("name")
+}
+
+func runCopyObjectTypeCmd(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	sourceID := copyObjectTypeSource
+	if copyObjectTypeSchema != "" {
+		sourceID, err = resolveObjectTypeFlag(client, "", copyObjectTypeSource, copyObjectTypeSchema, "--source")
+		if err != nil {
+			return err
+		}
+	}
+
+	response, err := sharedResult(composite.CopyObjectType(client, common.CopyObjectTypeParams{
+		SourceID:    sourceID,
+		Name:        copyObjectTypeName,
+		Description: copyObjectTypeDescription,
+		AsChild:     copyObjectTypeAsChild,
+		DryRun:      copyObjectTypeDryRun,
+	}))
+	if err != nil {
+		return err
+	}
+	if copyObjectTypeDryRun {
+		return outputResult(response)
+	}
+
+	// The copy is a new object type, so the same next steps apply as for create
+	data := sharedData(response)
+	objectTypeID := ""
+	if objectType, ok := data["object_type"].(*models.ObjectTypeScheme); ok {
+		objectTypeID = objectType.ID
+	}
+	return outputResult(addNextStepHints(response, "create_object_type", map[string]interface{}{
+		"object_type_name": copyObjectTypeName,
+		"object_type_id":   objectTypeID,
+		"source_type_id":   sourceID,
+		"schema_id":        data["schema_id"],
+		"has_parent":       data["parent_id"] != "",
+		"has_description":  true,
+		"has_custom_icon":  data["icon_id"] != "",
+	}))
+}
+
+// resolveObjectTypeFlag returns the object type ID given directly, or resolves a name
+// within a schema
+func resolveObjectTypeFlag(client common.ClientInterface, id, name, schema, idFlag string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+	if name == "" {
+		return "", fmt.Errorf("must specify either %s or --name", idFlag)
+	}
+	if schema == "" {
+		return "", fmt.Errorf("must specify --schema when using a name")
+	}
+
+	objectTypeID, err := resolver.NewResolver(client).ResolveObjectTypeID(context.Background(), schema, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve object type: %w", err)
+	}
+	return objectTypeID, nil
+}
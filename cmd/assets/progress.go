@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Width of the progress bar in characters
+const progressBarWidth = 30
+
+// newProgressBar returns a progress callback that draws a bar on stderr, or nil when
+// stderr is not a terminal so that redirected output stays clean
+func newProgressBar(label string) func(done, total int) {
+	info, err := os.Stderr.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+
+	return func(done, total int) {
+		if total == 0 {
+			return
+		}
+		filled := done * progressBarWidth / total
+		fmt.Fprintf(os.Stderr, "\r%s [%s%s] %d/%d", label,
+			strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled), done, total)
+		if done == total {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+}
@@ -1,11 +1,12 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
 )
 
 // REMOVE command with subcommands - for removing attributes, relationships, etc.
@@ -111,16 +112,16 @@ func init() {
 	removeAttributeCmd.MarkFlagRequired("type-id")
 	
 	// Relationship flags
-	removeRelationshipCmd.Flags().StringVar(&removeObjectID, "object-id", "", "Object ID to remove relationship from")
+	removeRelationshipCmd.Flags().StringVar(&removeObjectID, "object-id", "", "Object ID or key to remove relationship from")
 	removeRelationshipCmd.Flags().StringVar(&removeRelationshipID, "relationship-id", "", "Relationship ID to remove")
 	removeRelationshipCmd.Flags().StringVar(&removeRelationshipType, "relationship-type", "", "Relationship type to remove")
-	removeRelationshipCmd.Flags().StringVar(&removeTargetID, "target-id", "", "Target object ID for relationship removal")
+	removeRelationshipCmd.Flags().StringVar(&removeTargetID, "target-id", "", "Target object ID or key for relationship removal")
 	removeRelationshipCmd.Flags().BoolVar(&removeConfirm, "confirm", false, "Confirm removal")
 	removeRelationshipCmd.Flags().BoolVar(&removeForce, "force", false, "Force removal without confirmation")
 	removeRelationshipCmd.MarkFlagRequired("object-id")
 	
 	// Property flags
-	removePropertyCmd.Flags().StringVar(&removeObjectID, "object-id", "", "Object ID to remove property from")
+	removePropertyCmd.Flags().StringVar(&removeObjectID, "object-id", "", "Object ID or key to remove property from")
 	removePropertyCmd.Flags().StringVar(&removePropertyID, "property-id", "", "Property ID to remove")
 	removePropertyCmd.Flags().StringVar(&removePropertyName, "property-name", "", "Property name(s) to remove (comma-separated)")
 	removePropertyCmd.Flags().BoolVar(&removeConfirm, "confirm", false, "Confirm removal")
@@ -140,8 +141,6 @@ func runRemoveAttributeCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	ctx := context.Background()
-	
 	// Validate input
 	if removeAttributeID == "" && removeAttributeName == "" {
 		return fmt.Errorf("must specify either --attribute-id or --attribute-name")
@@ -152,39 +151,25 @@ func runRemoveAttributeCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("attribute removal requires --confirm or --force flag for safety")
 	}
 	
-	// Resolve attribute ID if needed
-	var attributeID string
-	if removeAttributeID != "" {
-		attributeID = removeAttributeID
-	} else {
-		// TODO: Implement name-to-ID resolution
-		return fmt.Errorf("removal by attribute name not yet implemented")
-	}
-	
-	// Perform removal
-	response, err := client.RemoveAttribute(ctx, removeTypeID, attributeID)
+	response, err := sharedResult(foundation.RemoveAttribute(client, common.RemoveAttributeParams{
+		ObjectTypeID:  removeTypeID,
+		AttributeID:   removeAttributeID,
+		AttributeName: removeAttributeName,
+		Confirm:       true,
+	}))
 	if err != nil {
-		return fmt.Errorf("failed to remove attribute: %w", err)
+		return err
 	}
 	
-	if !response.Success {
-		return fmt.Errorf("remove operation failed: %v", response.Error)
-	}
-	
-	result := map[string]interface{}{
-		"action":       "remove_attribute",
-		"type_id":      removeTypeID,
-		"attribute_id": attributeID,
-		"force":        removeForce,
-		"confirm":      removeConfirm,
-		"removed":      true,
-	}
+	data := sharedData(response)
+	data["force"] = removeForce
 	
 	// Add contextual hints
-	enhancedResponse := addNextStepHints(NewSuccessResponse(result), "remove_attribute", map[string]interface{}{
-		"type_id":      removeTypeID,
-		"attribute_id": attributeID,
-		"success":      true,
+	enhancedResponse := addNextStepHints(response, "remove_attribute", map[string]interface{}{
+		"type_id":        removeTypeID,
+		"object_type_id": removeTypeID,
+		"attribute_id":   data["attribute_id"],
+		"success":        true,
 	})
 	
 	return outputResult(enhancedResponse)
@@ -197,8 +182,6 @@ func runRemoveRelationshipCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	ctx := context.Background()
-	
 	// Validate input
 	if removeRelationshipID == "" && (removeRelationshipType == "" || removeTargetID == "") {
 		return fmt.Errorf("must specify either --relationship-id or both --relationship-type and --target-id")
@@ -209,39 +192,20 @@ func runRemoveRelationshipCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("relationship removal requires --confirm or --force flag for safety")
 	}
 	
-	// Get client instance
-	assetsClient, err := getClient()
+	response, err := sharedResult(foundation.RemoveRelationship(client, common.RemoveRelationshipParams{
+		ObjectID:         removeObjectID,
+		RelationshipID:   removeRelationshipID,
+		RelationshipType: removeRelationshipType,
+		TargetID:         removeTargetID,
+		Confirm:          true,
+	}))
 	if err != nil {
-		return fmt.Errorf("failed to create client: %w", err)
-	}
-	defer assetsClient.Close()
-	
-	// Perform removal - both methods return not implemented error for now
-	var removeErr error
-	
-	if removeRelationshipID != "" {
-		_, removeErr = assetsClient.RemoveRelationship(ctx, removeObjectID, removeRelationshipID)
-	} else {
-		_, removeErr = assetsClient.RemoveRelationshipByType(ctx, removeObjectID, removeRelationshipType, removeTargetID)
-	}
-	
-	if removeErr != nil {
-		return fmt.Errorf("failed to remove relationship: %w", removeErr)
-	}
-	
-	result := map[string]interface{}{
-		"action":            "remove_relationship",
-		"object_id":         removeObjectID,
-		"relationship_id":   removeRelationshipID,
-		"relationship_type": removeRelationshipType,
-		"target_id":         removeTargetID,
-		"force":             removeForce,
-		"confirm":           removeConfirm,
-		"removed":           true,
+		return err
 	}
+	sharedData(response)["force"] = removeForce
 	
 	// Add contextual hints
-	enhancedResponse := addNextStepHints(NewSuccessResponse(result), "remove_relationship", map[string]interface{}{
+	enhancedResponse := addNextStepHints(response, "remove_relationship", map[string]interface{}{
 		"object_id":         removeObjectID,
 		"relationship_type": removeRelationshipType,
 		"success":           true,
@@ -257,8 +221,6 @@ func runRemovePropertyCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	ctx := context.Background()
-	
 	// Validate input
 	if removePropertyID == "" && removePropertyName == "" {
 		return fmt.Errorf("must specify either --property-id or --property-name")
@@ -269,60 +231,32 @@ func runRemovePropertyCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("property removal requires --confirm or --force flag for safety")
 	}
 	
-	var removedProperties []string
-	var errors []string
-	
-	if removePropertyID != "" {
-		// Remove single property by ID
-		response, err := client.RemoveProperty(ctx, removeObjectID, removePropertyID)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("Property %s: %v", removePropertyID, err))
-		} else if !response.Success {
-			errors = append(errors, fmt.Sprintf("Property %s: %v", removePropertyID, response.Error))
-		} else {
-			removedProperties = append(removedProperties, removePropertyID)
-		}
-	} else {
-		// Remove properties by name (comma-separated)
-		propertyNames := strings.Split(removePropertyName, ",")
-		for _, name := range propertyNames {
-			name = strings.TrimSpace(name)
-			response, err := client.RemovePropertyByName(ctx, removeObjectID, name)
-			if err != nil {
-				errors = append(errors, fmt.Sprintf("Property %s: %v", name, err))
-			} else if !response.Success {
-				errors = append(errors, fmt.Sprintf("Property %s: %v", name, response.Error))
-			} else {
-				removedProperties = append(removedProperties, name)
-			}
-		}
+	var propertyNames []string
+	if removePropertyName != "" {
+		propertyNames = strings.Split(removePropertyName, ",")
 	}
 	
-	result := map[string]interface{}{
-		"action":             "remove_property",
-		"object_id":          removeObjectID,
-		"property_id":        removePropertyID,
-		"property_name":      removePropertyName,
-		"removed_properties": removedProperties,
-		"removed_count":      len(removedProperties),
-		"force":              removeForce,
-		"confirm":            removeConfirm,
+	response, err := sharedResult(foundation.RemoveProperty(client, common.RemovePropertyParams{
+		ObjectID:      removeObjectID,
+		PropertyID:    removePropertyID,
+		PropertyNames: propertyNames,
+		Confirm:       true,
+	}))
+	if err != nil {
+		return err
 	}
 	
-	if len(errors) > 0 {
-		result["errors"] = errors
-		result["success"] = false
-	} else {
-		result["success"] = true
-	}
+	data := sharedData(response)
+	data["force"] = removeForce
+	_, hasErrors := data["errors"]
 	
 	// Add contextual hints
-	enhancedResponse := addNextStepHints(NewSuccessResponse(result), "remove_property", map[string]interface{}{
-		"object_id":      removeObjectID,
-		"removed_count":  len(removedProperties),
-		"success":        len(errors) == 0,
-		"has_errors":     len(errors) > 0,
+	enhancedResponse := addNextStepHints(response, "remove_property", map[string]interface{}{
+		"object_id":     removeObjectID,
+		"removed_count": data["removed_count"],
+		"success":       !hasErrors,
+		"has_errors":    hasErrors,
 	})
 	
 	return outputResult(enhancedResponse)
-}
\ No newline at end of file
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
 	"github.com/aaronsb/atlassian-assets/internal/resolver"
 )
 
@@ -53,53 +55,16 @@ func runResolveSchemaCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	resolver := resolver.NewResolver(client)
-	ctx := context.Background()
-
-	var response *Response
-
 	if resolveSchemaName != "" && resolveSchemaID != "" {
 		return fmt.Errorf("specify either --name or --id, not both")
 	}
 
-	if resolveSchemaName == "" && resolveSchemaID == "" {
-		// List all schemas with resolution info
-		schemas, err := resolver.ListResolvedSchemas(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to list schemas: %w", err)
-		}
-
-		response = NewSuccessResponse(map[string]interface{}{
-			"action":  "list_schemas",
-			"schemas": schemas,
-			"count":   len(schemas),
-		})
-	} else if resolveSchemaName != "" {
-		// Resolve name to ID
-		id, err := resolver.ResolveSchemaID(ctx, resolveSchemaName)
-		if err != nil {
-			return fmt.Errorf("failed to resolve schema name: %w", err)
-		}
-
-		response = NewSuccessResponse(map[string]interface{}{
-			"action": "name_to_id",
-			"input":  resolveSchemaName,
-			"result": id,
-			"type":   "schema",
-		})
-	} else {
-		// Resolve ID to name
-		name, err := resolver.ResolveSchemaName(ctx, resolveSchemaID)
-		if err != nil {
-			return fmt.Errorf("failed to resolve schema ID: %w", err)
-		}
-
-		response = NewSuccessResponse(map[string]interface{}{
-			"action": "id_to_name",
-			"input":  resolveSchemaID,
-			"result": name,
-			"type":   "schema",
-		})
+	response, err := sharedResult(foundation.ResolveSchema(client, common.ResolveParams{
+		Name: resolveSchemaName,
+		ID:   resolveSchemaID,
+	}))
+	if err != nil {
+		return err
 	}
 
 	return outputResult(response)
@@ -139,64 +104,17 @@ func runResolveTypeCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	resolver := resolver.NewResolver(client)
-	ctx := context.Background()
-
-	var response *Response
-
 	if resolveTypeName != "" && resolveTypeID != "" {
 		return fmt.Errorf("specify either --name or --id, not both")
 	}
 
-	if resolveTypeName == "" && resolveTypeID == "" {
-		// List all object types for schema
-		if resolveTypeSchema == "" {
-			return fmt.Errorf("--schema is required when listing object types")
-		}
-
-		objectTypes, err := resolver.ListResolvedObjectTypes(ctx, resolveTypeSchema)
-		if err != nil {
-			return fmt.Errorf("failed to list object types: %w", err)
-		}
-
-		response = NewSuccessResponse(map[string]interface{}{
-			"action":       "list_object_types",
-			"schema":       resolveTypeSchema,
-			"object_types": objectTypes,
-			"count":        len(objectTypes),
-		})
-	} else if resolveTypeName != "" {
-		// Resolve name to ID
-		if resolveTypeSchema == "" {
-			return fmt.Errorf("--schema is required when resolving object type name")
-		}
-
-		id, err := resolver.ResolveObjectTypeID(ctx, resolveTypeSchema, resolveTypeName)
-		if err != nil {
-			return fmt.Errorf("failed to resolve object type name: %w", err)
-		}
-
-		response = NewSuccessResponse(map[string]interface{}{
-			"action": "name_to_id",
-			"input":  resolveTypeName,
-			"result": id,
-			"schema": resolveTypeSchema,
-			"type":   "object_type",
-		})
-	} else {
-		// Resolve ID to name
-		name, schemaName, err := resolver.ResolveObjectTypeName(ctx, resolveTypeID)
-		if err != nil {
-			return fmt.Errorf("failed to resolve object type ID: %w", err)
-		}
-
-		response = NewSuccessResponse(map[string]interface{}{
-			"action":      "id_to_name",
-			"input":       resolveTypeID,
-			"result":      name,
-			"schema_name": schemaName,
-			"type":        "object_type",
-		})
+	response, err := sharedResult(foundation.ResolveObjectType(client, common.ResolveParams{
+		Name:   resolveTypeName,
+		ID:     resolveTypeID,
+		Schema: resolveTypeSchema,
+	}))
+	if err != nil {
+		return err
 	}
 
 	return outputResult(response)
@@ -211,7 +129,10 @@ var resolveObjectCmd = &cobra.Command{
 Supports multiple input formats:
 - Numeric ID: "384"
 - Object key: "FAC-384" 
-- Compound format: "Facilities/FAC-384"`,
+- Compound format: "Facilities/FAC-384"
+- Schema and label: "Facilities/Building A"
+
+Resolved keys and labels are kept in the resolver cache.`,
 	Example: `  # Resolve object by ID
   assets resolve object --ref "384"
   
@@ -219,14 +140,17 @@ Supports multiple input formats:
   assets resolve object --ref "FAC-384"
   
   # Resolve with schema context
-  assets resolve object --ref "Facilities/FAC-384"`,
+  assets resolve object --ref "Facilities/FAC-384"
+
+  # Resolve by label within a schema
+  assets resolve object --ref "Facilities/Building A"`,
 	RunE: runResolveObjectCmd,
 }
 
 var resolveObjectRef string
 
 func init() {
-	resolveObjectCmd.Flags().StringVar(&resolveObjectRef, "ref", "", "Object reference (ID, key, or schema/key)")
+	resolveObjectCmd.Flags().StringVar(&resolveObjectRef, "ref", "", "Object reference (ID, key, schema/key or schema/label)")
 	resolveObjectCmd.MarkFlagRequired("ref")
 }
 
@@ -237,43 +161,13 @@ func runResolveObjectCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	resolver := resolver.NewResolver(client)
-	ctx := context.Background()
-
-	// First resolve the reference to an ID
-	objectID, err := resolver.ResolveObjectID(ctx, resolveObjectRef)
-	if err != nil {
-		return fmt.Errorf("failed to resolve object reference: %w", err)
-	}
-
-	// Get detailed object information
-	objectInfo, err := resolver.GetObjectInfo(ctx, objectID)
+	response, err := sharedResult(foundation.ResolveObject(client, common.ResolveParams{
+		Ref: resolveObjectRef,
+	}))
 	if err != nil {
-		return fmt.Errorf("failed to get object info: %w", err)
-	}
-
-	// Get additional context (schema and object type names)
-	var schemaName, objectTypeName string
-	if objectInfo.SchemaID != "" {
-		schemaName, _ = resolver.ResolveSchemaName(ctx, objectInfo.SchemaID)
-	}
-	if objectInfo.ParentID != "" {
-		objectTypeName, _, _ = resolver.ResolveObjectTypeName(ctx, objectInfo.ParentID)
+		return err
 	}
 
-	response := NewSuccessResponse(map[string]interface{}{
-		"action":           "resolve_object",
-		"input":            resolveObjectRef,
-		"object_id":        objectInfo.ID,
-		"object_key":       objectInfo.Name,
-		"display_name":     objectInfo.DisplayName,
-		"schema_id":        objectInfo.SchemaID,
-		"schema_name":      schemaName,
-		"object_type_id":   objectInfo.ParentID,
-		"object_type_name": objectTypeName,
-		"last_updated":     objectInfo.LastUpdated,
-	})
-
 	return outputResult(response)
 }
 
@@ -309,7 +203,10 @@ func runResolveStatsCmd(cmd *cobra.Command, args []string) error {
 var resolveRefreshCmd = &cobra.Command{
 	Use:   "refresh",
 	Short: "Refresh the resolver cache",
-	Long: `Force refresh of the resolver cache by fetching latest data from the API.`,
+	Long: `Refresh the resolver cache from the API.
+
+The schema list is compared with the cache, and only schemas whose updated time or
+object type count changed, or that this tool changed, have their object types reloaded.`,
 	Example: `  # Refresh cache
   assets resolve refresh`,
 	RunE: runResolveRefreshCmd,
@@ -333,7 +230,7 @@ func runResolveRefreshCmd(cmd *cobra.Command, args []string) error {
 
 	response := NewSuccessResponse(map[string]interface{}{
 		"action":  "refresh_cache",
-		"message": "Cache refreshed successfully",
+		"message": fmt.Sprintf("Cache refreshed successfully, %d schemas reloaded", stats["reloaded_schemas"]),
 		"stats":   stats,
 	})
 
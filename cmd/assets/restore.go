@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+)
+
+// RESTORE command - recreate deleted objects from a local archive
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore deleted object instances from an archive",
+	Long: `Recreate object instances that were deleted with 'assets delete instance --archive'.
+
+Each object is created again in its object type with the attribute values it had
+when it was deleted, and the reference attributes of other objects that pointed at
+it are pointed at the new object. References between objects restored together
+are kept. Restored objects get new IDs and keys; the archive records them so an
+object is never restored twice.
+
+Objects that still exist, for example because their deletion failed, are skipped.`,
+	Example: `  # Restore everything in the default archive that has not been restored
+  assets restore
+
+  # Restore specific objects, by their original IDs
+  assets restore --archive ./deleted-objects.jsonl --id 456,789`,
+	RunE: runRestoreCmd,
+}
+
+var (
+	restoreArchive string
+	restoreIDs     string
+)
+
+func init() {
+	restoreCmd.Flags().StringVar(&restoreArchive, "archive", "", "Archive file written by 'delete instance --archive' (default: deleted-objects.jsonl in the cache directory)")
+	restoreCmd.Flags().StringVar(&restoreIDs, "id", "", "Original IDs of the objects to restore (comma-separated, default: all unrestored objects)")
+}
+
+func runRestoreCmd(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	archivePath := restoreArchive
+	if archivePath == "" {
+		if archivePath, err = foundation.DefaultArchivePath(client); err != nil {
+			return fmt.Errorf("failed to locate archive: %w", err)
+		}
+	}
+
+	var ids []string
+	if restoreIDs != "" {
+		ids = strings.Split(restoreIDs, ",")
+	}
+
+	response, err := sharedResult(foundation.RestoreObjects(client, common.RestoreObjectsParams{
+		Archive: archivePath,
+		IDs:     ids,
+	}))
+	if err != nil {
+		return err
+	}
+
+	data := sharedData(response)
+	restored, _ := data["restored"].([]map[string]interface{})
+	_, hasUnattached := data["unattached_references"]
+	succeeded, _ := data["success"].(bool)
+
+	hintVars := map[string]interface{}{
+		"success":        succeeded && len(restored) > 0,
+		"has_unattached": hasUnattached,
+	}
+	if len(restored) > 0 {
+		hintVars["object_id"] = restored[0]["restored_id"]
+	}
+
+	return outputResult(addNextStepHints(response, "restore_objects", hintVars))
+}
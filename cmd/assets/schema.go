@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
 )
 
 // SCHEMA command with subcommands
@@ -160,22 +162,27 @@ func runSchemaCreateTypeCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	ctx := context.Background()
-
 	// Convert parent string to pointer if provided
 	var parentPtr *string
 	if createTypeParent != "" {
 		parentPtr = &createTypeParent
 	}
 
-	response, err := client.CreateObjectType(ctx, createTypeSchema, createTypeName, createTypeDescription, createTypeIconID, parentPtr)
+	response, err := sharedResult(foundation.CreateObjectType(client, common.CreateObjectTypeParams{
+		Schema:      createTypeSchema,
+		Name:        createTypeName,
+		Description: createTypeDescription,
+		Parent:      parentPtr,
+		Icon:        createTypeIconID,
+	}))
 	if err != nil {
-		return fmt.Errorf("failed to create object type: %w", err)
+		return err
 	}
 
 	// Add contextual hints
 	enhancedResponse := addNextStepHints(response, "create_object_type", map[string]interface{}{
 		"object_type_name": createTypeName,
+		"object_type_id":   createdObjectTypeID(sharedData(response)),
 		"schema_id":        createTypeSchema,
 		"has_parent":       parentPtr != nil,
 		"has_description":  createTypeDescription != "",
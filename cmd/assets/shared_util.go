@@ -0,0 +1,28 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+)
+
+// sharedResult converts a response from the shared common layer into a CLI response.
+// Failed operations become command errors so the process exits non-zero.
+func sharedResult(response *common.Response, err error) (*Response, error) {
+	if err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, errors.New(response.Error)
+	}
+	return NewSuccessResponse(response.Data), nil
+}
+
+// sharedData returns the result map carried by a successful shared response
+func sharedData(response *Response) map[string]interface{} {
+	data, _ := response.Data.(map[string]interface{})
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return data
+}
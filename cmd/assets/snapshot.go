@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+)
+
+// SNAPSHOT command - keep a local copy of the workspace for offline reads
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Manage the local workspace snapshot used by --offline",
+	Long: `Manage the local snapshot of schemas, object types, attributes and objects,
+kept in a BoltDB file (snapshot/workspace.db in the cache directory by default).
+
+With --offline, the list, get, search, attributes, browse, catalog and trace
+commands answer from the snapshot instead of Assets. Searches support a subset of
+AQL: comparisons on keys, labels, object types and attribute values, like, in and
+is EMPTY, combined with AND, OR and parentheses, and order by. AQL functions and
+dot notation are not supported offline.`,
+}
+
+// SNAPSHOT PULL subcommand
+var snapshotPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Download schemas into the local snapshot",
+	Long: `Download the object types, attributes and objects of one or more schemas into
+the snapshot file. Schemas pulled before are replaced; other schemas in the file
+are kept. The file is only written once every schema has been pulled.`,
+	Example: `  # Pull a schema into the default snapshot
+  assets snapshot pull --schema Hardware
+
+  # Pull several schemas into a specific file
+  assets snapshot pull --schema 7 --schema Facilities --snapshot ./datacenter.db
+
+  # Later, without a connection
+  assets search --simple "SRV-042" --offline --snapshot ./datacenter.db`,
+	RunE: runSnapshotPullCmd,
+}
+
+var snapshotPullSchemas []string
+
+func init() {
+	snapshotPullCmd.Flags().StringSliceVar(&snapshotPullSchemas, "schema", nil, "Schema ID or name to pull (repeatable, required)")
+	snapshotPullCmd.MarkFlagRequired("schema")
+
+	snapshotCmd.AddCommand(snapshotPullCmd)
+}
+
+func runSnapshotPullCmd(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	path := snapshotFile
+	if path == "" {
+		if path, err = foundation.DefaultSnapshotPath(client); err != nil {
+			return fmt.Errorf("failed to locate snapshot: %w", err)
+		}
+	}
+
+	response, err := sharedResult(foundation.PullSnapshot(client, common.PullSnapshotParams{
+		Schemas:  snapshotPullSchemas,
+		File:     path,
+		Progress: newProgressBar("Pulling schemas"),
+	}))
+	if err != nil {
+		return err
+	}
+
+	data := sharedData(response)
+	hintVars := map[string]interface{}{
+		"success": response.Success,
+	}
+	if pulled, _ := data["pulled"].([]map[string]interface{}); len(pulled) > 0 {
+		hintVars["schema_id"] = pulled[0]["schema_id"]
+	}
+
+	return outputResult(addNextStepHints(response, "snapshot_pull", hintVars))
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/composite"
 	"github.com/aaronsb/atlassian-assets/internal/validation"
 )
 
@@ -87,6 +89,7 @@ func runSummaryCompletionCmd(cmd *cobra.Command, args []string) error {
 				"value":      def.Value,
 				"reason":     def.Reason,
 				"confidence": def.Confidence,
+				"source":     def.Source,
 			})
 		}
 		summary["applied_defaults"] = defaults
@@ -132,78 +135,14 @@ func runSummarySchemaCmd(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	ctx := context.Background()
-
-	// Get schema info
-	schemaResponse, err := client.GetSchema(ctx, summarySchemaID)
-	if err != nil {
-		return fmt.Errorf("failed to get schema: %w", err)
-	}
-
-	// Get object types
-	typesResponse, err := client.GetObjectTypes(ctx, summarySchemaID)
+	response, err := sharedResult(composite.SummarizeSchema(client, common.SchemaSummaryParams{
+		SchemaID: summarySchemaID,
+	}))
 	if err != nil {
-		return fmt.Errorf("failed to get object types: %w", err)
-	}
-
-	if !schemaResponse.Success || !typesResponse.Success {
-		return fmt.Errorf("API error getting schema data")
-	}
-
-	schemaData := schemaResponse.Data
-	typesData := typesResponse.Data.(map[string]interface{})
-	objectTypesData := typesData["object_types"]
-	
-	// Parse object types to analyze structure
-	jsonBytes, _ := json.Marshal(objectTypesData)
-	var objectTypes []map[string]interface{}
-	json.Unmarshal(jsonBytes, &objectTypes)
-
-	// Analyze structure without complex jq
-	parentCounts := make(map[string]int)
-	rootTypes := 0
-	
-	for _, objType := range objectTypes {
-		if parent, exists := objType["parentObjectTypeId"]; exists && parent != nil {
-			parentStr := parent.(string)
-			parentCounts[parentStr]++
-		} else {
-			rootTypes++
-		}
-	}
-
-	summary := map[string]interface{}{
-		"action":            "schema_summary",
-		"schema":            schemaData,
-		"total_object_types": len(objectTypes),
-		"root_types":        rootTypes,
-		"parent_types":      len(parentCounts),
-		"deepest_hierarchy": len(parentCounts), // Simplified for now
+		return err
 	}
 
-	// Add top parents by child count
-	if len(parentCounts) > 0 {
-		topParents := make([]map[string]interface{}, 0)
-		for parentID, count := range parentCounts {
-			// Find the parent name
-			parentName := parentID
-			for _, objType := range objectTypes {
-				if objType["id"] == parentID {
-					parentName = objType["name"].(string)
-					break
-				}
-			}
-			
-			topParents = append(topParents, map[string]interface{}{
-				"id":           parentID,
-				"name":         parentName,
-				"child_count":  count,
-			})
-		}
-		summary["top_parents"] = topParents
-	}
-
-	return outputResult(NewSuccessResponse(summary))
+	return outputResult(response)
 }
 
 func init() {
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common"
+	"github.com/aaronsb/atlassian-assets/cmd/assets/common/foundation"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+)
+
+// Shortest polling interval, to stay clear of the API rate limits
+const minWatchInterval = 10 * time.Second
+
+// WATCH command - stream changes to the objects matching a query
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream changes to the objects matching an AQL query",
+	Long: `Poll an AQL query and compare each result set with the previous one. Objects
+that enter the results are "added", objects that leave them are "removed", and
+objects whose updated timestamp moved are "changed".
+
+Each event is written to stdout as one JSON object per line (NDJSON), with the
+object as Assets returned it. With --hook, the command is run through sh for each
+event instead: the event JSON is on its stdin, and ASSETS_WATCH_EVENT,
+ASSETS_OBJECT_ID and ASSETS_OBJECT_KEY are set in its environment. A failing
+hook or poll is logged and the watch carries on; stop it with Ctrl-C.
+
+The first poll only records the current results unless --initial is given.`,
+	Example: `  # Stream changes to production servers every minute
+  assets watch --query 'objectType = "Server" AND Environment = "Production"'
+
+  # Post to a chat webhook whenever a server changes
+  assets watch --query 'objectType = "Server"' --interval 5m \
+    --hook 'curl -s -X POST -d @- https://hooks.example.com/assets'
+
+  # Process events with jq
+  assets watch --query 'objectSchemaId = 7' | jq -r 'select(.event == "removed") | .object_key'`,
+	RunE: runWatchCmd,
+}
+
+var (
+	watchQuery    string
+	watchInterval time.Duration
+	watchHook     string
+	watchInitial  bool
+)
+
+func init() {
+	watchCmd.Flags().StringVar(&watchQuery, "query", "", "AQL query whose results are watched (required)")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", time.Minute, "Time between polls (at least 10s)")
+	watchCmd.Flags().StringVar(&watchHook, "hook", "", "Shell command run for each event, with the event JSON on stdin")
+	watchCmd.Flags().BoolVar(&watchInitial, "initial", false, "Report the objects of the first poll as added")
+	watchCmd.MarkFlagRequired("query")
+}
+
+func runWatchCmd(cmd *cobra.Command, args []string) error {
+	if watchInterval < minWatchInterval {
+		return fmt.Errorf("--interval must be at least %s, got %s", minWatchInterval, watchInterval)
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	watcher, err := foundation.NewObjectWatcher(client, common.WatchObjectsParams{
+		Query:   watchQuery,
+		Initial: watchInitial,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// A query that fails the first time is not worth retrying
+	events, err := watcher.Poll(ctx)
+	if err != nil {
+		return err
+	}
+	emitWatchEvents(ctx, events)
+	logger.Info("Watching %q every %s", watchQuery, watchInterval)
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		events, err := watcher.Poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			logger.Warning("Watch poll failed, retrying in %s: %v", watchInterval, err)
+			continue
+		}
+		emitWatchEvents(ctx, events)
+	}
+}
+
+// emitWatchEvents writes each event as a line of JSON, or runs the hook for it
+func emitWatchEvents(ctx context.Context, events []*foundation.WatchEvent) {
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			logger.Warning("Failed to encode %s event for object %s: %v", event.Event, event.ObjectID, err)
+			continue
+		}
+
+		if watchHook == "" {
+			fmt.Println(string(line))
+			continue
+		}
+
+		hook := exec.CommandContext(ctx, "sh", "-c", watchHook)
+		hook.Stdin = bytes.NewReader(append(line, '\n'))
+		hook.Stdout = os.Stdout
+		hook.Stderr = os.Stderr
+		hook.Env = append(os.Environ(),
+			"ASSETS_WATCH_EVENT="+event.Event,
+			"ASSETS_OBJECT_ID="+event.ObjectID,
+			"ASSETS_OBJECT_KEY="+event.ObjectKey,
+		)
+		if err := hook.Run(); err != nil && ctx.Err() == nil {
+			logger.Warning("Hook failed for %s event on object %s: %v", event.Event, event.ObjectID, err)
+		}
+	}
+}
@@ -74,13 +74,20 @@ func runWorkflowsShowCmd(cmd *cobra.Command, args []string) error {
 		})
 	}
 	
+	data := map[string]interface{}{
+		"action":      "show_workflow",
+		"workflow_id": workflowsShowWorkflow,
+		"steps":       steps,
+	}
+	
+	// Flag step commands this CLI would not accept
+	if issues := hints.ValidateSteps(cmd.Root(), "workflows."+workflowsShowWorkflow, steps); len(issues) > 0 {
+		data["command_issues"] = issues
+	}
+	
 	response := map[string]interface{}{
 		"success": true,
-		"data": map[string]interface{}{
-			"action":      "show_workflow",
-			"workflow_id": workflowsShowWorkflow,
-			"steps":       steps,
-		},
+		"data":    data,
 	}
 	
 	return outputResult(response)
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/aaronsb/atlassian-assets/internal/hints"
+)
+
+// TestHintCommandsMatchCommandTree keeps the hints from suggesting commands or flags
+// this CLI does not have
+func TestHintCommandsMatchCommandTree(t *testing.T) {
+	for _, issue := range hints.ValidateCommands(rootCmd) {
+		t.Errorf("%s: %s\n  %s", issue.Source, issue.Problem, issue.Command)
+	}
+}
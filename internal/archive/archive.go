@@ -0,0 +1,186 @@
+// Package archive keeps tombstones of deleted objects in a local JSON-lines file so
+// they can be recreated later. Assets has no recycle bin of its own.
+package archive
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultFileName is the archive written under the cache directory
+const DefaultFileName = "deleted-objects.jsonl"
+
+// Events recorded in an archive
+const (
+	EventDeleted  = "deleted"
+	EventRestored = "restored"
+)
+
+// Value is one stored value of an attribute, as the API returned it
+type Value struct {
+	Value        string `json:"value,omitempty"`
+	DisplayValue string `json:"display_value,omitempty"`
+	SearchValue  string `json:"search_value,omitempty"` // The key of a referenced object
+	StatusID     string `json:"status_id,omitempty"`
+}
+
+// Attribute holds the values an object had for one attribute
+type Attribute struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	DataType string  `json:"data_type"`
+	System   bool    `json:"system,omitempty"`
+	Values   []Value `json:"values"`
+}
+
+// InboundReference is an attribute of another object that pointed at the deleted object
+type InboundReference struct {
+	ObjectID      string `json:"object_id"`
+	ObjectKey     string `json:"object_key"`
+	ObjectTypeID  string `json:"object_type_id"`
+	AttributeID   string `json:"attribute_id"`
+	AttributeName string `json:"attribute_name"`
+}
+
+// Tombstone is the snapshot of an object taken before it was deleted
+type Tombstone struct {
+	ObjectID          string             `json:"object_id"`
+	ObjectKey         string             `json:"object_key"`
+	Label             string             `json:"label"`
+	ObjectTypeID      string             `json:"object_type_id"`
+	ObjectTypeName    string             `json:"object_type_name"`
+	SchemaID          string             `json:"schema_id,omitempty"`
+	Attributes        []Attribute        `json:"attributes"`
+	InboundReferences []InboundReference `json:"inbound_references,omitempty"`
+	ArchivedAt        time.Time          `json:"archived_at"`
+
+	// Set once the object has been recreated from this tombstone
+	RestoredID string     `json:"restored_id,omitempty"`
+	RestoredAt *time.Time `json:"restored_at,omitempty"`
+}
+
+// record is one line of an archive
+type record struct {
+	Event      string     `json:"event"`
+	Tombstone  *Tombstone `json:"tombstone,omitempty"`
+	ObjectID   string     `json:"object_id,omitempty"`
+	RestoredID string     `json:"restored_id,omitempty"`
+	At         time.Time  `json:"at"`
+}
+
+// Archive appends tombstones to a JSON-lines file
+type Archive struct {
+	path string
+	mu   sync.Mutex
+	now  func() time.Time
+}
+
+// Open returns the archive at path; the file is created on the first write
+func Open(path string) *Archive {
+	return &Archive{path: path, now: time.Now}
+}
+
+// DefaultPath returns the archive file in the cache directory
+func DefaultPath(cacheDir string) string {
+	return filepath.Join(cacheDir, "archive", DefaultFileName)
+}
+
+// Path returns the archive file path
+func (a *Archive) Path() string {
+	return a.path
+}
+
+// Add records the snapshot of an object that is about to be deleted
+func (a *Archive) Add(tombstone *Tombstone) error {
+	if tombstone.ArchivedAt.IsZero() {
+		tombstone.ArchivedAt = a.now().UTC()
+	}
+	return a.append(record{Event: EventDeleted, Tombstone: tombstone, ObjectID: tombstone.ObjectID, At: tombstone.ArchivedAt})
+}
+
+// MarkRestored records that the object has been recreated with a new ID
+func (a *Archive) MarkRestored(objectID, restoredID string) error {
+	return a.append(record{Event: EventRestored, ObjectID: objectID, RestoredID: restoredID, At: a.now().UTC()})
+}
+
+func (a *Archive) append(r record) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	line, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode archive record: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(a.path), 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return f.Close()
+}
+
+// Tombstones reads the archive in the order objects were deleted. An object deleted
+// more than once keeps its latest snapshot.
+func (a *Archive) Tombstones() ([]*Tombstone, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.Open(a.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	var order []string
+	byID := make(map[string]*Tombstone)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid archive record: %w", a.path, line, err)
+		}
+
+		switch r.Event {
+		case EventDeleted:
+			if r.Tombstone == nil {
+				continue
+			}
+			if _, seen := byID[r.Tombstone.ObjectID]; !seen {
+				order = append(order, r.Tombstone.ObjectID)
+			}
+			byID[r.Tombstone.ObjectID] = r.Tombstone
+		case EventRestored:
+			if tombstone, ok := byID[r.ObjectID]; ok {
+				at := r.At
+				tombstone.RestoredID = r.RestoredID
+				tombstone.RestoredAt = &at
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	tombstones := make([]*Tombstone, 0, len(order))
+	for _, id := range order {
+		tombstones = append(tombstones, byID[id])
+	}
+	return tombstones, nil
+}
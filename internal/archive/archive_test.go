@@ -0,0 +1,75 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchiveTombstones(t *testing.T) {
+	a := Open(filepath.Join(t.TempDir(), "archive", DefaultFileName))
+
+	first := &Tombstone{ObjectID: "1", ObjectKey: "HW-1", ObjectTypeID: "5", Attributes: []Attribute{
+		{ID: "51", Name: "Name", DataType: "Text", Values: []Value{{Value: "laptop"}}},
+	}}
+	second := &Tombstone{ObjectID: "2", ObjectKey: "HW-2", ObjectTypeID: "5"}
+	for _, tombstone := range []*Tombstone{first, second} {
+		if err := a.Add(tombstone); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if err := a.MarkRestored("1", "101"); err != nil {
+		t.Fatalf("MarkRestored failed: %v", err)
+	}
+
+	// A second deletion of the same object replaces the earlier snapshot
+	if err := a.Add(&Tombstone{ObjectID: "1", ObjectKey: "HW-1", ObjectTypeID: "5", Label: "again"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	tombstones, err := a.Tombstones()
+	if err != nil {
+		t.Fatalf("Tombstones failed: %v", err)
+	}
+	if len(tombstones) != 2 || tombstones[0].ObjectID != "1" || tombstones[1].ObjectID != "2" {
+		t.Fatalf("tombstones = %+v, want objects 1 and 2 in deletion order", tombstones)
+	}
+	if tombstones[0].Label != "again" || tombstones[0].RestoredID != "" {
+		t.Errorf("latest snapshot = %+v, want the unrestored second deletion", tombstones[0])
+	}
+	if tombstones[1].ArchivedAt.IsZero() {
+		t.Error("ArchivedAt was not set")
+	}
+
+	info, err := os.Stat(a.Path())
+	if err != nil {
+		t.Fatalf("archive file missing: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("archive mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestArchiveRestoredMarker(t *testing.T) {
+	a := Open(filepath.Join(t.TempDir(), DefaultFileName))
+	if err := a.Add(&Tombstone{ObjectID: "7", ObjectTypeID: "5"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := a.MarkRestored("7", "70"); err != nil {
+		t.Fatalf("MarkRestored failed: %v", err)
+	}
+
+	tombstones, err := a.Tombstones()
+	if err != nil {
+		t.Fatalf("Tombstones failed: %v", err)
+	}
+	if len(tombstones) != 1 || tombstones[0].RestoredID != "70" || tombstones[0].RestoredAt == nil {
+		t.Errorf("tombstone = %+v, want it marked restored as 70", tombstones[0])
+	}
+}
+
+func TestArchiveMissingFile(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "none.jsonl")).Tombstones(); err == nil {
+		t.Error("expected an error for a missing archive")
+	}
+}
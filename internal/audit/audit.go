@@ -0,0 +1,231 @@
+// Package audit appends a record of every change made through the CLI and MCP server
+// to a local JSON-lines log, rotated by size.
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaronsb/atlassian-assets/internal/filelock"
+)
+
+// Rotation defaults
+const (
+	DefaultMaxSizeMB = 10
+	DefaultMaxFiles  = 5
+)
+
+// Results recorded for an operation
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// Interfaces an operation can come through
+const (
+	InterfaceCLI = "cli"
+	InterfaceMCP = "mcp"
+)
+
+// Record is one change, or attempted change, made through the tool
+type Record struct {
+	Time        time.Time `json:"time"`
+	Actor       string    `json:"actor"`
+	Profile     string    `json:"profile,omitempty"`
+	Workspace   string    `json:"workspace"`
+	Interface   string    `json:"interface"`
+	Command     string    `json:"command,omitempty"` // CLI command path
+	Tool        string    `json:"tool,omitempty"`    // MCP tool
+	Session     string    `json:"session,omitempty"` // MCP session
+	RequestID   string    `json:"request_id,omitempty"`
+	Operation   string    `json:"operation"`
+	Targets     []string  `json:"targets,omitempty"`
+	PayloadHash string    `json:"payload_hash,omitempty"`
+	Result      string    `json:"result"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Log appends records to a file, moving it aside once it grows past the size limit.
+// path.1 is the most recent rotated file; files beyond the limit are removed. Writes
+// and rotations hold a lock on the file, as other processes may share the log.
+type Log struct {
+	path     string
+	maxBytes int64
+	maxFiles int
+
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// Open returns the log at path; the file is created on the first write. Sizes or
+// counts of zero or less use the defaults.
+func Open(path string, maxSizeMB, maxFiles int) *Log {
+	if maxSizeMB <= 0 {
+		maxSizeMB = DefaultMaxSizeMB
+	}
+	if maxFiles <= 0 {
+		maxFiles = DefaultMaxFiles
+	}
+	return &Log{path: path, maxBytes: int64(maxSizeMB) * 1024 * 1024, maxFiles: maxFiles, now: time.Now}
+}
+
+// Path returns the log file path
+func (l *Log) Path() string {
+	return l.path
+}
+
+// HashPayload returns the SHA-256 of the JSON encoding of a payload, or "" when
+// there is none
+func HashPayload(payload interface{}) string {
+	if payload == nil {
+		return ""
+	}
+	data, err := json.Marshal(payload)
+	if err != nil || string(data) == "null" {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Append writes a record, rotating the file first when the record would take it
+// past the size limit
+func (l *Log) Append(record *Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if record.Time.IsZero() {
+		record.Time = l.now().UTC()
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("failed to create audit directory: %w", err)
+	}
+	unlock, err := filelock.Lock(l.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if info, err := os.Stat(l.path); err == nil && info.Size() > 0 && info.Size()+int64(len(line)) > l.maxBytes {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// rotate shifts path.N to path.N+1, dropping the oldest, and moves the log to path.1
+func (l *Log) rotate() error {
+	os.Remove(l.rotated(l.maxFiles))
+	for n := l.maxFiles - 1; n >= 1; n-- {
+		if err := os.Rename(l.rotated(n), l.rotated(n+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	}
+	if err := os.Rename(l.path, l.rotated(1)); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+	return nil
+}
+
+func (l *Log) rotated(n int) string {
+	return l.path + "." + strconv.Itoa(n)
+}
+
+// Records reads the log, rotated files included, oldest first, keeping records made
+// at or after since. A missing log has no records.
+func (l *Log) Records(since time.Time) ([]*Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var records []*Record
+	for n := l.maxFiles; n >= 0; n-- {
+		path := l.path
+		if n > 0 {
+			path = l.rotated(n)
+		}
+		read, err := readRecords(path, since)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, read...)
+	}
+	return records, nil
+}
+
+func readRecords(path string, since time.Time) ([]*Record, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var records []*Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid audit record: %w", path, line, err)
+		}
+		if !r.Time.Before(since) {
+			records = append(records, &r)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return records, nil
+}
+
+// ParseSince turns a --since value into a time: a duration back from now such as 90m,
+// 24h or 7d, or an RFC 3339 or YYYY-MM-DD date
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --since value %q: use a duration such as 24h or 7d, or a date such as 2025-08-30", value)
+}
@@ -0,0 +1,124 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+	log := Open(path, 1, 2)
+	log.maxBytes = 400 // About two records per file
+
+	start := time.Date(2025, 8, 30, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 8; i++ {
+		at := start.Add(time.Duration(i) * time.Hour)
+		log.now = func() time.Time { return at }
+		if err := log.Append(&Record{Actor: "ada@example.com", Workspace: "ws-1", Operation: "update_object", Targets: []string{string(rune('a' + i))}, Result: ResultSuccess}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	// Only the log and two rotated files are kept
+	if _, err := os.Stat(path + ".2"); err != nil {
+		t.Errorf("rotated file missing: %v", err)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("rotation kept too many files: %v", err)
+	}
+
+	records, err := log.Records(time.Time{})
+	if err != nil {
+		t.Fatalf("Records failed: %v", err)
+	}
+	var targets []string
+	for _, record := range records {
+		targets = append(targets, record.Targets[0])
+	}
+	if got := strings.Join(targets, ""); got != "cdefgh" {
+		t.Errorf("records = %s, want the newest six in order", got)
+	}
+
+	recent, _ := log.Records(start.Add(6 * time.Hour))
+	if len(recent) != 2 {
+		t.Errorf("records since 18:00 = %d, want 2", len(recent))
+	}
+}
+
+func TestRecordsMissingLog(t *testing.T) {
+	records, err := Open(filepath.Join(t.TempDir(), "audit.jsonl"), 0, 0).Records(time.Time{})
+	if err != nil || len(records) != 0 {
+		t.Errorf("Records = %v, %v; want none", records, err)
+	}
+}
+
+func TestHashPayload(t *testing.T) {
+	a := HashPayload(map[string]interface{}{"Name": "x", "RAM": "16"})
+	b := HashPayload(map[string]interface{}{"RAM": "16", "Name": "x"})
+	if a == "" || a != b || !strings.HasPrefix(a, "sha256:") {
+		t.Errorf("hashes = %q, %q; want equal sha256 hashes", a, b)
+	}
+	if HashPayload(nil) != "" {
+		t.Error("a missing payload has a hash")
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 8, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "", want: time.Time{}},
+		{value: "24h", want: now.Add(-24 * time.Hour)},
+		{value: "90m", want: now.Add(-90 * time.Minute)},
+		{value: "7d", want: now.AddDate(0, 0, -7)},
+		{value: "2025-08-01", want: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "2025-08-01T09:30:00Z", want: time.Date(2025, 8, 1, 9, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got, err := ParseSince(tt.value, now); err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseSince(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+
+	if _, err := ParseSince("yesterday", now); err == nil {
+		t.Error("ParseSince(yesterday) succeeded, want an error")
+	}
+}
+
+func TestAppendAcrossProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	// Separate logs share only the files, as the CLI and the MCP server do
+	const writers, appends = 8, 50
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			log := Open(path, 1, 100)
+			log.maxBytes = 600
+			for j := 0; j < appends; j++ {
+				if err := log.Append(&Record{Actor: "ada@example.com", Workspace: "ws-1", Operation: "update_object", Result: ResultSuccess}); err != nil {
+					t.Errorf("Append failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	records, err := Open(path, 1, 100).Records(time.Time{})
+	if err != nil {
+		t.Fatalf("Records failed: %v", err)
+	}
+	if len(records) != writers*appends {
+		t.Errorf("kept %d records, want %d", len(records), writers*appends)
+	}
+}
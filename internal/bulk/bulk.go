@@ -0,0 +1,43 @@
+// Package bulk runs one operation over many objects concurrently and keeps a report
+// of the outcome for each object, so an interrupted run can be resumed.
+package bulk
+
+import "sync"
+
+// DefaultConcurrency is the number of objects processed at once
+const DefaultConcurrency = 4
+
+// Each calls fn for every ID with up to workers calls in flight, and calls done with
+// each outcome. Calls to done are serialized, so it can record results and report
+// progress without locking.
+func Each(ids []string, workers int, fn func(id string) error, done func(id string, err error)) {
+	if workers < 1 {
+		workers = DefaultConcurrency
+	}
+	if workers > len(ids) {
+		workers = len(ids)
+	}
+
+	jobs := make(chan string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				err := fn(id)
+				mu.Lock()
+				done(id, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, id := range ids {
+		jobs <- id
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,86 @@
+package bulk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEach(t *testing.T) {
+	var inFlight, peak int32
+	var done []string
+
+	Each([]string{"1", "2", "3", "4", "5", "6"}, 3, func(id string) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		defer atomic.AddInt32(&inFlight, -1)
+		if id == "4" {
+			return errors.New("boom")
+		}
+		return nil
+	}, func(id string, err error) {
+		if (id == "4") != (err != nil) {
+			t.Errorf("outcome for %s = %v", id, err)
+		}
+		done = append(done, id)
+	})
+
+	sort.Strings(done)
+	if len(done) != 6 || done[0] != "1" || done[5] != "6" {
+		t.Errorf("done = %v, want every ID once", done)
+	}
+	if peak > 3 {
+		t.Errorf("%d calls in flight, want at most 3", peak)
+	}
+}
+
+func TestReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "delete.jsonl")
+
+	r, err := OpenReport(path)
+	if err != nil {
+		t.Fatalf("OpenReport failed: %v", err)
+	}
+	if r.Run() != nil {
+		t.Errorf("new report has run %+v", r.Run())
+	}
+	if err := r.Start(Run{Operation: "delete_instances", Query: "Name = temp", ExpectCount: 3}); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	r.Record("1", nil)
+	r.Record("2", errors.New("API error: 500"))
+	r.Close()
+
+	// Simulate a run killed in the middle of a line
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	f.WriteString(`{"result":{"object_id":"3","sta`)
+	f.Close()
+
+	r, err = OpenReport(path)
+	if err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	if run := r.Run(); run == nil || run.Query != "Name = temp" || run.ExpectCount != 3 {
+		t.Errorf("run = %+v", run)
+	}
+	if !r.Done("1") || r.Done("2") || r.Done("3") || r.DoneCount() != 1 {
+		t.Errorf("done 1=%v 2=%v 3=%v count=%d, want only object 1", r.Done("1"), r.Done("2"), r.Done("3"), r.DoneCount())
+	}
+
+	// Results written after the partial line are still read back
+	r.Record("2", nil)
+	r.Close()
+	r, _ = OpenReport(path)
+	defer r.Close()
+	if !r.Done("2") || r.DoneCount() != 2 {
+		t.Errorf("retried object 2 not recorded as done")
+	}
+}
@@ -0,0 +1,206 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Outcomes recorded for an object
+const (
+	StatusDone   = "done"
+	StatusFailed = "failed"
+)
+
+// Run describes the operation a report was written for
+type Run struct {
+	Operation   string    `json:"operation"`
+	Query       string    `json:"query,omitempty"`
+	ExpectCount int       `json:"expect_count,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+}
+
+// Result is the outcome of the operation for one object
+type Result struct {
+	ObjectID string    `json:"object_id"`
+	Status   string    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+// record is one line of a report: the run header or an object's result
+type record struct {
+	Run    *Run    `json:"run,omitempty"`
+	Result *Result `json:"result,omitempty"`
+}
+
+// Report is a JSON-lines file of per-object results. Reopening a report continues it.
+type Report struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+	run  *Run
+	done map[string]bool
+}
+
+// OpenReport opens the report at path, reading the results already recorded in it
+func OpenReport(path string) (*Report, error) {
+	r := &Report{path: path, done: make(map[string]bool)}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create report directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open report: %w", err)
+	}
+	r.file = file
+
+	// Start on a fresh line after a partial write from an interrupted run
+	if info, err := file.Stat(); err == nil && info.Size() > 0 {
+		if last, err := readLastByte(path); err == nil && last != '\n' {
+			file.Write([]byte{'\n'})
+		}
+	}
+	return r, nil
+}
+
+// DefaultReportPath returns a new report file for an operation under the cache directory
+func DefaultReportPath(cacheDir, operation string) string {
+	return filepath.Join(cacheDir, "reports", fmt.Sprintf("%s-%s.jsonl", operation, time.Now().UTC().Format("20060102-150405")))
+}
+
+func readLastByte(path string) (byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	last := make([]byte, 1)
+	if _, err := f.Seek(-1, io.SeekEnd); err != nil {
+		return 0, err
+	}
+	if _, err := f.Read(last); err != nil {
+		return 0, err
+	}
+	return last[0], nil
+}
+
+func (r *Report) load() error {
+	f, err := os.Open(r.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open report: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			// A run killed mid-write leaves a partial last line; the object is retried
+			continue
+		}
+		if rec.Run != nil && r.run == nil {
+			r.run = rec.Run
+		}
+		if rec.Result != nil {
+			r.done[rec.Result.ObjectID] = rec.Result.Status == StatusDone
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+	return nil
+}
+
+// Path returns the report file path
+func (r *Report) Path() string {
+	return r.path
+}
+
+// Run returns the run the report was started for, or nil for a new report
+func (r *Report) Run() *Run {
+	return r.run
+}
+
+// Start records the run header of a new report
+func (r *Report) Start(run Run) error {
+	if r.run != nil {
+		return nil
+	}
+	if run.StartedAt.IsZero() {
+		run.StartedAt = time.Now().UTC()
+	}
+	r.run = &run
+	return r.write(record{Run: &run})
+}
+
+// Done reports whether the operation already succeeded for an object
+func (r *Report) Done(objectID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.done[objectID]
+}
+
+// DoneCount returns the number of objects the operation succeeded for
+func (r *Report) DoneCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, ok := range r.done {
+		if ok {
+			count++
+		}
+	}
+	return count
+}
+
+// Record appends the outcome for one object
+func (r *Report) Record(objectID string, err error) error {
+	result := Result{ObjectID: objectID, Status: StatusDone, At: time.Now().UTC()}
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	r.done[objectID] = err == nil
+	r.mu.Unlock()
+
+	return r.write(record{Result: &result})
+}
+
+func (r *Report) write(rec record) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode report record: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// Close closes the report file
+func (r *Report) Close() error {
+	return r.file.Close()
+}
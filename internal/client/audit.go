@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/audit"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+)
+
+// SetAuditSession marks later changes as made by the MCP server in the given session;
+// the tool name comes from the command of each call's context
+func (ac *AssetsClient) SetAuditSession(session string) {
+	ac.auditSession = session
+}
+
+// audit appends a record of a change made in ctx to the audit log. Created resources
+// are added to the targets. A failure to write the log is a warning; it never fails
+// the change.
+func (ac *AssetsClient) audit(ctx context.Context, operation string, targets []string, payload interface{}, result *Response, err error) {
+	if ac.auditLog == nil {
+		return
+	}
+
+	record := &audit.Record{
+		Actor:       ac.config.Email,
+		Profile:     ac.config.Profile,
+		Workspace:   ac.workspaceID,
+		Interface:   audit.InterfaceCLI,
+		Command:     logger.CommandFrom(ctx),
+		RequestID:   logger.RequestIDFrom(ctx),
+		Operation:   operation,
+		PayloadHash: audit.HashPayload(payload),
+		Result:      audit.ResultSuccess,
+	}
+	for _, target := range targets {
+		if target != "" {
+			record.Targets = append(record.Targets, target)
+		}
+	}
+	if ac.auditSession != "" {
+		record.Interface = audit.InterfaceMCP
+		record.Tool, record.Command = record.Command, ""
+		record.Session = ac.auditSession
+	}
+
+	switch {
+	case err != nil:
+		record.Result, record.Error = audit.ResultError, err.Error()
+	case result == nil || !result.Success:
+		record.Result = audit.ResultError
+		if result != nil {
+			record.Error = result.Error
+		}
+	default:
+		if id := createdID(result); id != "" && !contains(record.Targets, id) {
+			record.Targets = append(record.Targets, id)
+		}
+	}
+
+	if err := ac.auditLog.Append(record); err != nil {
+		logger.Warning("Failed to write audit log: %v", err)
+	}
+}
+
+// createdID returns the ID of the schema, object type, object or attribute a create
+// call returned
+func createdID(result *Response) string {
+	data := result.Data
+	if m, ok := data.(map[string]interface{}); ok {
+		for _, key := range []string{"object", "object_type", "attribute"} {
+			if created, ok := m[key]; ok {
+				data = created
+				break
+			}
+		}
+	}
+
+	switch created := data.(type) {
+	case *models.ObjectSchemaScheme:
+		return created.ID
+	case *models.ObjectTypeScheme:
+		return created.ID
+	case *models.ObjectScheme:
+		return created.ID
+	case *models.ObjectTypeAttributeScheme:
+		return created.ID
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
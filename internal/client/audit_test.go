@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/audit"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+)
+
+func TestAuditChanges(t *testing.T) {
+	log := audit.Open(filepath.Join(t.TempDir(), "audit.jsonl"), 0, 0)
+	ac := &AssetsClient{
+		config:      &config.Config{Email: "ada@example.com", Profile: "prod"},
+		workspaceID: "ws-1",
+		auditLog:    log,
+	}
+	logger.SetCommand("assets delete")
+	defer logger.SetCommand("")
+
+	// Refused changes are audited too
+	ac.DeleteObject(context.Background(), "1001")
+
+	// Created resources are added to the targets
+	ac.SetAuditSession("session-1")
+	ctx := logger.WithCommand(context.Background(), "assets_create_object")
+	ac.audit(ctx, "create_object", []string{"141", ""}, map[string]interface{}{"Name": "x"},
+		NewSuccessResponse(map[string]interface{}{"object": &models.ObjectScheme{ID: "1002"}}), nil)
+
+	// Overlapping tool calls each keep their own request ID and tool
+	first := ac.ForRequest(logger.WithRequestID(logger.WithCommand(context.Background(), "assets_delete_object"), "req-1"))
+	second := ac.ForRequest(logger.WithRequestID(logger.WithCommand(context.Background(), "assets_update_object"), "req-2"))
+	second.DeleteObject(context.Background(), "1003")
+	first.DeleteObject(context.Background(), "1004")
+
+	records, err := log.Records(time.Time{})
+	if err != nil || len(records) != 4 {
+		t.Fatalf("Records = %v, %v; want 4", records, err)
+	}
+
+	deleted := records[0]
+	if deleted.Operation != "delete_object" || deleted.Result != audit.ResultError || deleted.Error != "delete operations are disabled" ||
+		deleted.Actor != "ada@example.com" || deleted.Profile != "prod" || deleted.Workspace != "ws-1" ||
+		deleted.Interface != audit.InterfaceCLI || deleted.Command != "assets delete" || len(deleted.Targets) != 1 || deleted.Targets[0] != "1001" {
+		t.Errorf("delete record = %+v", deleted)
+	}
+
+	created := records[1]
+	if created.Result != audit.ResultSuccess || created.Interface != audit.InterfaceMCP || created.Tool != "assets_create_object" ||
+		created.Session != "session-1" || created.Command != "" || created.PayloadHash == "" ||
+		len(created.Targets) != 2 || created.Targets[1] != "1002" {
+		t.Errorf("create record = %+v", created)
+	}
+
+	for i, want := range []struct{ tool, requestID, target string }{
+		{"assets_update_object", "req-2", "1003"},
+		{"assets_delete_object", "req-1", "1004"},
+	} {
+		record := records[2+i]
+		if record.Tool != want.tool || record.RequestID != want.requestID || record.Targets[0] != want.target {
+			t.Errorf("record %d = %+v, want tool %s and request %s", 2+i, record, want.tool, want.requestID)
+		}
+	}
+}
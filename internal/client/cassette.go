@@ -0,0 +1,297 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aaronsb/atlassian-assets/internal/config"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+)
+
+// cassetteVersion is bumped when the cassette layout changes
+const cassetteVersion = 1
+
+// Credentials used when replaying, which needs none
+const (
+	replayEmail    = "replay@example.com"
+	replayAPIToken = "replay-token"
+)
+
+// Cassette holds the HTTP exchanges of a recorded run, in the order they were made
+type Cassette struct {
+	Version      int            `json:"version"`
+	Host         string         `json:"host"`
+	WorkspaceID  string         `json:"workspace_id,omitempty"`
+	RecordedAt   time.Time      `json:"recorded_at"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is one request and the response it got. Headers are not kept, so the
+// authorization header never reaches the file.
+type Interaction struct {
+	Method       string        `json:"method"`
+	URL          string        `json:"url"`
+	RequestBody  *cassetteBody `json:"request_body,omitempty"`
+	Status       int           `json:"status"`
+	ContentType  string        `json:"content_type,omitempty"`
+	ResponseBody *cassetteBody `json:"response_body,omitempty"`
+}
+
+// cassetteBody keeps JSON bodies readable in the file and anything else as text
+type cassetteBody struct {
+	JSON json.RawMessage `json:"json,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+// newCassetteBody returns nil for an empty body
+func newCassetteBody(data []byte) *cassetteBody {
+	if len(data) == 0 {
+		return nil
+	}
+	var compact bytes.Buffer
+	if json.Compact(&compact, data) == nil {
+		return &cassetteBody{JSON: compact.Bytes()}
+	}
+	return &cassetteBody{Text: string(data)}
+}
+
+func (b *cassetteBody) bytes() []byte {
+	if b == nil {
+		return nil
+	}
+	if len(b.JSON) > 0 {
+		return b.JSON
+	}
+	return []byte(b.Text)
+}
+
+// equal compares bodies ignoring JSON formatting
+func (b *cassetteBody) equal(other *cassetteBody) bool {
+	if b == nil || other == nil {
+		return b == other
+	}
+	if len(b.JSON) > 0 || len(other.JSON) > 0 {
+		var x, y bytes.Buffer
+		return json.Compact(&x, b.JSON) == nil && json.Compact(&y, other.JSON) == nil && bytes.Equal(x.Bytes(), y.Bytes())
+	}
+	return b.Text == other.Text
+}
+
+// LoadCassette reads a recorded cassette
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	if cassette.Version != cassetteVersion {
+		return nil, fmt.Errorf("cassette %s has version %d, expected %d - record it again", path, cassette.Version, cassetteVersion)
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette, replacing the file only once it is complete
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// cassetteTransport returns the transport for the record or replay mode in the
+// configuration, nil outside them, and the configuration to run with. The resolver
+// disk cache is turned off in both modes so a replay makes the same requests as the
+// recording; a replay also fills in the site, workspace and credentials it was
+// recorded with.
+func cassetteTransport(cfg *config.Config) (http.RoundTripper, *config.Config, error) {
+	if cfg.RecordFile == "" && cfg.ReplayFile == "" {
+		return nil, cfg, nil
+	}
+	if cfg.RecordFile != "" && cfg.ReplayFile != "" {
+		return nil, nil, fmt.Errorf("ATLASSIAN_ASSETS_RECORD and ATLASSIAN_ASSETS_REPLAY cannot be used together")
+	}
+
+	cassetteConfig := *cfg
+	cassetteConfig.CacheTTLHours = 0
+
+	if cfg.RecordFile != "" {
+		logger.Info("Recording API calls to %s", cfg.RecordFile)
+		return &recordingTransport{
+			base: http.DefaultTransport,
+			path: cfg.RecordFile,
+			cassette: &Cassette{
+				Version:     cassetteVersion,
+				Host:        cfg.Host,
+				WorkspaceID: cfg.WorkspaceID,
+				RecordedAt:  time.Now().UTC(),
+			},
+		}, &cassetteConfig, nil
+	}
+
+	cassette, err := LoadCassette(cfg.ReplayFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load cassette: %w", err)
+	}
+	logger.Info("Replaying %d API calls from %s", len(cassette.Interactions), cfg.ReplayFile)
+
+	if cassetteConfig.Host == "" {
+		cassetteConfig.Host = cassette.Host
+	}
+	if cassetteConfig.WorkspaceID == "" {
+		cassetteConfig.WorkspaceID = cassette.WorkspaceID
+	}
+	if cassetteConfig.Email == "" {
+		cassetteConfig.Email = replayEmail
+	}
+	if cassetteConfig.APIToken == "" {
+		cassetteConfig.APIToken = replayAPIToken
+	}
+	return &replayTransport{cassette: cassette, used: make([]bool, len(cassette.Interactions))}, &cassetteConfig, nil
+}
+
+// recordingTransport makes each request and appends the exchange to the cassette,
+// saving it after every call so an interrupted run still leaves a usable file
+type recordingTransport struct {
+	base http.RoundTripper
+	path string
+
+	mu       sync.Mutex
+	cassette *Cassette
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response for recording: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := &Interaction{
+		Method:       req.Method,
+		URL:          logger.Redact(req.URL.String()),
+		RequestBody:  newCassetteBody([]byte(logger.Redact(string(requestBody)))),
+		Status:       resp.StatusCode,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ResponseBody: newCassetteBody([]byte(logger.Redact(string(responseBody)))),
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	if err := t.cassette.Save(t.path); err != nil {
+		logger.Warning("Failed to save cassette: %v", err)
+	}
+	return resp, nil
+}
+
+// setWorkspaceID records the workspace the run discovered
+func (t *recordingTransport) setWorkspaceID(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.WorkspaceID = id
+}
+
+// replayTransport answers requests from a cassette without touching the network. A
+// request gets the first unused exchange with the same method, URL and body, so
+// repeated calls see responses in recorded order; once those are used up the last
+// one is served again.
+type replayTransport struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	url := logger.Redact(req.URL.String())
+	body := newCassetteBody([]byte(logger.Redact(string(requestBody))))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	match := -1
+	for i, interaction := range t.cassette.Interactions {
+		if interaction.Method != req.Method || interaction.URL != url || !interaction.RequestBody.equal(body) {
+			continue
+		}
+		match = i
+		if !t.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded response for %s %s; record the cassette again with ATLASSIAN_ASSETS_RECORD", req.Method, url)
+	}
+	t.used[match] = true
+
+	interaction := t.cassette.Interactions[match]
+	header := http.Header{}
+	if interaction.ContentType != "" {
+		header.Set("Content-Type", interaction.ContentType)
+	}
+	responseBody := interaction.ResponseBody.bytes()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(responseBody)),
+		ContentLength: int64(len(responseBody)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody returns the body of a request and leaves it readable for the
+// transport that sends it
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
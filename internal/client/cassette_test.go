@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+)
+
+func TestRecordReplay(t *testing.T) {
+	const token = "record-test-api-token"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "ada@example.com" || password != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/servicedeskapi/insight/workspace":
+			w.Write([]byte(`{"values": [{"workspaceId": "ws-9"}]}`))
+		case "/rest/api/3/user/search":
+			w.Write([]byte(`[{"accountId": "557058:1", "displayName": "Ada", "emailAddress": "ada@example.com"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	path := filepath.Join(t.TempDir(), "cassettes", "users.json")
+
+	recording, err := NewAssetsClient(&config.Config{Email: "ada@example.com", Host: server.URL, APIToken: token, RecordFile: path, CacheTTLHours: 24})
+	if err != nil {
+		t.Fatalf("NewAssetsClient failed: %v", err)
+	}
+	if recording.GetConfig().CacheTTLHours != 0 {
+		t.Error("the resolver disk cache is on while recording")
+	}
+	if response, _ := recording.SearchUsers(context.Background(), "ada", 10); !response.Success {
+		t.Fatalf("SearchUsers failed: %s", response.Error)
+	}
+	server.Close()
+
+	// Nothing that authenticates reaches the file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{token, base64.StdEncoding.EncodeToString([]byte("ada@example.com:" + token)), "Authorization"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+
+	// The replay needs neither the server nor credentials
+	replaying, err := NewAssetsClient(&config.Config{ReplayFile: path})
+	if err != nil {
+		t.Fatalf("NewAssetsClient failed: %v", err)
+	}
+	if replaying.GetWorkspaceID() != "ws-9" {
+		t.Errorf("workspace = %q, want the recorded ws-9", replaying.GetWorkspaceID())
+	}
+	response, _ := replaying.SearchUsers(context.Background(), "ada", 10)
+	if users, _ := response.Data.(map[string]interface{})["users"].([]*models.UserScheme); len(users) != 1 || users[0].DisplayName != "Ada" {
+		t.Errorf("replayed users = %+v", response)
+	}
+	if response, _ := replaying.SearchUsers(context.Background(), "bob", 10); response.Success || !strings.Contains(response.Error, "no recorded response for GET") {
+		t.Errorf("unrecorded search = %+v, want a replay miss", response)
+	}
+}
+
+func TestReplayOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "objects.json")
+	object := func(label string) *Interaction {
+		return &Interaction{
+			Method:       "GET",
+			URL:          "https://api.atlassian.com/jsm/assets/workspace/ws-1/v1/object/1",
+			Status:       200,
+			ContentType:  "application/json",
+			ResponseBody: newCassetteBody([]byte(`{"id": "1", "label": "` + label + `"}`)),
+		}
+	}
+	cassette := &Cassette{Version: cassetteVersion, Host: "https://example.atlassian.net", WorkspaceID: "ws-1",
+		Interactions: []*Interaction{object("Before"), object("After")}}
+	if err := cassette.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewAssetsClient(&config.Config{ReplayFile: path})
+	if err != nil {
+		t.Fatalf("NewAssetsClient failed: %v", err)
+	}
+
+	// Responses come back in recorded order, then the last one repeats
+	for _, want := range []string{"Before", "After", "After"} {
+		response, _ := client.GetObject(context.Background(), "1")
+		if !response.Success {
+			t.Fatalf("GetObject failed: %s", response.Error)
+		}
+		if label := response.Data.(*models.ObjectScheme).Label; label != want {
+			t.Errorf("label = %q, want %q", label, want)
+		}
+	}
+
+	if _, err := NewAssetsClient(&config.Config{ReplayFile: path, RecordFile: path}); err == nil {
+		t.Error("recording and replaying together succeeded")
+	}
+}
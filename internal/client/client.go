@@ -7,10 +7,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/ctreminiom/go-atlassian/v2/assets"
 	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/audit"
 	"github.com/aaronsb/atlassian-assets/internal/config"
+	"github.com/aaronsb/atlassian-assets/internal/hooks"
 	"github.com/aaronsb/atlassian-assets/internal/logger"
 )
 
@@ -20,16 +23,30 @@ type AssetsClient struct {
 	assetsAPI   *assets.Client
 	config      *config.Config
 	workspaceID string
+
+	auditLog     *audit.Log
+	auditSession string // MCP session the changes are made in
+	hooks        *hooks.Config
+
+	request context.Context // Request the calls are made for, see ForRequest
 }
 
 // NewAssetsClient creates a new Assets client with the given configuration
 func NewAssetsClient(cfg *config.Config) (*AssetsClient, error) {
+	// Record or replay the API calls when ATLASSIAN_ASSETS_RECORD or _REPLAY is set
+	base, cfg, err := cassetteTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	// Create HTTP client for basic auth
-	httpClient := &http.Client{}
+	// Create HTTP client for basic auth; the credentials never reach the logs
+	httpClient := &http.Client{Transport: newLoggingTransport(base)}
+	logger.RegisterSecret(cfg.GetPassword())
+	logger.RegisterSecret(base64.StdEncoding.EncodeToString([]byte(cfg.GetUsername() + ":" + cfg.GetPassword())))
 
 	// Create the assets API client - use default Assets API URL
 	assetsAPI, err := assets.New(httpClient, "https://api.atlassian.com/")
@@ -57,6 +74,22 @@ func NewAssetsClient(cfg *config.Config) (*AssetsClient, error) {
 		ac.workspaceID = cfg.WorkspaceID
 	}
 
+	if recorder, ok := base.(*recordingTransport); ok {
+		recorder.setWorkspaceID(ac.workspaceID)
+	}
+
+	// Changes are audited, except in replays where nothing changes
+	if path := cfg.GetAuditPath(); path != "" && cfg.ReplayFile == "" {
+		ac.auditLog = audit.Open(path, cfg.AuditMaxSizeMB, cfg.AuditMaxFiles)
+	}
+
+	// Hooks are not run in replays either; a broken hooks file must not skip a veto
+	if cfg.ReplayFile == "" {
+		if ac.hooks, err = hooks.LoadDefault(); err != nil {
+			return nil, fmt.Errorf("failed to load hooks: %w", err)
+		}
+	}
+
 	return ac, nil
 }
 
@@ -111,6 +144,8 @@ func (ac *AssetsClient) GetWorkspaceID() string {
 
 // TestConnection tests the connection to the Atlassian instance
 func (ac *AssetsClient) TestConnection(ctx context.Context) error {
+	ctx = ac.withRequest(ctx)
+
 	// Try to make a simple API call to verify connectivity
 	// This could be a call to get current user or workspace info
 	return fmt.Errorf("connection test not yet implemented")
@@ -143,6 +178,25 @@ func (ac *AssetsClient) WithWorkspaceID(workspaceID string) *AssetsClient {
 	return ac
 }
 
+// ForRequest returns a copy of the client whose API calls, audit records and hook
+// events carry the request ID and command of ctx. An MCP server takes one per tool call,
+// so that concurrent calls are never attributed to each other.
+func (ac *AssetsClient) ForRequest(ctx context.Context) *AssetsClient {
+	bound := *ac
+	bound.request = ctx
+	return &bound
+}
+
+// withRequest adds the request ID and command of the request the client is bound to
+// to the context of a call
+func (ac *AssetsClient) withRequest(ctx context.Context) context.Context {
+	if ac.request == nil {
+		return ctx
+	}
+	ctx = logger.WithRequestID(ctx, logger.RequestIDFrom(ac.request))
+	return logger.WithCommand(ctx, logger.CommandFrom(ac.request))
+}
+
 // Common response wrapper for consistent error handling
 type Response struct {
 	Success bool        `json:"success"`
@@ -168,6 +222,8 @@ func NewErrorResponse(err error) *Response {
 
 // ListSchemas lists all object schemas in the workspace
 func (ac *AssetsClient) ListSchemas(ctx context.Context) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -188,7 +244,11 @@ func (ac *AssetsClient) ListSchemas(ctx context.Context) (*Response, error) {
 }
 
 // CreateSchema creates a new object schema
-func (ac *AssetsClient) CreateSchema(ctx context.Context, name, description string) (*Response, error) {
+func (ac *AssetsClient) CreateSchema(ctx context.Context, name, description string) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "create_schema", nil, map[string]interface{}{"name": name, "description": description}, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -241,6 +301,8 @@ func generateSchemaKey(name string) string {
 
 // GetSchema gets details of a specific schema
 func (ac *AssetsClient) GetSchema(ctx context.Context, schemaID string) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -259,6 +321,8 @@ func (ac *AssetsClient) GetSchema(ctx context.Context, schemaID string) (*Respon
 
 // GetObjectTypes gets object types for a specific schema
 func (ac *AssetsClient) GetObjectTypes(ctx context.Context, schemaID string) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -281,11 +345,15 @@ func (ac *AssetsClient) GetObjectTypes(ctx context.Context, schemaID string) (*R
 
 // ListObjects lists objects in a specific schema using structured search
 func (ac *AssetsClient) ListObjects(ctx context.Context, schemaID string, limit int) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
 	return ac.ListObjectsWithPagination(ctx, schemaID, limit, 0)
 }
 
 // ListObjectsWithPagination lists objects in a specific schema with pagination support
 func (ac *AssetsClient) ListObjectsWithPagination(ctx context.Context, schemaID string, limit int, offset int) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -293,7 +361,7 @@ func (ac *AssetsClient) ListObjectsWithPagination(ctx context.Context, schemaID
 	// Use AQL query to get all objects in schema - now using direct HTTP call
 	query := fmt.Sprintf("objectSchemaId = %s", schemaID)
 	
-	logger.Debug("ListObjectsWithPagination using direct HTTP call - workspaceID=%s, query=%s, limit=%d, offset=%d", ac.workspaceID, query, limit, offset)
+	logger.Debug("ListObjectsWithPagination using direct HTTP call - workspaceID=%s, schemaID=%s, limit=%d, offset=%d", ac.workspaceID, schemaID, limit, offset)
 	
 	// Use direct HTTP call to bypass broken SDK
 	objects, err := ac.searchObjectsDirectWithPagination(ctx, query, limit, offset)
@@ -313,6 +381,8 @@ func (ac *AssetsClient) ListObjectsWithPagination(ctx context.Context, schemaID
 
 // GetObject gets a specific object by ID
 func (ac *AssetsClient) GetObject(ctx context.Context, objectID string) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -329,8 +399,34 @@ func (ac *AssetsClient) GetObject(ctx context.Context, objectID string) (*Respon
 	return NewSuccessResponse(object), nil
 }
 
+// GetObjectHistory gets the change history of an object, newest first
+func (ac *AssetsClient) GetObjectHistory(ctx context.Context, objectID string) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
+	if ac.workspaceID == "" {
+		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
+	}
+
+	history, response, err := ac.assetsAPI.Object.History(ctx, ac.workspaceID, objectID, false)
+	if err != nil {
+		return NewErrorResponse(fmt.Errorf("failed to get object history: %w", err)), nil
+	}
+
+	if response.Code != 200 {
+		return NewErrorResponse(fmt.Errorf("API error: %d - %s", response.Code, response.Bytes.String())), nil
+	}
+
+	return NewSuccessResponse(map[string]interface{}{
+		"history":   history,
+		"object_id": objectID,
+		"total":     len(history),
+	}), nil
+}
+
 // GetObjectTypeAttributes gets all attributes for a specific object type
 func (ac *AssetsClient) GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -357,16 +453,20 @@ func (ac *AssetsClient) GetObjectTypeAttributes(ctx context.Context, objectTypeI
 
 // SearchObjects searches for objects using AQL with human-readable results
 func (ac *AssetsClient) SearchObjects(ctx context.Context, query string, limit int) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
 	return ac.SearchObjectsWithPagination(ctx, query, limit, 0)
 }
 
 // SearchObjectsWithPagination searches for objects using AQL with pagination support
 func (ac *AssetsClient) SearchObjectsWithPagination(ctx context.Context, query string, limit int, offset int) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
 
-	logger.Debug("SearchObjectsWithPagination using direct HTTP call - workspaceID=%s, query=%s, limit=%d, offset=%d", ac.workspaceID, query, limit, offset)
+	logger.Debug("SearchObjectsWithPagination using direct HTTP call - workspaceID=%s, limit=%d, offset=%d", ac.workspaceID, limit, offset)
 	
 	// Use direct HTTP call to bypass broken SDK
 	objects, err := ac.searchObjectsDirectWithPagination(ctx, query, limit, offset)
@@ -417,8 +517,11 @@ func (ac *AssetsClient) searchObjectsDirectWithPagination(ctx context.Context, a
 	auth := base64.StdEncoding.EncodeToString([]byte(ac.config.GetUsername() + ":" + ac.config.GetPassword()))
 	req.Header.Set("Authorization", "Basic " + auth)
 	
-	logger.Debug("Direct HTTP POST to %s", endpoint)
-	logger.Debug("Payload: %s", string(payloadBytes))
+	// AQL carries names, emails and serials, so only its size and hash are logged
+	logger.LogContext(ctx, logger.DEBUG, "Search payload", logger.Fields{
+		"payload_bytes": len(payloadBytes),
+		"payload_hash":  audit.HashPayload(payload),
+	})
 	
 	// Make the request
 	resp, err := ac.httpClient.Do(req)
@@ -427,8 +530,6 @@ func (ac *AssetsClient) searchObjectsDirectWithPagination(ctx context.Context, a
 	}
 	defer resp.Body.Close()
 	
-	logger.Debug("Direct HTTP response status: %d", resp.StatusCode)
-	
 	if resp.StatusCode != 200 {
 		var errorBody bytes.Buffer
 		errorBody.ReadFrom(resp.Body)
@@ -449,7 +550,11 @@ func (ac *AssetsClient) searchObjectsDirectWithPagination(ctx context.Context, a
 }
 
 // CreateObjectType creates a new object type in the specified schema
-func (ac *AssetsClient) CreateObjectType(ctx context.Context, schemaID, name, description, iconID string, parentObjectTypeID *string) (*Response, error) {
+func (ac *AssetsClient) CreateObjectType(ctx context.Context, schemaID, name, description, iconID string, parentObjectTypeID *string) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "create_object_type", []string{schemaID}, map[string]interface{}{"name": name, "description": description, "icon_id": iconID, "parent_object_type_id": parentObjectTypeID}, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -489,44 +594,24 @@ func (ac *AssetsClient) CreateObjectType(ctx context.Context, schemaID, name, de
 }
 
 // CreateObject creates a new object instance in the specified object type
-func (ac *AssetsClient) CreateObject(ctx context.Context, objectTypeID string, attributes map[string]interface{}) (*Response, error) {
+func (ac *AssetsClient) CreateObject(ctx context.Context, objectTypeID string, attributes map[string]interface{}) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "create_object", []string{objectTypeID}, attributes, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
 
-	// Get object type attributes to resolve names to IDs
-	attrResponse, err := ac.GetObjectTypeAttributes(ctx, objectTypeID)
-	if err != nil {
-		return NewErrorResponse(fmt.Errorf("failed to get object type attributes: %w", err)), nil
+	event := ac.hookEvent(ctx, hooks.ObjectCreated, objectTypeID, "", attributes)
+	if err := ac.hooks.Before(ctx, event); err != nil {
+		return NewErrorResponse(err), nil
 	}
+	defer func() { ac.afterHooks(ctx, event, result, err) }()
 
-	if !attrResponse.Success {
-		return NewErrorResponse(fmt.Errorf("failed to get object type attributes: %s", attrResponse.Error)), nil
-	}
-
-	// Build name-to-ID mapping using proper type handling
-	attrData := attrResponse.Data.(map[string]interface{})
-	attributesRaw := attrData["attributes"]
-	nameToID := make(map[string]string)
-	
-	// Handle the attributes as they come from the API
-	switch attrs := attributesRaw.(type) {
-	case []*models.ObjectTypeAttributeScheme:
-		for _, attr := range attrs {
-			nameToID[attr.Name] = attr.ID
-		}
-	case []interface{}:
-		for _, attr := range attrs {
-			if attrMap, ok := attr.(map[string]interface{}); ok {
-				if id, ok := attrMap["id"].(string); ok {
-					if name, ok := attrMap["name"].(string); ok {
-						nameToID[name] = id
-					}
-				}
-			}
-		}
-	default:
-		return NewErrorResponse(fmt.Errorf("unexpected attributes type: %T", attributesRaw)), nil
+	nameToID, err := ac.attributeIDsByName(ctx, objectTypeID, attributes)
+	if err != nil {
+		return NewErrorResponse(err), nil
 	}
 
 	// Convert map[string]interface{} to ObjectPayloadScheme attributes using resolved IDs
@@ -539,13 +624,14 @@ func (ac *AssetsClient) CreateObject(ctx context.Context, objectTypeID string, a
 			attributeID = id
 		}
 		
+		values, err := payloadValues(value)
+		if err != nil {
+			return NewErrorResponse(fmt.Errorf("attribute %s: %w", key, err)), nil
+		}
+		
 		attr := &models.ObjectPayloadAttributeScheme{
 			ObjectTypeAttributeID: attributeID,
-			ObjectAttributeValues: []*models.ObjectPayloadAttributeValueScheme{
-				{
-					Value: fmt.Sprintf("%v", value),
-				},
-			},
+			ObjectAttributeValues: values,
 		}
 		objectAttributes = append(objectAttributes, attr)
 	}
@@ -560,9 +646,6 @@ func (ac *AssetsClient) CreateObject(ctx context.Context, objectTypeID string, a
 	logger.Debug("NameToID mapping: %+v", nameToID)
 	for i, attr := range objectAttributes {
 		logger.Debug("Attribute[%d]: ID=%s, ValueCount=%d", i, attr.ObjectTypeAttributeID, len(attr.ObjectAttributeValues))
-		if len(attr.ObjectAttributeValues) > 0 {
-			logger.Debug("FirstValue=%s", attr.ObjectAttributeValues[0].Value)
-		}
 	}
 
 	object, response, err := ac.assetsAPI.Object.Create(ctx, ac.workspaceID, payload)
@@ -582,12 +665,160 @@ func (ac *AssetsClient) CreateObject(ctx context.Context, objectTypeID string, a
 	}), nil
 }
 
+// attributeIDsByName maps the attribute names among the keys to attribute IDs. The
+// attributes are only fetched when some key is not already an attribute ID.
+func (ac *AssetsClient) attributeIDsByName(ctx context.Context, objectTypeID string, attributes map[string]interface{}) (map[string]string, error) {
+	nameToID := make(map[string]string)
+
+	needsLookup := false
+	for key := range attributes {
+		if _, err := strconv.Atoi(key); err != nil {
+			needsLookup = true
+			break
+		}
+	}
+	if !needsLookup {
+		return nameToID, nil
+	}
+
+	attrResponse, err := ac.GetObjectTypeAttributes(ctx, objectTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object type attributes: %w", err)
+	}
+
+	if !attrResponse.Success {
+		return nil, fmt.Errorf("failed to get object type attributes: %s", attrResponse.Error)
+	}
+
+	// Handle the attributes as they come from the API
+	attrData := attrResponse.Data.(map[string]interface{})
+	switch attrs := attrData["attributes"].(type) {
+	case []*models.ObjectTypeAttributeScheme:
+		for _, attr := range attrs {
+			nameToID[attr.Name] = attr.ID
+		}
+	case []interface{}:
+		for _, attr := range attrs {
+			if attrMap, ok := attr.(map[string]interface{}); ok {
+				if id, ok := attrMap["id"].(string); ok {
+					if name, ok := attrMap["name"].(string); ok {
+						nameToID[name] = id
+					}
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unexpected attributes type: %T", attrData["attributes"])
+	}
+
+	return nameToID, nil
+}
+
+// payloadValues converts an attribute value, or a list of values for a
+// multi-value attribute, to API payload values
+func payloadValues(value interface{}) ([]*models.ObjectPayloadAttributeValueScheme, error) {
+	var items []interface{}
+	switch v := value.(type) {
+	case []string:
+		for _, item := range v {
+			items = append(items, item)
+		}
+	case []interface{}:
+		items = v
+	default:
+		items = []interface{}{v}
+	}
+
+	values := make([]*models.ObjectPayloadAttributeValueScheme, 0, len(items))
+	for _, item := range items {
+		var text string
+		switch v := item.(type) {
+		case string:
+			text = v
+		case bool:
+			text = strconv.FormatBool(v)
+		case float64:
+			text = strconv.FormatFloat(v, 'f', -1, 64)
+		case int:
+			text = strconv.Itoa(v)
+		case int64:
+			text = strconv.FormatInt(v, 10)
+		default:
+			return nil, fmt.Errorf("unsupported value type %T", item)
+		}
+		values = append(values, &models.ObjectPayloadAttributeValueScheme{Value: text})
+	}
+	return values, nil
+}
+
+// UpdateObject replaces the values of the given attributes, keyed by attribute ID, on
+// an existing object. Attributes that are not listed keep their values.
+func (ac *AssetsClient) UpdateObject(ctx context.Context, objectID, objectTypeID string, attributes map[string]interface{}) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "update_object", []string{objectID}, attributes, result, err) }()
+
+	if ac.workspaceID == "" {
+		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
+	}
+
+	event := ac.hookEvent(ctx, hooks.ObjectUpdated, objectTypeID, objectID, attributes)
+	if err := ac.hooks.Before(ctx, event); err != nil {
+		return NewErrorResponse(err), nil
+	}
+	defer func() { ac.afterHooks(ctx, event, result, err) }()
+
+	var objectAttributes []*models.ObjectPayloadAttributeScheme
+	for attributeID, value := range attributes {
+		values, err := payloadValues(value)
+		if err != nil {
+			return NewErrorResponse(fmt.Errorf("attribute %s: %w", attributeID, err)), nil
+		}
+		objectAttributes = append(objectAttributes, &models.ObjectPayloadAttributeScheme{
+			ObjectTypeAttributeID: attributeID,
+			ObjectAttributeValues: values,
+		})
+	}
+
+	payload := &models.ObjectPayloadScheme{
+		ObjectTypeID: objectTypeID,
+		Attributes:   objectAttributes,
+	}
+
+	logger.Debug("Updating object %s: ObjectTypeID=%s, AttributeCount=%d", objectID, objectTypeID, len(objectAttributes))
+
+	object, response, err := ac.assetsAPI.Object.Update(ctx, ac.workspaceID, objectID, payload)
+	if err != nil {
+		return NewErrorResponse(fmt.Errorf("failed to update object: %w", err)), nil
+	}
+
+	if response.Code != 200 {
+		return NewErrorResponse(fmt.Errorf("API error: %d - %s", response.Code, response.Bytes.String())), nil
+	}
+
+	return NewSuccessResponse(map[string]interface{}{
+		"object":    object,
+		"object_id": objectID,
+		"message":   fmt.Sprintf("Successfully updated object %s", objectID),
+	}), nil
+}
+
 // CreateObjectTypeAttribute creates a new attribute on an object type
-func (ac *AssetsClient) CreateObjectTypeAttribute(ctx context.Context, objectTypeID string, payload *models.ObjectTypeAttributePayloadScheme) (*Response, error) {
+func (ac *AssetsClient) CreateObjectTypeAttribute(ctx context.Context, objectTypeID string, payload *models.ObjectTypeAttributePayloadScheme) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "create_object_type_attribute", []string{objectTypeID}, payload, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
 
+	event := ac.hookEvent(ctx, hooks.AttributeAdded, objectTypeID, "", payload)
+	if err := ac.hooks.Before(ctx, event); err != nil {
+		return NewErrorResponse(err), nil
+	}
+	defer func() { ac.afterHooks(ctx, event, result, err) }()
+
 	attribute, response, err := ac.assetsAPI.ObjectTypeAttribute.Create(ctx, ac.workspaceID, objectTypeID, payload)
 	if err != nil {
 		return NewErrorResponse(fmt.Errorf("failed to create object type attribute: %w", err)), nil
@@ -605,7 +836,11 @@ func (ac *AssetsClient) CreateObjectTypeAttribute(ctx context.Context, objectTyp
 }
 
 // DeleteObjectType deletes an object type (and all its instances)
-func (ac *AssetsClient) DeleteObjectType(ctx context.Context, objectTypeID string) (*Response, error) {
+func (ac *AssetsClient) DeleteObjectType(ctx context.Context, objectTypeID string) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "delete_object_type", []string{objectTypeID}, nil, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -630,7 +865,11 @@ func (ac *AssetsClient) DeleteObjectType(ctx context.Context, objectTypeID strin
 }
 
 // DeleteObject deletes an object instance
-func (ac *AssetsClient) DeleteObject(ctx context.Context, objectID string) (*Response, error) {
+func (ac *AssetsClient) DeleteObject(ctx context.Context, objectID string) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "delete_object", []string{objectID}, nil, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -639,6 +878,12 @@ func (ac *AssetsClient) DeleteObject(ctx context.Context, objectID string) (*Res
 		return NewErrorResponse(fmt.Errorf("delete operations are disabled")), nil
 	}
 
+	event := ac.hookEvent(ctx, hooks.ObjectDeleted, "", objectID, nil)
+	if err := ac.hooks.Before(ctx, event); err != nil {
+		return NewErrorResponse(err), nil
+	}
+	defer func() { ac.afterHooks(ctx, event, result, err) }()
+
 	response, err := ac.assetsAPI.Object.Delete(ctx, ac.workspaceID, objectID)
 	if err != nil {
 		return NewErrorResponse(fmt.Errorf("failed to delete object: %w", err)), nil
@@ -656,6 +901,8 @@ func (ac *AssetsClient) DeleteObject(ctx context.Context, objectID string) (*Res
 
 // GetObjectType gets object type details
 func (ac *AssetsClient) GetObjectType(ctx context.Context, objectTypeID string) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -672,8 +919,60 @@ func (ac *AssetsClient) GetObjectType(ctx context.Context, objectTypeID string)
 	return NewSuccessResponse(objectType), nil
 }
 
+// UpdateObjectType changes the name, description or icon of an object type
+func (ac *AssetsClient) UpdateObjectType(ctx context.Context, objectTypeID string, payload *models.ObjectTypePayloadScheme) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "update_object_type", []string{objectTypeID}, payload, result, err) }()
+
+	if ac.workspaceID == "" {
+		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
+	}
+
+	objectType, response, err := ac.assetsAPI.ObjectType.Update(ctx, ac.workspaceID, objectTypeID, payload)
+	if err != nil {
+		return NewErrorResponse(fmt.Errorf("failed to update object type: %w", err)), nil
+	}
+
+	if response.Code != 200 {
+		return NewErrorResponse(fmt.Errorf("API error: %d - %s", response.Code, response.Bytes.String())), nil
+	}
+
+	return NewSuccessResponse(map[string]interface{}{
+		"object_type": objectType,
+		"message":     fmt.Sprintf("Successfully updated object type %s", objectTypeID),
+	}), nil
+}
+
+// ListIcons lists the global icons object types can use
+func (ac *AssetsClient) ListIcons(ctx context.Context) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
+	if ac.workspaceID == "" {
+		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
+	}
+
+	icons, response, err := ac.assetsAPI.Icon.Global(ctx, ac.workspaceID)
+	if err != nil {
+		return NewErrorResponse(fmt.Errorf("failed to list icons: %w", err)), nil
+	}
+
+	if response.Code != 200 {
+		return NewErrorResponse(fmt.Errorf("API error: %d - %s", response.Code, response.Bytes.String())), nil
+	}
+
+	return NewSuccessResponse(map[string]interface{}{
+		"icons": icons,
+		"total": len(icons),
+	}), nil
+}
+
 // RemoveAttribute removes an attribute from an object type
-func (ac *AssetsClient) RemoveAttribute(ctx context.Context, objectTypeID, attributeID string) (*Response, error) {
+func (ac *AssetsClient) RemoveAttribute(ctx context.Context, objectTypeID, attributeID string) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "remove_attribute", []string{objectTypeID, attributeID}, nil, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -695,7 +994,11 @@ func (ac *AssetsClient) RemoveAttribute(ctx context.Context, objectTypeID, attri
 }
 
 // RemoveRelationship removes a relationship from an object
-func (ac *AssetsClient) RemoveRelationship(ctx context.Context, objectID, relationshipID string) (*Response, error) {
+func (ac *AssetsClient) RemoveRelationship(ctx context.Context, objectID, relationshipID string) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "remove_relationship", []string{objectID, relationshipID}, nil, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -706,7 +1009,11 @@ func (ac *AssetsClient) RemoveRelationship(ctx context.Context, objectID, relati
 }
 
 // RemoveRelationshipByType removes a relationship by type and target
-func (ac *AssetsClient) RemoveRelationshipByType(ctx context.Context, objectID, relationshipType, targetID string) (*Response, error) {
+func (ac *AssetsClient) RemoveRelationshipByType(ctx context.Context, objectID, relationshipType, targetID string) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "remove_relationship", []string{objectID, targetID}, map[string]interface{}{"relationship_type": relationshipType}, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -717,7 +1024,11 @@ func (ac *AssetsClient) RemoveRelationshipByType(ctx context.Context, objectID,
 }
 
 // RemoveProperty removes a property from an object
-func (ac *AssetsClient) RemoveProperty(ctx context.Context, objectID, propertyID string) (*Response, error) {
+func (ac *AssetsClient) RemoveProperty(ctx context.Context, objectID, propertyID string) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "remove_property", []string{objectID, propertyID}, nil, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
@@ -728,7 +1039,11 @@ func (ac *AssetsClient) RemoveProperty(ctx context.Context, objectID, propertyID
 }
 
 // RemovePropertyByName removes a property by name from an object
-func (ac *AssetsClient) RemovePropertyByName(ctx context.Context, objectID, propertyName string) (*Response, error) {
+func (ac *AssetsClient) RemovePropertyByName(ctx context.Context, objectID, propertyName string) (result *Response, err error) {
+	ctx = ac.withRequest(ctx)
+
+	defer func() { ac.audit(ctx, "remove_property", []string{objectID}, map[string]interface{}{"property": propertyName}, result, err) }()
+
 	if ac.workspaceID == "" {
 		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
 	}
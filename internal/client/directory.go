@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+)
+
+// SearchUsers finds Jira users whose email address or display name matches the query
+func (ac *AssetsClient) SearchUsers(ctx context.Context, query string, limit int) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("maxResults", fmt.Sprint(limit))
+
+	var users []*models.UserScheme
+	if err := ac.getJira(ctx, "/rest/api/3/user/search?"+params.Encode(), &users); err != nil {
+		return NewErrorResponse(fmt.Errorf("failed to search users: %w", err)), nil
+	}
+
+	return NewSuccessResponse(map[string]interface{}{
+		"users": users,
+		"total": len(users),
+		"query": query,
+	}), nil
+}
+
+// SearchGroups finds Jira groups whose name matches the query
+func (ac *AssetsClient) SearchGroups(ctx context.Context, query string, limit int) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("maxResults", fmt.Sprint(limit))
+
+	var picker struct {
+		Total  int                         `json:"total"`
+		Groups []*models.GroupDetailScheme `json:"groups"`
+	}
+	if err := ac.getJira(ctx, "/rest/api/3/groups/picker?"+params.Encode(), &picker); err != nil {
+		return NewErrorResponse(fmt.Errorf("failed to search groups: %w", err)), nil
+	}
+
+	return NewSuccessResponse(map[string]interface{}{
+		"groups": picker.Groups,
+		"total":  picker.Total,
+		"query":  query,
+	}), nil
+}
+
+// getJira calls a Jira platform REST endpoint on the configured site and decodes the
+// JSON response into result
+func (ac *AssetsClient) getJira(ctx context.Context, path string, result interface{}) error {
+	return ac.get(ctx, ac.config.GetBaseURL()+path, result)
+}
+
+// get calls a REST endpoint with the configured credentials and decodes the JSON
+// response into result
+func (ac *AssetsClient) get(ctx context.Context, endpoint string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.SetBasicAuth(ac.config.GetUsername(), ac.config.GetPassword())
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := ac.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		var errorBody bytes.Buffer
+		errorBody.ReadFrom(resp.Body)
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, errorBody.String())
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// GetStatusTypes lists the global status types and, with a schema ID, that schema's own
+func (ac *AssetsClient) GetStatusTypes(ctx context.Context, schemaID string) (*Response, error) {
+	ctx = ac.withRequest(ctx)
+
+	if ac.workspaceID == "" {
+		return NewErrorResponse(fmt.Errorf("workspace ID not set")), nil
+	}
+
+	paths := []string{"/config/statustype"}
+	if schemaID != "" {
+		paths = append(paths, "/config/statustype?objectSchemaId="+url.QueryEscape(schemaID))
+	}
+
+	seen := make(map[string]bool)
+	var statuses []*models.ObjectTypeAssetAttributeStatusScheme
+	for _, path := range paths {
+		var page []*models.ObjectTypeAssetAttributeStatusScheme
+		if err := ac.getAssets(ctx, path, &page); err != nil {
+			return NewErrorResponse(fmt.Errorf("failed to list status types: %w", err)), nil
+		}
+		for _, status := range page {
+			if status != nil && !seen[status.ID] {
+				seen[status.ID] = true
+				statuses = append(statuses, status)
+			}
+		}
+	}
+
+	return NewSuccessResponse(map[string]interface{}{
+		"statuses":  statuses,
+		"total":     len(statuses),
+		"schema_id": schemaID,
+	}), nil
+}
+
+// getAssets calls an Assets REST endpoint of the workspace the SDK does not cover
+func (ac *AssetsClient) getAssets(ctx context.Context, path string, result interface{}) error {
+	return ac.get(ctx, fmt.Sprintf("https://api.atlassian.com/jsm/assets/workspace/%s/v1%s", ac.workspaceID, path), result)
+}
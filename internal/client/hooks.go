@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aaronsb/atlassian-assets/internal/audit"
+	"github.com/aaronsb/atlassian-assets/internal/hooks"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+)
+
+// hookEvent describes a change made in ctx for the hooks, with the same origin as its
+// audit record
+func (ac *AssetsClient) hookEvent(ctx context.Context, event, objectTypeID, objectID string, attributes interface{}) *hooks.Event {
+	e := &hooks.Event{
+		Event:        event,
+		Time:         time.Now().UTC().Format(time.RFC3339),
+		Actor:        ac.config.Email,
+		Profile:      ac.config.Profile,
+		Workspace:    ac.workspaceID,
+		Interface:    audit.InterfaceCLI,
+		Command:      logger.CommandFrom(ctx),
+		RequestID:    logger.RequestIDFrom(ctx),
+		ObjectTypeID: objectTypeID,
+		ObjectID:     objectID,
+		Attributes:   attributes,
+	}
+	if ac.auditSession != "" {
+		e.Interface = audit.InterfaceMCP
+		e.Tool, e.Command = e.Command, ""
+		e.Session = ac.auditSession
+	}
+	return e
+}
+
+// afterHooks runs the post hooks of a change with its outcome. Created resources are
+// reported by their new ID.
+func (ac *AssetsClient) afterHooks(ctx context.Context, event *hooks.Event, result *Response, err error) {
+	switch {
+	case err != nil:
+	case result == nil || !result.Success:
+		err = errors.New("change failed")
+		if result != nil && result.Error != "" {
+			err = errors.New(result.Error)
+		}
+	case event.Event == hooks.AttributeAdded:
+		event.AttributeID = createdID(result)
+	case event.ObjectID == "":
+		event.ObjectID = createdID(result)
+	}
+	ac.hooks.After(ctx, event, err)
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aaronsb/atlassian-assets/internal/audit"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+	"github.com/aaronsb/atlassian-assets/internal/hooks"
+)
+
+func TestHookVeto(t *testing.T) {
+	log := audit.Open(filepath.Join(t.TempDir(), "audit.jsonl"), 0, 0)
+	ac := &AssetsClient{
+		config:      &config.Config{Email: "ada@example.com", AllowDelete: true},
+		workspaceID: "ws-1",
+		auditLog:    log,
+		hooks: &hooks.Config{Hooks: []hooks.Hook{{
+			Name:    "freeze",
+			Phase:   hooks.PhasePre,
+			Events:  []string{hooks.ObjectDeleted},
+			Command: `test "$ASSETS_OBJECT_ID" != 1001 || { echo "1001 is frozen"; exit 1; }`,
+			Timeout: time.Second,
+		}}},
+	}
+
+	// The veto stops the call before it reaches the API
+	result, err := ac.DeleteObject(context.Background(), "1001")
+	if err != nil || result.Success || result.Error != "object.deleted vetoed by hook freeze: 1001 is frozen" {
+		t.Fatalf("DeleteObject = %+v, %v; want a veto", result, err)
+	}
+
+	records, _ := log.Records(time.Time{})
+	if len(records) != 1 || records[0].Result != audit.ResultError || !strings.Contains(records[0].Error, "vetoed") {
+		t.Errorf("audit records = %+v, want the veto recorded", records)
+	}
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+)
+
+// loggingTransport logs the endpoint, status and duration of every API call, both the
+// SDK's and our direct HTTP requests, under the request ID of the call's context
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+func newLoggingTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &loggingTransport{base: base}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	endpoint := req.URL.Redacted()
+	if err != nil {
+		logger.Request(req.Context(), req.Method, endpoint, 0, time.Since(start), err)
+		return nil, err
+	}
+	logger.Request(req.Context(), req.Method, endpoint, resp.StatusCode, time.Since(start), nil)
+	return resp, nil
+}
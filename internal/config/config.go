@@ -12,18 +12,50 @@ import (
 
 // Config holds all configuration for the Assets CLI
 type Config struct {
-	Email         string
-	Host          string
-	APIToken      string
-	WorkspaceID   string
-	Profile       string
-	CacheDir      string
-	CacheTTLHours int
-	AllowDelete   bool
+	Email          string
+	Host           string
+	APIToken       string
+	WorkspaceID    string
+	Profile        string
+	CacheDir       string
+	CacheTTLHours  int
+	AllowDelete    bool
+	RecordFile     string // Cassette that API calls are recorded to
+	ReplayFile     string // Cassette that API calls are answered from
+	AuditFile      string // Audit log of changes; "off" disables it
+	AuditMaxSizeMB int
+	AuditMaxFiles  int
 }
 
 // LoadConfig loads configuration from environment variables and .env file
 func LoadConfig() (*Config, error) {
+	config, err := LoadLocalConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Replays need no tenant; the cassette supplies the site and workspace
+	if config.ReplayFile != "" {
+		return config, nil
+	}
+
+	// Validate required fields
+	if config.Email == "" {
+		return nil, fmt.Errorf("ATLASSIAN_EMAIL is required")
+	}
+	if config.Host == "" {
+		return nil, fmt.Errorf("ATLASSIAN_HOST is required")
+	}
+	if config.APIToken == "" {
+		return nil, fmt.Errorf("ATLASSIAN_API_TOKEN is required")
+	}
+
+	return config, nil
+}
+
+// LoadLocalConfig loads configuration without requiring credentials, for commands
+// that only read local files
+func LoadLocalConfig() (*Config, error) {
 	// Load .env file if it exists
 	if err := godotenv.Load(); err != nil {
 		// Not an error if .env doesn't exist
@@ -48,26 +80,24 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
-	config := &Config{
-		Email:         os.Getenv("ATLASSIAN_EMAIL"),
-		Host:          os.Getenv("ATLASSIAN_HOST"),
-		APIToken:      os.Getenv("ATLASSIAN_API_TOKEN"),
-		WorkspaceID:   os.Getenv("ATLASSIAN_ASSETS_WORKSPACE_ID"),
-		Profile:       os.Getenv("ATLASSIAN_ASSETS_PROFILE"),
-		CacheDir:      os.Getenv("ATLASSIAN_ASSETS_CACHE_DIR"),
-		CacheTTLHours: cacheTTLHours,
-		AllowDelete:   allowDelete,
-	}
+	// Parse audit log rotation; zero keeps the defaults
+	auditMaxSizeMB, _ := strconv.Atoi(os.Getenv("ATLASSIAN_ASSETS_AUDIT_MAX_SIZE_MB"))
+	auditMaxFiles, _ := strconv.Atoi(os.Getenv("ATLASSIAN_ASSETS_AUDIT_MAX_FILES"))
 
-	// Validate required fields
-	if config.Email == "" {
-		return nil, fmt.Errorf("ATLASSIAN_EMAIL is required")
-	}
-	if config.Host == "" {
-		return nil, fmt.Errorf("ATLASSIAN_HOST is required")
-	}
-	if config.APIToken == "" {
-		return nil, fmt.Errorf("ATLASSIAN_API_TOKEN is required")
+	config := &Config{
+		Email:          os.Getenv("ATLASSIAN_EMAIL"),
+		Host:           os.Getenv("ATLASSIAN_HOST"),
+		APIToken:       os.Getenv("ATLASSIAN_API_TOKEN"),
+		WorkspaceID:    os.Getenv("ATLASSIAN_ASSETS_WORKSPACE_ID"),
+		Profile:        os.Getenv("ATLASSIAN_ASSETS_PROFILE"),
+		CacheDir:       os.Getenv("ATLASSIAN_ASSETS_CACHE_DIR"),
+		CacheTTLHours:  cacheTTLHours,
+		AllowDelete:    allowDelete,
+		RecordFile:     os.Getenv("ATLASSIAN_ASSETS_RECORD"),
+		ReplayFile:     os.Getenv("ATLASSIAN_ASSETS_REPLAY"),
+		AuditFile:      os.Getenv("ATLASSIAN_ASSETS_AUDIT_LOG"),
+		AuditMaxSizeMB: auditMaxSizeMB,
+		AuditMaxFiles:  auditMaxFiles,
 	}
 
 	// Set default profile if not specified
@@ -149,6 +179,18 @@ func (c *Config) GetCacheDir() (string, error) {
 	return cacheDir, nil
 }
 
+// GetAuditPath returns the audit log path, or "" when auditing is off. The default
+// log lives in the cache directory.
+func (c *Config) GetAuditPath() string {
+	switch c.AuditFile {
+	case "off":
+		return ""
+	case "":
+		return filepath.Join(c.CacheDir, "audit", "audit.jsonl")
+	}
+	return c.AuditFile
+}
+
 // GetCacheTTL returns the cache TTL as a time.Duration
 func (c *Config) GetCacheTTL() time.Duration {
 	return time.Duration(c.CacheTTLHours) * time.Hour
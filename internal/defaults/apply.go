@@ -0,0 +1,425 @@
+package defaults
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+
+	"github.com/aaronsb/atlassian-assets/internal/client"
+)
+
+// API is the part of the Assets client defaults need to scope and copy values
+type API interface {
+	GetObject(ctx context.Context, objectID string) (*client.Response, error)
+	GetObjectType(ctx context.Context, objectTypeID string) (*client.Response, error)
+	GetSchema(ctx context.Context, schemaID string) (*client.Response, error)
+	SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error)
+}
+
+// Env is what applying defaults needs beyond the properties themselves
+type Env struct {
+	API       API
+	Sequences *SequenceStore      // Required only by templates that use {{seq}}
+	Reserve   bool                // Reserve sequence numbers; previews only peek at them
+	Choices   map[string][]string // Status values and select options by attribute name
+}
+
+// Applied records a default that filled a missing field and where it came from
+type Applied struct {
+	Field  string      `json:"field"`
+	Value  interface{} `json:"value"`
+	Source Source      `json:"source"`
+	Detail string      `json:"detail"`
+	File   string      `json:"file"`
+}
+
+var numericID = regexp.MustCompile(`^\d+$`)
+
+// Empty reports whether the set has no defaults
+func (s *Set) Empty() bool {
+	return s == nil || len(s.Defaults) == 0
+}
+
+// Apply fills the missing fields of properties from the defaults that apply to
+// the object type, in file order, so later templates can use earlier defaults
+func (s *Set) Apply(ctx context.Context, env Env, objectTypeID string, properties map[string]interface{}) ([]Applied, error) {
+	if s.Empty() {
+		return nil, nil
+	}
+
+	t := target{objectTypeID: objectTypeID}
+	if s.scoped() {
+		if err := t.load(ctx, env.API); err != nil {
+			return nil, err
+		}
+	}
+
+	a := &applier{ctx: ctx, env: env, objectTypeID: objectTypeID, references: map[string]*models.ObjectScheme{}}
+
+	var applied []Applied
+	for i := range s.Defaults {
+		d := &s.Defaults[i]
+		if !d.appliesTo(t) {
+			continue
+		}
+		if _, provided := lookup(properties, d.Field); provided {
+			continue
+		}
+
+		value, detail, ok, err := a.value(d, properties)
+		if err != nil {
+			return nil, fmt.Errorf("default for %s: %w", d.Field, err)
+		}
+		if !ok {
+			continue
+		}
+
+		properties[d.Field] = value
+		applied = append(applied, Applied{
+			Field:  d.Field,
+			Value:  value,
+			Source: d.source(),
+			Detail: detail,
+			File:   d.file,
+		})
+	}
+
+	return applied, nil
+}
+
+// applier carries the state of one Apply call
+type applier struct {
+	ctx          context.Context
+	env          Env
+	objectTypeID string
+	references   map[string]*models.ObjectScheme
+}
+
+// value produces a default's value. ok is false when the default cannot apply,
+// such as a template over a field that was not provided.
+func (a *applier) value(d *Default, properties map[string]interface{}) (string, string, bool, error) {
+	switch d.source() {
+	case SourceTemplate:
+		return a.render(d, properties)
+	case SourceReference:
+		return a.copy(d, properties)
+	case SourceFirstOption:
+		options := lookupChoices(a.env.Choices, d.Field)
+		if len(options) == 0 {
+			return "", "", false, nil
+		}
+		return options[0], fmt.Sprintf("first option of %s", d.Field), true, nil
+	default:
+		return matchChoice(lookupChoices(a.env.Choices, d.Field), d.Value), "static value", true, nil
+	}
+}
+
+// render executes a template, reserving a sequence number only if the template
+// renders and uses {{seq}} and the caller is creating the object
+func (a *applier) render(d *Default, properties map[string]interface{}) (string, string, bool, error) {
+	data := templateData(properties, a.objectTypeID)
+	name := d.Sequence
+	if name == "" {
+		name = a.objectTypeID + "." + normalizeKey(d.Field)
+	}
+
+	usesSeq := false
+	number := 0
+	peek := func(width ...int) (string, error) {
+		if a.env.Sequences == nil {
+			return "", fmt.Errorf("no sequence store available for {{seq}}")
+		}
+		usesSeq = true
+		if number == 0 {
+			value, err := a.env.Sequences.Peek(name, d.SequenceStart)
+			if err != nil {
+				return "", err
+			}
+			number = value
+		}
+		return formatSequence(number, width), nil
+	}
+
+	value, err := execute(d.template, data, peek)
+	if err != nil {
+		if strings.Contains(err.Error(), "map has no entry for key") {
+			return "", "", false, nil
+		}
+		return "", "", false, err
+	}
+	if !usesSeq {
+		return value, d.Template, true, nil
+	}
+	if !a.env.Reserve {
+		return value, fmt.Sprintf("%s (sequence %s = %d, not reserved)", d.Template, name, number), true, nil
+	}
+
+	// Reserve the number, then render again in case another process took the peeked one
+	reserved, err := a.env.Sequences.Next(name, d.SequenceStart)
+	if err != nil {
+		return "", "", false, err
+	}
+	value, err = execute(d.template, data, func(width ...int) (string, error) {
+		return formatSequence(reserved, width), nil
+	})
+	if err != nil {
+		return "", "", false, err
+	}
+	return value, fmt.Sprintf("%s (sequence %s = %d)", d.Template, name, reserved), true, nil
+}
+
+// copy takes an attribute value from the object referenced by another field
+func (a *applier) copy(d *Default, properties map[string]interface{}) (string, string, bool, error) {
+	ref, ok := lookup(properties, d.CopyFrom.Field)
+	if !ok {
+		return "", "", false, nil
+	}
+
+	object, err := a.reference(ref)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	value, ok := attributeValue(object, d.CopyFrom.Attribute)
+	if !ok {
+		return "", "", false, nil
+	}
+
+	key := object.ObjectKey
+	if key == "" {
+		key = object.ID
+	}
+	return value, fmt.Sprintf("%s of %s referenced by %s", d.CopyFrom.Attribute, key, d.CopyFrom.Field), true, nil
+}
+
+// reference fetches a referenced object by ID or key, once per Apply call
+func (a *applier) reference(ref string) (*models.ObjectScheme, error) {
+	if object, ok := a.references[ref]; ok {
+		return object, nil
+	}
+	if a.env.API == nil {
+		return nil, fmt.Errorf("no client available to look up %s", ref)
+	}
+
+	var object *models.ObjectScheme
+	if numericID.MatchString(ref) {
+		response, err := a.env.API.GetObject(a.ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get referenced object %s: %w", ref, err)
+		}
+		if !response.Success {
+			return nil, fmt.Errorf("failed to get referenced object %s: %s", ref, response.Error)
+		}
+		object, _ = response.Data.(*models.ObjectScheme)
+	} else {
+		response, err := a.env.API.SearchObjects(a.ctx, fmt.Sprintf(`Key = "%s"`, strings.ReplaceAll(ref, `"`, `\"`)), 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find referenced object %s: %w", ref, err)
+		}
+		if !response.Success {
+			return nil, fmt.Errorf("failed to find referenced object %s: %s", ref, response.Error)
+		}
+		if data, ok := response.Data.(map[string]interface{}); ok {
+			if objects, ok := data["objects"].([]*models.ObjectScheme); ok && len(objects) > 0 {
+				object = objects[0]
+			}
+		}
+	}
+
+	if object == nil {
+		return nil, fmt.Errorf("referenced object %s not found", ref)
+	}
+	a.references[ref] = object
+	return object, nil
+}
+
+// attributeValue reads an attribute of an object by name, falling back to its label and key
+func attributeValue(object *models.ObjectScheme, name string) (string, bool) {
+	want := normalizeKey(name)
+	for _, attr := range object.Attributes {
+		if attr.ObjectTypeAttribute == nil || normalizeKey(attr.ObjectTypeAttribute.Name) != want {
+			continue
+		}
+		for _, value := range attr.ObjectAttributeValues {
+			if value.Value != "" {
+				return value.Value, true
+			}
+			if value.DisplayValue != "" {
+				return value.DisplayValue, true
+			}
+		}
+		return "", false
+	}
+
+	switch want {
+	case "label", "name":
+		return object.Label, object.Label != ""
+	case "key":
+		return object.ObjectKey, object.ObjectKey != ""
+	}
+	return "", false
+}
+
+// execute renders a template with the given {{seq}} implementation
+func execute(tmpl *template.Template, data map[string]interface{}, seq func(width ...int) (string, error)) (string, error) {
+	clone, err := tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	if err := clone.Funcs(templateFuncs(seq)).Execute(&out, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// templateFuncs are the functions available to default templates
+func templateFuncs(seq func(width ...int) (string, error)) template.FuncMap {
+	if seq == nil {
+		seq = func(width ...int) (string, error) { return "", nil }
+	}
+	return template.FuncMap{
+		"upper":   strings.ToUpper,
+		"lower":   strings.ToLower,
+		"trim":    strings.TrimSpace,
+		"replace": func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"slug": func(s string) string {
+			return strings.ToLower(strings.Join(strings.Fields(s), "-"))
+		},
+		"truncate": func(n int, s string) string {
+			if runes := []rune(s); len(runes) > n {
+				return string(runes[:n])
+			}
+			return s
+		},
+		"seq": seq,
+	}
+}
+
+func formatSequence(number int, width []int) string {
+	if len(width) > 0 && width[0] > 0 {
+		return fmt.Sprintf("%0*d", width[0], number)
+	}
+	return fmt.Sprintf("%d", number)
+}
+
+// templateData exposes the provided fields to templates as {{.field_name}}
+func templateData(properties map[string]interface{}, objectTypeID string) map[string]interface{} {
+	data := map[string]interface{}{"object_type_id": objectTypeID}
+	for key, value := range properties {
+		if text, ok := stringValue(value); ok {
+			data[normalizeKey(key)] = text
+		}
+	}
+	return data
+}
+
+// lookup finds a provided, non-empty field, treating "Asset Tag", "asset_tag"
+// and "asset-tag" as the same field
+func lookup(properties map[string]interface{}, field string) (string, bool) {
+	want := normalizeKey(field)
+	for key, value := range properties {
+		if normalizeKey(key) == want {
+			return stringValue(value)
+		}
+	}
+	return "", false
+}
+
+func lookupChoices(choices map[string][]string, field string) []string {
+	want := normalizeKey(field)
+	for key, options := range choices {
+		if normalizeKey(key) == want {
+			return options
+		}
+	}
+	return nil
+}
+
+// matchChoice returns the option spelled as the attribute defines it, if any
+func matchChoice(options []string, value string) string {
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			return option
+		}
+	}
+	return value
+}
+
+func stringValue(value interface{}) (string, bool) {
+	if value == nil {
+		return "", false
+	}
+	text := strings.TrimSpace(fmt.Sprintf("%v", value))
+	return text, text != ""
+}
+
+func normalizeKey(field string) string {
+	field = strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(field))
+	return strings.Join(strings.Fields(field), "_")
+}
+
+// target identifies the object type being completed, for matching scopes
+type target struct {
+	objectTypeID   string
+	objectTypeName string
+	schemaID       string
+	schemaName     string
+}
+
+// scoped reports whether any default is limited to a schema or object type
+func (s *Set) scoped() bool {
+	for _, d := range s.Defaults {
+		if d.Schema != "" || d.ObjectType != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// load fetches the object type and schema names used to match scopes
+func (t *target) load(ctx context.Context, api API) error {
+	if api == nil {
+		return nil
+	}
+
+	response, err := api.GetObjectType(ctx, t.objectTypeID)
+	if err != nil {
+		return fmt.Errorf("failed to get object type for defaults: %w", err)
+	}
+	if !response.Success {
+		return fmt.Errorf("failed to get object type for defaults: %s", response.Error)
+	}
+	if objectType, ok := response.Data.(*models.ObjectTypeScheme); ok {
+		t.objectTypeName = objectType.Name
+		t.schemaID = objectType.ObjectSchemaID
+	}
+
+	if t.schemaID == "" {
+		return nil
+	}
+	response, err = api.GetSchema(ctx, t.schemaID)
+	if err == nil && response.Success {
+		if schema, ok := response.Data.(*models.ObjectSchemaScheme); ok {
+			t.schemaName = schema.Name
+		}
+	}
+	return nil
+}
+
+// appliesTo reports whether the default's scope covers the target
+func (d *Default) appliesTo(t target) bool {
+	if d.ObjectType != "" && d.ObjectType != t.objectTypeID && !strings.EqualFold(d.ObjectType, t.objectTypeName) {
+		return false
+	}
+	if d.Schema != "" && d.Schema != t.schemaID && !strings.EqualFold(d.Schema, t.schemaName) {
+		return false
+	}
+	return true
+}
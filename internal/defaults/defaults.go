@@ -0,0 +1,197 @@
+package defaults
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aaronsb/atlassian-assets/internal/config"
+)
+
+// DefaultDirName is the defaults directory looked up in the config directory
+const DefaultDirName = "defaults"
+
+// Source says how a default value was produced
+type Source string
+
+const (
+	SourceStatic      Source = "static"       // A fixed value from the policy file
+	SourceTemplate    Source = "template"     // Rendered from a template over the object's fields
+	SourceReference   Source = "reference"    // Copied from an attribute of a referenced object
+	SourceFirstOption Source = "first_option" // The first status or select option of the attribute
+)
+
+// Default fills one field when it is missing. Exactly one of Value, Template,
+// CopyFrom and FirstOption must be set.
+type Default struct {
+	Field       string    `yaml:"field"`
+	Value       string    `yaml:"value"`
+	Template    string    `yaml:"template"`
+	CopyFrom    *CopyFrom `yaml:"copy_from"`
+	FirstOption bool      `yaml:"first_option"`
+
+	// Sequence names the counter behind {{seq}}; it defaults to <object type>.<field>
+	Sequence      string `yaml:"sequence"`
+	SequenceStart int    `yaml:"sequence_start"`
+
+	Schema     string `yaml:"schema"`      // Schema ID or name the default is limited to
+	ObjectType string `yaml:"object_type"` // Object type ID or name the default is limited to
+
+	file     string
+	template *template.Template
+}
+
+// CopyFrom copies an attribute of the object referenced by another field
+type CopyFrom struct {
+	Field     string `yaml:"field"`     // Field holding the referenced object's ID or key
+	Attribute string `yaml:"attribute"` // Attribute of the referenced object to copy
+}
+
+// file is the layout of a defaults file. Scope set at the top applies to every
+// default in the file that does not set its own.
+type file struct {
+	Schema     string    `yaml:"schema"`
+	ObjectType string    `yaml:"object_type"`
+	Defaults   []Default `yaml:"defaults"`
+}
+
+// Set is the collection of defaults loaded from one or more files
+type Set struct {
+	Defaults []Default
+	Sources  []string
+}
+
+// Load reads defaults from files or directories of *.yaml and *.yml files
+func Load(paths ...string) (*Set, error) {
+	set := &Set{}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read defaults path: %w", err)
+		}
+
+		files := []string{path}
+		if info.IsDir() {
+			files = nil
+			for _, pattern := range []string{"*.yaml", "*.yml"} {
+				matches, _ := filepath.Glob(filepath.Join(path, pattern))
+				files = append(files, matches...)
+			}
+			sort.Strings(files)
+		}
+
+		for _, name := range files {
+			if err := set.loadFile(name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return set, nil
+}
+
+// LoadDefault loads the defaults named by ATLASSIAN_ASSETS_DEFAULTS, then the
+// defaults directory in the config directory. No defaults are loaded if neither exists.
+func LoadDefault() (*Set, error) {
+	if path := os.Getenv("ATLASSIAN_ASSETS_DEFAULTS"); path != "" {
+		return Load(path)
+	}
+
+	if configDir, err := config.GetConfigDir(); err == nil {
+		path := filepath.Join(configDir, DefaultDirName)
+		if _, err := os.Stat(path); err == nil {
+			return Load(path)
+		}
+	}
+
+	return &Set{}, nil
+}
+
+// loadFile parses and compiles the defaults in one file
+func (s *Set) loadFile(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("failed to read defaults file: %w", err)
+	}
+
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("failed to parse defaults file %s: %w", name, err)
+	}
+
+	for i := range f.Defaults {
+		d := f.Defaults[i]
+		if d.Schema == "" {
+			d.Schema = f.Schema
+		}
+		if d.ObjectType == "" {
+			d.ObjectType = f.ObjectType
+		}
+		d.file = name
+
+		if err := d.compile(); err != nil {
+			return fmt.Errorf("invalid default %d in %s: %w", i+1, name, err)
+		}
+		s.Defaults = append(s.Defaults, d)
+	}
+
+	s.Sources = append(s.Sources, name)
+	return nil
+}
+
+// compile checks a default's settings and parses its template
+func (d *Default) compile() error {
+	if d.Field == "" {
+		return fmt.Errorf("default requires a field")
+	}
+
+	set := 0
+	if d.Value != "" {
+		set++
+	}
+	if d.Template != "" {
+		set++
+	}
+	if d.CopyFrom != nil {
+		set++
+	}
+	if d.FirstOption {
+		set++
+	}
+	if set != 1 {
+		return fmt.Errorf("default for %s must set exactly one of value, template, copy_from or first_option", d.Field)
+	}
+
+	if d.CopyFrom != nil && (d.CopyFrom.Field == "" || d.CopyFrom.Attribute == "") {
+		return fmt.Errorf("copy_from for %s requires field and attribute", d.Field)
+	}
+
+	if d.Template != "" {
+		tmpl, err := template.New(d.Field).Option("missingkey=error").Funcs(templateFuncs(nil)).Parse(d.Template)
+		if err != nil {
+			return fmt.Errorf("invalid template for %s: %w", d.Field, err)
+		}
+		d.template = tmpl
+	}
+
+	return nil
+}
+
+// source reports how the default produces its value
+func (d *Default) source() Source {
+	switch {
+	case d.Template != "":
+		return SourceTemplate
+	case d.CopyFrom != nil:
+		return SourceReference
+	case d.FirstOption:
+		return SourceFirstOption
+	default:
+		return SourceStatic
+	}
+}
@@ -0,0 +1,302 @@
+package defaults
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+
+	"github.com/aaronsb/atlassian-assets/internal/client"
+)
+
+// fakeAPI serves one object type and a set of objects found by ID or key
+type fakeAPI struct {
+	objects map[string]*models.ObjectScheme
+}
+
+func (f *fakeAPI) GetObject(ctx context.Context, objectID string) (*client.Response, error) {
+	for _, object := range f.objects {
+		if object.ID == objectID {
+			return client.NewSuccessResponse(object), nil
+		}
+	}
+	return client.NewErrorResponse(fmt.Errorf("API error: 404")), nil
+}
+
+func (f *fakeAPI) GetObjectType(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	return client.NewSuccessResponse(&models.ObjectTypeScheme{ID: objectTypeID, Name: "Laptops", ObjectSchemaID: "6"}), nil
+}
+
+func (f *fakeAPI) GetSchema(ctx context.Context, schemaID string) (*client.Response, error) {
+	return client.NewSuccessResponse(&models.ObjectSchemaScheme{ID: schemaID, Name: "Hardware"}), nil
+}
+
+func (f *fakeAPI) SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error) {
+	var found []*models.ObjectScheme
+	for key, object := range f.objects {
+		if query == fmt.Sprintf(`Key = "%s"`, key) {
+			found = append(found, object)
+		}
+	}
+	return client.NewSuccessResponse(map[string]interface{}{"objects": found, "total": len(found)}), nil
+}
+
+func writeDefaults(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func loadDefaults(t *testing.T, content string) *Set {
+	t.Helper()
+	set, err := Load(writeDefaults(t, t.TempDir(), "defaults.yaml", content))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	return set
+}
+
+func owner() *models.ObjectScheme {
+	return &models.ObjectScheme{
+		ID:        "42",
+		ObjectKey: "HR-42",
+		Label:     "Ada Lovelace",
+		Attributes: []*models.ObjectAttributeScheme{
+			{
+				ObjectTypeAttribute:   &models.ObjectTypeAttributeScheme{Name: "Location"},
+				ObjectAttributeValues: []*models.ObjectTypeAssetAttributeValueScheme{{Value: "London"}},
+			},
+		},
+	}
+}
+
+func TestApply(t *testing.T) {
+	set := loadDefaults(t, `
+object_type: Laptops
+defaults:
+  - field: asset_status
+    first_option: true
+  - field: device_type
+    value: physical
+  - field: asset_tag
+    template: "{{.name | upper | replace \" \" \"-\"}}-{{seq 3}}"
+    sequence: laptop-tags
+    sequence_start: 7
+  - field: location
+    copy_from:
+      field: owner
+      attribute: Location
+  - field: notes
+    template: "Issued to {{.owner_name}}"
+`)
+
+	env := Env{
+		API:       &fakeAPI{objects: map[string]*models.ObjectScheme{"HR-42": owner()}},
+		Sequences: NewSequenceStore(filepath.Join(t.TempDir(), "sequences.json")),
+		Reserve:   true,
+		Choices: map[string][]string{
+			"Asset Status": {"In Stock", "Deployed"},
+			"Device Type":  {"Physical", "Virtual"},
+		},
+	}
+
+	properties := map[string]interface{}{"name": "dev box", "Owner": "HR-42"}
+	applied, err := set.Apply(context.Background(), env, "23", properties)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	want := map[string]string{
+		"asset_status": "In Stock",
+		"device_type":  "Physical",
+		"asset_tag":    "DEV-BOX-007",
+		"location":     "London",
+	}
+	if len(applied) != len(want) {
+		t.Fatalf("applied %d defaults, want %d: %+v", len(applied), len(want), applied)
+	}
+	for _, a := range applied {
+		if properties[a.Field] != want[a.Field] || a.Value != want[a.Field] {
+			t.Errorf("%s = %v, want %s", a.Field, properties[a.Field], want[a.Field])
+		}
+		if a.File == "" || a.Detail == "" {
+			t.Errorf("%s has no origin: %+v", a.Field, a)
+		}
+	}
+	if applied[2].Source != SourceTemplate || !strings.Contains(applied[2].Detail, "sequence laptop-tags = 7") {
+		t.Errorf("asset_tag origin = %+v", applied[2])
+	}
+	if applied[3].Source != SourceReference || !strings.Contains(applied[3].Detail, "HR-42") {
+		t.Errorf("location origin = %+v", applied[3])
+	}
+
+	// The sequence persists, and provided fields are never overwritten
+	properties = map[string]interface{}{"name": "spare", "device_type": "Virtual"}
+	applied, err = set.Apply(context.Background(), env, "23", properties)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if properties["asset_tag"] != "SPARE-008" || properties["device_type"] != "Virtual" {
+		t.Errorf("second completion = %v", properties)
+	}
+	if len(applied) != 2 {
+		t.Errorf("applied %d defaults, want 2: %+v", len(applied), applied)
+	}
+}
+
+func TestApplyPreviewDoesNotReserve(t *testing.T) {
+	set := loadDefaults(t, `
+defaults:
+  - field: asset_tag
+    template: "{{.name | upper}}-{{seq}}"
+`)
+
+	path := filepath.Join(t.TempDir(), "sequences.json")
+	env := Env{Sequences: NewSequenceStore(path)}
+	for i := 0; i < 2; i++ {
+		properties := map[string]interface{}{"name": "spare"}
+		applied, err := set.Apply(context.Background(), env, "23", properties)
+		if err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+		if properties["asset_tag"] != "SPARE-1" || !strings.Contains(applied[0].Detail, "not reserved") {
+			t.Errorf("preview %d = %v, %+v; want SPARE-1 unreserved", i, properties, applied)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("a preview reserved a sequence number")
+	}
+}
+
+func TestApplyScope(t *testing.T) {
+	set := loadDefaults(t, `
+defaults:
+  - field: a
+    value: "1"
+    object_type: Servers
+  - field: b
+    value: "2"
+    schema: hardware
+  - field: c
+    value: "3"
+    object_type: "23"
+`)
+
+	properties := map[string]interface{}{}
+	if _, err := set.Apply(context.Background(), Env{API: &fakeAPI{}}, "23", properties); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if _, ok := properties["a"]; ok || properties["b"] != "2" || properties["c"] != "3" {
+		t.Errorf("properties = %v, want b and c only", properties)
+	}
+}
+
+func TestApplyTemplateSkipsMissingFields(t *testing.T) {
+	set := loadDefaults(t, `
+defaults:
+  - field: asset_tag
+    template: "{{.name | upper}}-{{seq}}"
+`)
+
+	path := filepath.Join(t.TempDir(), "sequences.json")
+	properties := map[string]interface{}{}
+	applied, err := set.Apply(context.Background(), Env{Sequences: NewSequenceStore(path)}, "23", properties)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("applied %+v, want nothing", applied)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("sequence was reserved for a skipped default")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"missing field", "defaults:\n  - value: x\n", "requires a field"},
+		{"no source", "defaults:\n  - field: x\n", "exactly one of"},
+		{"two sources", "defaults:\n  - field: x\n    value: a\n    first_option: true\n", "exactly one of"},
+		{"bad template", "defaults:\n  - field: x\n    template: \"{{.name\"\n", "invalid template"},
+		{"incomplete copy", "defaults:\n  - field: x\n    copy_from:\n      field: owner\n", "requires field and attribute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeDefaults(t, t.TempDir(), "defaults.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSequenceStore(t *testing.T) {
+	store := NewSequenceStore(filepath.Join(t.TempDir(), "nested", "sequences.json"))
+
+	if value, _ := store.Peek("tags", 100); value != 100 {
+		t.Errorf("Peek = %d, want 100", value)
+	}
+	for _, want := range []int{100, 101} {
+		if value, err := store.Next("tags", 100); err != nil || value != want {
+			t.Errorf("Next = %d, %v, want %d", value, err, want)
+		}
+	}
+	if value, _ := store.Next("other", 0); value != 1 {
+		t.Errorf("Next(other) = %d, want 1", value)
+	}
+
+	reopened := NewSequenceStore(store.path)
+	if value, _ := reopened.Peek("tags", 100); value != 102 {
+		t.Errorf("Peek after reopen = %d, want 102", value)
+	}
+}
+
+func TestSequenceStoreAcrossProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sequences.json")
+
+	// Separate stores share only the file, as separate processes do
+	const stores, reservations = 4, 25
+	values := make(chan int, stores*reservations)
+	var wg sync.WaitGroup
+	for i := 0; i < stores; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store := NewSequenceStore(path)
+			for j := 0; j < reservations; j++ {
+				value, err := store.Next("tags", 1)
+				if err != nil {
+					t.Errorf("Next failed: %v", err)
+					return
+				}
+				values <- value
+			}
+		}()
+	}
+	wg.Wait()
+	close(values)
+
+	seen := map[int]bool{}
+	for value := range values {
+		if seen[value] {
+			t.Errorf("sequence value %d was handed out twice", value)
+		}
+		seen[value] = true
+	}
+	if len(seen) != stores*reservations {
+		t.Errorf("got %d distinct values, want %d", len(seen), stores*reservations)
+	}
+}
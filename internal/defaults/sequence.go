@@ -0,0 +1,125 @@
+package defaults
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/aaronsb/atlassian-assets/internal/config"
+	"github.com/aaronsb/atlassian-assets/internal/filelock"
+)
+
+// DefaultSequencesFileName is the sequence file kept in the config directory
+const DefaultSequencesFileName = "sequences.json"
+
+// SequenceStore persists named counters used by {{seq}} templates. Reservations hold
+// a lock on the file, so concurrent processes never get the same number.
+type SequenceStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewSequenceStore creates a store backed by the given file
+func NewSequenceStore(path string) *SequenceStore {
+	return &SequenceStore{path: path}
+}
+
+// DefaultSequenceStore uses ATLASSIAN_ASSETS_SEQUENCES, then sequences.json in the
+// config directory
+func DefaultSequenceStore() (*SequenceStore, error) {
+	if path := os.Getenv("ATLASSIAN_ASSETS_SEQUENCES"); path != "" {
+		return NewSequenceStore(path), nil
+	}
+
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewSequenceStore(filepath.Join(configDir, DefaultSequencesFileName)), nil
+}
+
+// Peek returns the value Next would return, without reserving it
+func (s *SequenceStore) Peek(name string, start int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counters, err := s.read()
+	if err != nil {
+		return 0, err
+	}
+	return nextValue(counters, name, start), nil
+}
+
+// Next reserves and returns the next value of a counter
+func (s *SequenceStore) Next(name string, start int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := filelock.Lock(s.path)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	counters, err := s.read()
+	if err != nil {
+		return 0, err
+	}
+
+	value := nextValue(counters, name, start)
+	counters[name] = value
+	if err := s.write(counters); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+func nextValue(counters map[string]int, name string, start int) int {
+	last, ok := counters[name]
+	if !ok {
+		if start < 1 {
+			start = 1
+		}
+		return start
+	}
+	return last + 1
+}
+
+// read loads the counters; a missing file means no counter has been used
+func (s *SequenceStore) read() (map[string]int, error) {
+	counters := map[string]int{}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return counters, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sequences: %w", err)
+	}
+	if err := json.Unmarshal(data, &counters); err != nil {
+		return nil, fmt.Errorf("failed to parse sequences file %s: %w", s.path, err)
+	}
+	return counters, nil
+}
+
+// write saves the counters through a temporary file so a crash cannot truncate them
+func (s *SequenceStore) write(counters map[string]int) error {
+	data, err := json.MarshalIndent(counters, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sequences: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create sequences directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write sequences: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write sequences: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package filelock
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLockExcludesOtherHolders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "counters.json")
+
+	unlock, err := Lock(path)
+	if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+
+	// Each Lock opens the file again, so a second holder waits as another process would
+	acquired := make(chan func())
+	go func() {
+		second, err := Lock(path)
+		if err != nil {
+			t.Errorf("second Lock failed: %v", err)
+			close(acquired)
+			return
+		}
+		acquired <- second
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second Lock succeeded while the first was held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case second := <-acquired:
+		if second != nil {
+			second()
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second Lock did not succeed after the first was released")
+	}
+}
@@ -0,0 +1,210 @@
+package hints
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// CommandIssue is a command suggested by the hints that the CLI would not accept
+type CommandIssue struct {
+	Source  string `json:"source"`
+	Command string `json:"command"`
+	Problem string `json:"problem"`
+}
+
+// Backtick spans in hint messages, and template references such as {set_icon_command}
+var (
+	codeSpanPattern    = regexp.MustCompile("`([^`]+)`")
+	templateRefPattern = regexp.MustCompile(`^\{([a-z_]+_command)\}$`)
+)
+
+// ValidateCommands checks every command in the workflow steps, command templates and
+// hint messages against the command tree rooted at root
+func ValidateCommands(root *cobra.Command) []CommandIssue {
+	hints, err := LoadHints()
+	if err != nil {
+		return []CommandIssue{{Source: "workflow_hints.json", Problem: err.Error()}}
+	}
+
+	var issues []CommandIssue
+	for _, workflowID := range sortedKeys(hints.Workflows) {
+		issues = append(issues, ValidateSteps(root, "workflows."+workflowID, hints.Workflows[workflowID].Steps)...)
+	}
+
+	for _, name := range sortedKeys(hints.Templates) {
+		command := hints.Templates[name]
+		if err := CheckCommand(root, command); err != nil {
+			issues = append(issues, CommandIssue{Source: "command_templates." + name, Command: command, Problem: err.Error()})
+		}
+	}
+
+	for _, contextID := range sortedKeys(hints.Contexts) {
+		for i, hint := range hints.Contexts[contextID].Hints {
+			source := fmt.Sprintf("contexts.%s.hints[%d]", contextID, i)
+			for _, match := range codeSpanPattern.FindAllStringSubmatch(hint.Message, -1) {
+				span := match[1]
+				if ref := templateRefPattern.FindStringSubmatch(span); ref != nil {
+					if _, exists := hints.Templates[ref[1]]; !exists {
+						issues = append(issues, CommandIssue{Source: source, Command: span, Problem: "unknown command template " + ref[1]})
+					}
+					continue
+				}
+				if !strings.HasPrefix(span, root.Name()+" ") {
+					continue
+				}
+				if err := CheckCommand(root, span); err != nil {
+					issues = append(issues, CommandIssue{Source: source, Command: span, Problem: err.Error()})
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+// ValidateSteps checks the command of each workflow step
+func ValidateSteps(root *cobra.Command, source string, steps []Step) []CommandIssue {
+	var issues []CommandIssue
+	for _, step := range steps {
+		if step.Command == "" {
+			continue
+		}
+		if err := CheckCommand(root, step.Command); err != nil {
+			issues = append(issues, CommandIssue{Source: source + "." + step.ID, Command: step.Command, Problem: err.Error()})
+		}
+	}
+	return issues
+}
+
+// CheckCommand checks that a command line names a runnable command, that its flags
+// exist and that its required flags are given. Placeholders such as {object_type_id}
+// are accepted as values.
+func CheckCommand(root *cobra.Command, command string) error {
+	args, err := splitCommandLine(command)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 || args[0] != root.Name() {
+		return fmt.Errorf("command must start with %q", root.Name())
+	}
+
+	cmd, rest, err := root.Find(args[1:])
+	if err != nil {
+		// Drop cobra's "Did you mean" suggestions
+		return errors.New(strings.SplitN(err.Error(), "\n", 2)[0])
+	}
+
+	set := make(map[string]bool)
+	var positional []string
+	for i := 0; i < len(rest); i++ {
+		arg := rest[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, hasValue := strings.TrimLeft(arg, "-"), false
+		if eq := strings.Index(name, "="); eq >= 0 {
+			name, hasValue = name[:eq], true
+		}
+
+		flag := cmd.Flag(name)
+		if !strings.HasPrefix(arg, "--") && len(name) == 1 {
+			flag = cmd.Flags().ShorthandLookup(name)
+			if flag == nil {
+				flag = cmd.InheritedFlags().ShorthandLookup(name)
+			}
+		}
+		if flag == nil {
+			return fmt.Errorf("unknown flag %s for %q", arg, cmd.CommandPath())
+		}
+		set[flag.Name] = true
+
+		// Flags without a default for a bare flag take the next argument as their value
+		if !hasValue && flag.NoOptDefVal == "" {
+			if i+1 >= len(rest) {
+				return fmt.Errorf("flag --%s needs a value", flag.Name)
+			}
+			i++
+		}
+	}
+
+	if !cmd.Runnable() {
+		if len(positional) > 0 {
+			return fmt.Errorf("unknown command %q for %q", positional[0], cmd.CommandPath())
+		}
+		return fmt.Errorf("%q is not a runnable command", cmd.CommandPath())
+	}
+	if cmd.Args != nil {
+		if err := cmd.Args(cmd, positional); err != nil {
+			return err
+		}
+	} else if len(positional) > 0 {
+		return fmt.Errorf("unexpected argument %q for %q", positional[0], cmd.CommandPath())
+	}
+
+	var missing []string
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if required := flag.Annotations[cobra.BashCompOneRequiredFlag]; len(required) > 0 && required[0] == "true" && !set[flag.Name] {
+			missing = append(missing, "--"+flag.Name)
+		}
+	})
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required flags for %q: %s", cmd.CommandPath(), strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// splitCommandLine splits a command line into arguments the way a POSIX shell would,
+// honouring single and double quotes
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
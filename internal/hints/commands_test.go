@@ -0,0 +1,95 @@
+package hints
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// newTestTree builds a small command tree shaped like the CLI's
+func newTestTree() *cobra.Command {
+	run := func(cmd *cobra.Command, args []string) {}
+
+	root := &cobra.Command{Use: "assets"}
+	root.PersistentFlags().StringP("output", "o", "json", "")
+
+	get := &cobra.Command{Use: "get", Run: run}
+	get.Flags().String("id", "", "")
+	get.MarkFlagRequired("id")
+
+	trace := &cobra.Command{Use: "trace"}
+	reference := &cobra.Command{Use: "reference", Run: run}
+	reference.Flags().String("attribute-id", "", "")
+	reference.Flags().Bool("all", false, "")
+	trace.AddCommand(reference)
+
+	resolve := &cobra.Command{Use: "resolve", Args: cobra.ExactArgs(1), Run: run}
+
+	root.AddCommand(get, trace, resolve)
+	return root
+}
+
+func TestCheckCommand(t *testing.T) {
+	tests := []struct {
+		command   string
+		wantError string
+	}{
+		{command: "assets get --id {object_id}"},
+		{command: "assets get --id={object_id} -o yaml"},
+		{command: "assets trace reference --attribute-id '{attribute_id}' --all"},
+		{command: `assets trace reference --attribute-id "a b" --all --output table`},
+		{command: "assets resolve Laptops"},
+		{command: "assets enhance set-icon --target x", wantError: `unknown command "enhance" for "assets"`},
+		{command: "assets trace --attribute-id 5", wantError: `unknown flag --attribute-id for "assets trace"`},
+		{command: "assets trace", wantError: `"assets trace" is not a runnable command`},
+		{command: "assets get --id 1 --show-relationships", wantError: "unknown flag --show-relationships"},
+		{command: "assets get", wantError: "missing required flags for \"assets get\": --id"},
+		{command: "assets get --id", wantError: "flag --id needs a value"},
+		{command: "assets get --id 1 extra", wantError: `unexpected argument "extra"`},
+		{command: "assets resolve", wantError: "accepts 1 arg(s)"},
+		{command: "assets get --id '1", wantError: "unterminated ' quote"},
+		{command: "jira get --id 1", wantError: `command must start with "assets"`},
+	}
+
+	root := newTestTree()
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			err := CheckCommand(root, tt.command)
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("error = %v, want error containing %q", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	got, err := splitCommandLine(`assets search --query "Name = 'x y'" --data '{"name":"A"}'  -o json`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"assets", "search", "--query", "Name = 'x y'", "--data", `{"name":"A"}`, "-o", "json"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("split = %q, want %q", got, want)
+	}
+}
+
+func TestValidateSteps(t *testing.T) {
+	steps := []Step{
+		{ID: "get", Command: "assets get --id {object_id}"},
+		{ID: "note"},
+		{ID: "copy", Command: "assets copy object-type --source {object_type}"},
+	}
+
+	issues := ValidateSteps(newTestTree(), "workflows.test", steps)
+	if len(issues) != 1 || issues[0].Source != "workflows.test.copy" || !strings.Contains(issues[0].Problem, `unknown command "copy"`) {
+		t.Errorf("issues = %+v, want only the copy step", issues)
+	}
+}
@@ -65,12 +65,27 @@ type AIHintsLibrary struct {
 type AIWorkflow struct {
 	Description     string    `json:"description"`
 	SemanticContext string    `json:"semantic_context"`
+	Prompt          *AIPrompt `json:"prompt,omitempty"`
 	Steps           []AIStep  `json:"steps"`
 }
 
+// AIPrompt exposes a workflow as an MCP prompt
+type AIPrompt struct {
+	Name      string             `json:"name"`
+	Title     string             `json:"title"`
+	Arguments []AIPromptArgument `json:"arguments"`
+}
+
+type AIPromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
 type AIStep struct {
 	ID               string                 `json:"id"`
 	ToolName         string                 `json:"tool_name"`
+	Requires         []string               `json:"requires,omitempty"` // Prompt arguments the step depends on
 	Parameters       map[string]interface{} `json:"parameters"`
 	SuccessIndicators []string              `json:"success_indicators"`
 	NextActions      []AIAction             `json:"next_actions"`
@@ -288,6 +303,25 @@ func evaluateCondition(condition string, variables map[string]interface{}) bool
 			return children
 		}
 		return false
+	case "has_archive":
+		if archive, ok := variables["archive"].(string); ok {
+			return archive != ""
+		}
+		return false
+	case "dry_run":
+		if dryRun, ok := variables["dry_run"].(bool); ok {
+			return dryRun
+		}
+		return false
+	case "resumable":
+		report, _ := variables["report"].(string)
+		errors, _ := variables["has_errors"].(bool)
+		return report != "" && errors
+	case "has_unattached":
+		if unattached, ok := variables["has_unattached"].(bool); ok {
+			return unattached
+		}
+		return false
 	case "has_empty_types":
 		if emptyTypes, ok := variables["has_empty_types"].(bool); ok {
 			return emptyTypes
@@ -464,6 +498,111 @@ func LoadAIErrorRecovery() (*AIErrorRecoveryLibrary, error) {
 	return globalAIErrorRecovery, nil
 }
 
+// GetWorkflowPrompts returns the workflows that are exposed as prompts, keyed by workflow ID
+func GetWorkflowPrompts() (map[string]AIWorkflow, error) {
+	workflows, err := LoadAIHints()
+	if err != nil {
+		return nil, err
+	}
+
+	prompts := make(map[string]AIWorkflow)
+	for id, workflow := range workflows.AIWorkflows {
+		if workflow.Prompt != nil {
+			prompts[id] = workflow
+		}
+	}
+
+	return prompts, nil
+}
+
+// RenderWorkflowPrompt renders a workflow as step-by-step instructions with the
+// prompt arguments substituted into the tool parameters
+func RenderWorkflowPrompt(workflowID string, arguments map[string]string) (string, error) {
+	workflows, err := LoadAIHints()
+	if err != nil {
+		return "", err
+	}
+
+	workflow, exists := workflows.AIWorkflows[workflowID]
+	if !exists || workflow.Prompt == nil {
+		return "", fmt.Errorf("workflow prompt not found: %s", workflowID)
+	}
+
+	variables := make(map[string]interface{})
+	for _, arg := range workflow.Prompt.Arguments {
+		value := strings.TrimSpace(arguments[arg.Name])
+		if value == "" {
+			if arg.Required {
+				return "", fmt.Errorf("missing required argument: %s", arg.Name)
+			}
+			continue
+		}
+		variables[arg.Name] = value
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Goal: %s.\n", workflow.Description)
+
+	if len(variables) > 0 {
+		b.WriteString("\nInputs:\n")
+		for _, arg := range workflow.Prompt.Arguments {
+			if value, ok := variables[arg.Name]; ok {
+				fmt.Fprintf(&b, "- %s: %s\n", arg.Name, value)
+			}
+		}
+	}
+
+	b.WriteString("\nWork through these steps with the Atlassian Assets tools, checking each result before moving on:\n")
+	stepNumber := 0
+	for _, step := range workflow.Steps {
+		if !hasVariables(step.Requires, variables) {
+			continue
+		}
+		stepNumber++
+
+		parameters, err := json.Marshal(renderStepParameters(step.Parameters, variables, workflow.Prompt))
+		if err != nil {
+			return "", fmt.Errorf("failed to render parameters for step %s: %w", step.ID, err)
+		}
+
+		fmt.Fprintf(&b, "\n%d. %s: call `%s` with %s\n", stepNumber, strings.ReplaceAll(step.ID, "_", " "), step.ToolName, parameters)
+		if len(step.SuccessIndicators) > 0 {
+			fmt.Fprintf(&b, "   Success looks like: %s\n", strings.Join(step.SuccessIndicators, ", "))
+		}
+	}
+
+	b.WriteString("\nValues still in braces come from the results of earlier steps.\n")
+
+	return b.String(), nil
+}
+
+// hasVariables reports whether every named variable has been supplied
+func hasVariables(names []string, variables map[string]interface{}) bool {
+	for _, name := range names {
+		if _, ok := variables[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// renderStepParameters substitutes supplied variables and drops parameters that only
+// reference an optional prompt argument nobody supplied
+func renderStepParameters(parameters map[string]interface{}, variables map[string]interface{}, prompt *AIPrompt) map[string]interface{} {
+	result := substituteParameterVariables(parameters, variables)
+	for _, arg := range prompt.Arguments {
+		if _, supplied := variables[arg.Name]; supplied {
+			continue
+		}
+		for key, value := range parameters {
+			if value == "{"+arg.Name+"}" {
+				delete(result, key)
+			}
+		}
+	}
+	return result
+}
+
 // GetAIGuidance generates AI-specific guidance for a tool operation
 func GetAIGuidance(toolName string, context map[string]interface{}) (*AIGuidance, error) {
 	// Load AI tool semantics for context
@@ -636,6 +775,21 @@ func buildWorkflowContext(toolName string, context map[string]interface{}) Workf
 	case "assets_get":
 		currentState = "object_analyzed"
 		completionPercentage = 50
+	case "assets_catalog_attributes", "assets_summary_schema", "assets_resolve":
+		currentState = "discovery_completed"
+		completionPercentage = 25
+	case "assets_extract_attributes", "assets_trace_reference", "assets_trace_dependencies":
+		currentState = "attributes_analyzed"
+		completionPercentage = 50
+	case "assets_copy_attributes", "assets_apply_attributes":
+		currentState = "attributes_applied"
+		completionPercentage = 80
+		if dryRun, ok := context["dry_run"].(bool); ok && dryRun {
+			currentState = "attributes_planned"
+			completionPercentage = 60
+		}
+	case "assets_remove", "assets_delete_object_type":
+		currentState = "cleanup_completed"
 	}
 
 	return WorkflowContext{
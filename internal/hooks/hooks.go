@@ -0,0 +1,301 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aaronsb/atlassian-assets/internal/config"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+)
+
+// Phase says whether a hook runs before or after the change
+type Phase string
+
+const (
+	PhasePre  Phase = "pre"  // Runs before the change and can veto it
+	PhasePost Phase = "post" // Runs after the change, whether it succeeded or not
+)
+
+// Events that trigger hooks
+const (
+	ObjectCreated  = "object.created"
+	ObjectUpdated  = "object.updated"
+	ObjectDeleted  = "object.deleted"
+	AttributeAdded = "attribute.added"
+)
+
+// DefaultFileName is the hooks file looked up in the config directory
+const DefaultFileName = "hooks.yaml"
+
+// Time a hook may take unless it sets its own timeout
+const defaultTimeout = 10 * time.Second
+
+// Most of a hook's output quoted in a veto or warning
+const maxReasonBytes = 500
+
+// Hook runs a command or posts to a URL when one of its events happens
+type Hook struct {
+	Name    string            `yaml:"name"`
+	Events  []string          `yaml:"events"` // All events when empty
+	Phase   Phase             `yaml:"phase"`
+	Command string            `yaml:"command"` // Run through sh with the event JSON on stdin
+	URL     string            `yaml:"url"`     // Receives the event JSON in a POST
+	Headers map[string]string `yaml:"headers"` // Added to the POST; values may use ${VAR}
+	Timeout time.Duration     `yaml:"timeout"`
+}
+
+// Config is the set of hooks loaded from a hooks file
+type Config struct {
+	Hooks []Hook `yaml:"hooks"`
+
+	// Source is the file the hooks were loaded from, empty when there is none
+	Source string `yaml:"-"`
+}
+
+// Event describes a change to the hooks that run for it
+type Event struct {
+	Event        string      `json:"event"`
+	Phase        Phase       `json:"phase"`
+	Time         string      `json:"time"`
+	Actor        string      `json:"actor,omitempty"`
+	Profile      string      `json:"profile,omitempty"`
+	Workspace    string      `json:"workspace"`
+	Interface    string      `json:"interface"`
+	Command      string      `json:"command,omitempty"`
+	Tool         string      `json:"tool,omitempty"`
+	Session      string      `json:"session,omitempty"`
+	RequestID    string      `json:"request_id,omitempty"`
+	ObjectTypeID string      `json:"object_type_id,omitempty"`
+	ObjectID     string      `json:"object_id,omitempty"`
+	AttributeID  string      `json:"attribute_id,omitempty"` // The created attribute, post hooks only
+	Attributes   interface{} `json:"attributes,omitempty"`   // The payload of the change
+	Success      *bool       `json:"success,omitempty"`      // Post hooks only
+	Error        string      `json:"error,omitempty"`        // Post hooks only
+}
+
+// VetoError is returned when a pre hook refuses a change
+type VetoError struct {
+	Hook   string
+	Event  string
+	Reason string
+}
+
+func (e *VetoError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%s vetoed by hook %s", e.Event, e.Hook)
+	}
+	return fmt.Sprintf("%s vetoed by hook %s: %s", e.Event, e.Hook, e.Reason)
+}
+
+// Load reads a hooks file
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hooks file: %w", err)
+	}
+
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse hooks file %s: %w", path, err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("invalid hooks file %s: %w", path, err)
+	}
+
+	c.Source = path
+	return &c, nil
+}
+
+// LoadDefault loads the hooks named by ATLASSIAN_ASSETS_HOOKS, then hooks.yaml in the
+// config directory. No hooks are loaded if neither exists.
+func LoadDefault() (*Config, error) {
+	if path := os.Getenv("ATLASSIAN_ASSETS_HOOKS"); path != "" {
+		return Load(path)
+	}
+
+	if configDir, err := config.GetConfigDir(); err == nil {
+		path := filepath.Join(configDir, DefaultFileName)
+		if _, err := os.Stat(path); err == nil {
+			return Load(path)
+		}
+	}
+
+	return &Config{}, nil
+}
+
+// validate checks every hook and fills in its defaults
+func (c *Config) validate() error {
+	for i := range c.Hooks {
+		hook := &c.Hooks[i]
+		if hook.Name == "" {
+			hook.Name = fmt.Sprintf("hook-%d", i+1)
+		}
+		if hook.Phase == "" {
+			hook.Phase = PhasePost
+		}
+		if hook.Timeout <= 0 {
+			hook.Timeout = defaultTimeout
+		}
+
+		if hook.Phase != PhasePre && hook.Phase != PhasePost {
+			return fmt.Errorf("hook %s: unknown phase %q (expected pre or post)", hook.Name, hook.Phase)
+		}
+		if (hook.Command == "") == (hook.URL == "") {
+			return fmt.Errorf("hook %s: set either command or url", hook.Name)
+		}
+		for _, event := range hook.Events {
+			switch event {
+			case ObjectCreated, ObjectUpdated, ObjectDeleted, AttributeAdded:
+			default:
+				return fmt.Errorf("hook %s: unknown event %q (expected %s, %s, %s or %s)",
+					hook.Name, event, ObjectCreated, ObjectUpdated, ObjectDeleted, AttributeAdded)
+			}
+		}
+	}
+	return nil
+}
+
+// Before runs the pre hooks of an event in order. The first hook that exits non-zero,
+// answers with a status other than 2xx or cannot be run vetoes the change.
+func (c *Config) Before(ctx context.Context, event *Event) error {
+	event.Phase = PhasePre
+	for _, hook := range c.hooks(event.Event, PhasePre) {
+		if err := hook.run(ctx, event); err != nil {
+			return &VetoError{Hook: hook.Name, Event: event.Event, Reason: err.Error()}
+		}
+	}
+	return nil
+}
+
+// After runs the post hooks of an event with the outcome of the change. A failing hook
+// is a warning; the change has already been made.
+func (c *Config) After(ctx context.Context, event *Event, changeErr error) {
+	event.Phase = PhasePost
+	success := changeErr == nil
+	event.Success = &success
+	if changeErr != nil {
+		event.Error = changeErr.Error()
+	}
+
+	for _, hook := range c.hooks(event.Event, PhasePost) {
+		if err := hook.run(ctx, event); err != nil {
+			logger.Warning("Post hook %s failed for %s: %v", hook.Name, event.Event, err)
+		}
+	}
+}
+
+// hooks returns the hooks of a phase that run for an event
+func (c *Config) hooks(event string, phase Phase) []*Hook {
+	if c == nil {
+		return nil
+	}
+
+	var matched []*Hook
+	for i := range c.Hooks {
+		hook := &c.Hooks[i]
+		if hook.Phase == phase && (len(hook.Events) == 0 || contains(hook.Events, event)) {
+			matched = append(matched, hook)
+		}
+	}
+	return matched
+}
+
+// run delivers the event to the hook, returning why the hook failed
+func (h *Hook) run(ctx context.Context, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+	defer cancel()
+
+	logger.Debug("Running %s hook %s for %s", event.Phase, h.Name, event.Event)
+	if h.Command != "" {
+		return h.runCommand(ctx, event, payload)
+	}
+	return h.post(ctx, payload)
+}
+
+func (h *Hook) runCommand(ctx context.Context, event *Event, payload []byte) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", h.Command)
+	cmd.Stdin = bytes.NewReader(append(payload, '\n'))
+	cmd.Env = append(os.Environ(),
+		"ASSETS_HOOK_EVENT="+event.Event,
+		"ASSETS_HOOK_PHASE="+string(event.Phase),
+		"ASSETS_OBJECT_ID="+event.ObjectID,
+		"ASSETS_OBJECT_TYPE_ID="+event.ObjectTypeID,
+	)
+
+	// The hook's output becomes the reason for a veto
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return fmt.Errorf("timed out after %s", h.Timeout)
+	case err != nil:
+		if reason := truncate(output.String()); reason != "" {
+			return fmt.Errorf("%s", reason)
+		}
+		return err
+	}
+	return nil
+}
+
+func (h *Hook) post(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range h.Headers {
+		req.Header.Set(name, os.ExpandEnv(value))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxReasonBytes+1))
+		if reason := truncate(string(body)); reason != "" {
+			return fmt.Errorf("%s (HTTP %d)", reason, resp.StatusCode)
+		}
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// truncate trims a hook's output to the start of a readable reason
+func truncate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > maxReasonBytes {
+		s = s[:maxReasonBytes] + "..."
+	}
+	return s
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeHooks(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultFileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	c, err := Load(writeHooks(t, `
+hooks:
+  - events: [object.created]
+    phase: pre
+    command: ./check-name.sh
+  - url: https://hooks.example.com/assets
+    timeout: 3s
+`))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(c.Hooks) != 2 || c.Hooks[0].Name != "hook-1" || c.Hooks[1].Phase != PhasePost ||
+		c.Hooks[0].Timeout != defaultTimeout || c.Hooks[1].Timeout.Seconds() != 3 {
+		t.Errorf("hooks = %+v", c.Hooks)
+	}
+	if pre := c.hooks(ObjectCreated, PhasePre); len(pre) != 1 {
+		t.Errorf("pre hooks for %s = %d, want 1", ObjectCreated, len(pre))
+	}
+	if pre := c.hooks(ObjectDeleted, PhasePre); len(pre) != 0 {
+		t.Errorf("pre hooks for %s = %d, want 0", ObjectDeleted, len(pre))
+	}
+
+	tests := []struct {
+		content   string
+		wantError string
+	}{
+		{content: "hooks:\n  - phase: during\n    command: x\n", wantError: "unknown phase"},
+		{content: "hooks:\n  - name: both\n    command: x\n    url: https://x\n", wantError: "hook both: set either command or url"},
+		{content: "hooks:\n  - events: [object.renamed]\n    command: x\n", wantError: "unknown event"},
+	}
+	for _, tt := range tests {
+		if _, err := Load(writeHooks(t, tt.content)); err == nil || !strings.Contains(err.Error(), tt.wantError) {
+			t.Errorf("Load(%q) error = %v, want %q", tt.content, err, tt.wantError)
+		}
+	}
+}
+
+func TestBeforeVetoes(t *testing.T) {
+	c := &Config{Hooks: []Hook{
+		{Name: "allow", Phase: PhasePre, Command: `test "$ASSETS_HOOK_EVENT" = object.created`},
+		{Name: "naming", Phase: PhasePre, Command: `grep -q '"Name":"srv-' || { echo "names must start with srv-"; exit 1; }`},
+	}}
+	if err := c.validate(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := c.Before(ctx, &Event{Event: ObjectCreated, Attributes: map[string]string{"Name": "srv-01"}}); err != nil {
+		t.Errorf("Before(srv-01) = %v, want no veto", err)
+	}
+
+	err := c.Before(ctx, &Event{Event: ObjectCreated, Attributes: map[string]string{"Name": "web-01"}})
+	var veto *VetoError
+	if !errors.As(err, &veto) || veto.Hook != "naming" || veto.Reason != "names must start with srv-" {
+		t.Errorf("Before(web-01) = %v, want a veto by naming", err)
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	var received []*Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		json.NewDecoder(r.Body).Decode(&event)
+		received = append(received, &event)
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+		if event.Phase == PhasePre && event.ObjectID == "13" {
+			http.Error(w, "object 13 is frozen", http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("HOOK_TOKEN", "s3cret")
+
+	c := &Config{Hooks: []Hook{
+		{Name: "freeze", Events: []string{ObjectDeleted}, Phase: PhasePre, URL: server.URL, Headers: map[string]string{"Authorization": "Bearer ${HOOK_TOKEN}"}},
+		{Name: "mirror", Phase: PhasePost, URL: server.URL, Headers: map[string]string{"Authorization": "Bearer ${HOOK_TOKEN}"}},
+	}}
+	if err := c.validate(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	err := c.Before(ctx, &Event{Event: ObjectDeleted, ObjectID: "13"})
+	if err == nil || !strings.Contains(err.Error(), "object.deleted vetoed by hook freeze: object 13 is frozen (HTTP 409)") {
+		t.Errorf("Before = %v, want a veto", err)
+	}
+
+	c.After(ctx, &Event{Event: ObjectDeleted, ObjectID: "14"}, errors.New("API error: 404"))
+	last := received[len(received)-1]
+	if len(received) != 2 || last.Phase != PhasePost || last.Success == nil || *last.Success || last.Error != "API error: 404" {
+		t.Errorf("post event = %+v", last)
+	}
+}
+
+func TestNoHooks(t *testing.T) {
+	var c *Config
+	if err := c.Before(context.Background(), &Event{Event: ObjectCreated}); err != nil {
+		t.Errorf("Before without hooks = %v", err)
+	}
+	c.After(context.Background(), &Event{Event: ObjectCreated}, nil)
+}
@@ -1,10 +1,18 @@
 package logger
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
 )
 
 // LogLevel represents the logging level
@@ -36,18 +44,61 @@ func (l LogLevel) String() string {
 	}
 }
 
+// Format is the encoding of log lines
+type Format int
+
+const (
+	// FormatText writes "[LEVEL] message" lines
+	FormatText Format = iota
+	// FormatJSON writes one JSON object per line with timestamp, level, command,
+	// request ID and any request fields
+	FormatJSON
+)
+
+// ParseFormat returns the format with the given name
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	}
+	return FormatText, fmt.Errorf("unsupported log format: %s (use text or json)", name)
+}
+
+// Fields are extra values attached to a log entry
+type Fields map[string]interface{}
+
 // Logger provides structured logging to stderr
 type Logger struct {
-	level LogLevel
+	level  LogLevel
+	format Format
+	out    io.Writer
+
+	mu        sync.Mutex
+	command   string   // Command being run by the process, unless the context names one
+	requestID string   // Correlation ID of the process, unless the context carries one
+	secrets   []string // Values redacted wherever they appear
 }
 
+// contextKey keys the request values a context carries for the logger
+type contextKey int
+
+const (
+	commandKey contextKey = iota
+	requestIDKey
+)
+
 // Global logger instance
 var globalLogger *Logger
 
 // init initializes the global logger based on environment variables
 func init() {
-	level := getLogLevelFromEnv()
-	globalLogger = &Logger{level: level}
+	format, err := ParseFormat(os.Getenv("ATLASSIAN_ASSETS_LOG_FORMAT"))
+	globalLogger = &Logger{level: getLogLevelFromEnv(), format: format, out: os.Stderr}
+	if err != nil {
+		globalLogger.Warning("%v", err)
+	}
 }
 
 // getLogLevelFromEnv determines log level from environment variables
@@ -89,6 +140,91 @@ func GetLevel() LogLevel {
 	return globalLogger.level
 }
 
+// SetFormat sets the encoding of log lines by name (text or json)
+func SetFormat(name string) error {
+	format, err := ParseFormat(name)
+	if err != nil {
+		return err
+	}
+	globalLogger.format = format
+	return nil
+}
+
+// SetCommand records the command the whole process runs, as the CLI does. Servers that
+// handle requests concurrently put each request's command in its context instead.
+func SetCommand(command string) {
+	globalLogger.mu.Lock()
+	defer globalLogger.mu.Unlock()
+	globalLogger.command = command
+}
+
+// SetRequestID sets the correlation ID of the whole process, as the CLI does
+func SetRequestID(id string) {
+	globalLogger.mu.Lock()
+	defer globalLogger.mu.Unlock()
+	globalLogger.requestID = id
+}
+
+// WithCommand returns a context naming the command or MCP tool a request runs
+func WithCommand(ctx context.Context, command string) context.Context {
+	return context.WithValue(ctx, commandKey, command)
+}
+
+// WithRequestID returns a context carrying the correlation ID of a request
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// CommandFrom returns the command or MCP tool of a request, or the process's command
+// when the context names none
+func CommandFrom(ctx context.Context) string {
+	if command := contextValue(ctx, commandKey); command != "" {
+		return command
+	}
+	globalLogger.mu.Lock()
+	defer globalLogger.mu.Unlock()
+	return globalLogger.command
+}
+
+// RequestIDFrom returns the correlation ID of a request, or the process's ID when the
+// context carries none
+func RequestIDFrom(ctx context.Context) string {
+	if id := contextValue(ctx, requestIDKey); id != "" {
+		return id
+	}
+	globalLogger.mu.Lock()
+	defer globalLogger.mu.Unlock()
+	return globalLogger.requestID
+}
+
+func contextValue(ctx context.Context, key contextKey) string {
+	if ctx == nil {
+		return ""
+	}
+	value, _ := ctx.Value(key).(string)
+	return value
+}
+
+// NewRequestID returns a random correlation ID
+func NewRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// RegisterSecret makes the logger redact a value, such as the API token, wherever
+// it appears
+func RegisterSecret(secret string) {
+	if len(secret) < 4 {
+		return
+	}
+	globalLogger.mu.Lock()
+	defer globalLogger.mu.Unlock()
+	globalLogger.secrets = append(globalLogger.secrets, secret)
+}
+
 // shouldLog checks if a message should be logged based on current level
 func (l *Logger) shouldLog(level LogLevel) bool {
 	return l.level <= level
@@ -99,12 +235,106 @@ func (l *Logger) logf(level LogLevel, format string, args ...interface{}) {
 	if !l.shouldLog(level) {
 		return
 	}
+	l.write(context.Background(), level, fmt.Sprintf(format, args...), nil)
+}
 
-	prefix := fmt.Sprintf("[%s] ", level.String())
-	message := fmt.Sprintf(format, args...)
-	
-	// Always log to stderr to avoid contaminating stdout
-	fmt.Fprintf(os.Stderr, "%s%s\n", prefix, message)
+// write redacts and encodes an entry, with the command and request ID of the context.
+// Always log to stderr to avoid contaminating stdout.
+func (l *Logger) write(ctx context.Context, level LogLevel, message string, fields Fields) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	message = l.redact(message)
+	if l.format == FormatText {
+		fmt.Fprintf(l.out, "[%s] %s\n", level.String(), message)
+		return
+	}
+
+	entry := map[string]interface{}{
+		"timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+		"level":     level.String(),
+		"message":   message,
+	}
+	command, requestID := contextValue(ctx, commandKey), contextValue(ctx, requestIDKey)
+	if command == "" {
+		command = l.command
+	}
+	if requestID == "" {
+		requestID = l.requestID
+	}
+	if command != "" {
+		entry["command"] = command
+	}
+	if requestID != "" {
+		entry["request_id"] = requestID
+	}
+	for key, value := range fields {
+		if s, ok := value.(string); ok {
+			value = l.redact(s)
+		}
+		entry[key] = value
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		fmt.Fprintf(l.out, "[%s] %s\n", level.String(), message)
+		return
+	}
+	fmt.Fprintf(l.out, "%s\n", line)
+}
+
+// Credentials that are redacted even when they were never registered
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(authorization"?\s*[:=]\s*"?(?:basic|bearer)\s+)[A-Za-z0-9+/=._~-]+`),
+	regexp.MustCompile(`(?i)((?:api[_-]?token|access[_-]?token|password|secret)"?\s*[:=]\s*"?)[^\s"&,]+`),
+}
+
+// redact hides registered secrets and anything shaped like a credential; callers hold mu
+func (l *Logger) redact(s string) string {
+	for _, secret := range l.secrets {
+		s = strings.ReplaceAll(s, secret, "[REDACTED]")
+	}
+	for _, pattern := range secretPatterns {
+		s = pattern.ReplaceAllString(s, "${1}[REDACTED]")
+	}
+	return s
+}
+
+// Log writes a message with fields; the text format leaves the fields out
+func (l *Logger) Log(level LogLevel, message string, fields Fields) {
+	l.LogContext(context.Background(), level, message, fields)
+}
+
+// LogContext writes a message with fields, attributed to the request of the context
+func (l *Logger) LogContext(ctx context.Context, level LogLevel, message string, fields Fields) {
+	if !l.shouldLog(level) {
+		return
+	}
+	l.write(ctx, level, message, fields)
+}
+
+// Request logs an API call of the request in ctx with its endpoint, status and
+// duration. Failed calls are warnings; the rest are debug entries.
+func (l *Logger) Request(ctx context.Context, method, endpoint string, status int, duration time.Duration, err error) {
+	level := DEBUG
+	outcome := fmt.Sprintf("%d", status)
+	if err != nil {
+		level, outcome = WARNING, err.Error()
+	} else if status >= 400 {
+		level = WARNING
+	}
+
+	fields := Fields{
+		"method":      method,
+		"endpoint":    endpoint,
+		"duration_ms": duration.Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	} else {
+		fields["status"] = status
+	}
+	l.LogContext(ctx, level, fmt.Sprintf("%s %s -> %s (%dms)", method, endpoint, outcome, duration.Milliseconds()), fields)
 }
 
 // Debug logs a debug message
@@ -154,6 +384,25 @@ func Fatal(format string, args ...interface{}) {
 	globalLogger.Fatal(format, args...)
 }
 
+func Log(level LogLevel, message string, fields Fields) {
+	globalLogger.Log(level, message, fields)
+}
+
+func LogContext(ctx context.Context, level LogLevel, message string, fields Fields) {
+	globalLogger.LogContext(ctx, level, message, fields)
+}
+
+func Request(ctx context.Context, method, endpoint string, status int, duration time.Duration, err error) {
+	globalLogger.Request(ctx, method, endpoint, status, duration, err)
+}
+
+// Redact hides registered secrets and credentials in s
+func Redact(s string) string {
+	globalLogger.mu.Lock()
+	defer globalLogger.mu.Unlock()
+	return globalLogger.redact(s)
+}
+
 // IsDebugEnabled returns true if debug logging is enabled
 func IsDebugEnabled() bool {
 	return globalLogger.level <= DEBUG
@@ -166,8 +415,21 @@ func IsInfoEnabled() bool {
 
 // SetupStandardLogger configures the standard Go logger to use stderr
 func SetupStandardLogger() {
-	// Redirect standard log package to stderr with our prefix
-	log.SetOutput(os.Stderr)
-	log.SetPrefix("[ERROR] ")
-	log.SetFlags(log.LstdFlags)
+	// Redirect standard log package through the logger as errors
+	log.SetOutput(standardWriter{})
+	log.SetPrefix("")
+	log.SetFlags(0)
+}
+
+// standardWriter writes lines from the standard log package as error entries, dated
+// in the text format as the standard logger would
+type standardWriter struct{}
+
+func (standardWriter) Write(p []byte) (int, error) {
+	message := strings.TrimRight(string(p), "\n")
+	if globalLogger.format == FormatText {
+		message = time.Now().Format("2006/01/02 15:04:05 ") + message
+	}
+	globalLogger.write(context.Background(), ERROR, message, nil)
+	return len(p), nil
 }
\ No newline at end of file
@@ -0,0 +1,135 @@
+package policy
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Arguments that are not part of what a confirmation token approves
+var confirmationIgnoredArgs = map[string]bool{
+	"confirmation_token": true,
+	"confirm":            true,
+}
+
+var (
+	ErrTokenUnknown  = errors.New("confirmation token is unknown or has already been used")
+	ErrTokenExpired  = errors.New("confirmation token has expired")
+	ErrTokenMismatch = errors.New("confirmation token was issued for a different call")
+)
+
+// pendingConfirmation is an issued token waiting to be redeemed
+type pendingConfirmation struct {
+	tool        string
+	fingerprint string
+	state       string // What the preview showed, handed back on redemption
+	expiresAt   time.Time
+}
+
+// ConfirmationStore issues short-lived, single-use tokens that approve one
+// specific destructive call
+type ConfirmationStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	pending map[string]pendingConfirmation
+	now     func() time.Time
+}
+
+// NewConfirmationStore creates a token store whose tokens live for ttl
+func NewConfirmationStore(ttl time.Duration) *ConfirmationStore {
+	return &ConfirmationStore{
+		ttl:     ttl,
+		pending: make(map[string]pendingConfirmation),
+		now:     time.Now,
+	}
+}
+
+// Issue creates a token approving the given tool call. The state records what the
+// preview showed, so the confirmed call can check nothing has changed since.
+func (s *ConfirmationStore) Issue(tool string, args map[string]interface{}, state string) (string, time.Time, error) {
+	fingerprint, err := fingerprintArgs(args)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	raw := make([]byte, 8)
+	if _, err := rand.Read(raw); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire()
+	expiresAt := s.now().Add(s.ttl)
+	s.pending[token] = pendingConfirmation{
+		tool:        tool,
+		fingerprint: fingerprint,
+		state:       state,
+		expiresAt:   expiresAt,
+	}
+
+	return token, expiresAt, nil
+}
+
+// Redeem consumes a token, succeeding only if it was issued for the same tool
+// and arguments and has not expired. It returns the state the token was issued with.
+func (s *ConfirmationStore) Redeem(token, tool string, args map[string]interface{}) (string, error) {
+	fingerprint, err := fingerprintArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, ok := s.pending[token]
+	if !ok {
+		return "", ErrTokenUnknown
+	}
+	delete(s.pending, token)
+
+	if s.now().After(pending.expiresAt) {
+		return "", ErrTokenExpired
+	}
+	if pending.tool != tool || pending.fingerprint != fingerprint {
+		return "", ErrTokenMismatch
+	}
+
+	return pending.state, nil
+}
+
+// expire drops tokens past their expiry; callers must hold the lock
+func (s *ConfirmationStore) expire() {
+	now := s.now()
+	for token, pending := range s.pending {
+		if now.After(pending.expiresAt) {
+			delete(s.pending, token)
+		}
+	}
+}
+
+// fingerprintArgs hashes the arguments a token approves
+func fingerprintArgs(args map[string]interface{}) (string, error) {
+	approved := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if !confirmationIgnoredArgs[key] {
+			approved[key] = value
+		}
+	}
+
+	// encoding/json sorts map keys, so equal arguments hash equally
+	data, err := json.Marshal(approved)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint arguments: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
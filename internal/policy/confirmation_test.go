@@ -0,0 +1,56 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfirmationStore(t *testing.T) {
+	args := map[string]interface{}{"id": "65"}
+
+	tests := []struct {
+		name    string
+		tool    string
+		args    map[string]interface{}
+		advance time.Duration
+		want    error
+	}{
+		{"redeems a matching call", "assets_delete", map[string]interface{}{"id": "65", "confirmation_token": "ignored"}, 0, nil},
+		{"ignores the confirm flag", "assets_delete", map[string]interface{}{"id": "65", "confirm": true}, 0, nil},
+		{"rejects other arguments", "assets_delete", map[string]interface{}{"id": "66"}, 0, ErrTokenMismatch},
+		{"rejects another tool", "assets_delete_object_type", args, 0, ErrTokenMismatch},
+		{"rejects an expired token", "assets_delete", args, 2 * time.Minute, ErrTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			store := NewConfirmationStore(time.Minute)
+			store.now = func() time.Time { return now }
+
+			token, _, err := store.Issue("assets_delete", args, "state-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			now = now.Add(tt.advance)
+			state, err := store.Redeem(token, tt.tool, tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Redeem() = %v, want %v", err, tt.want)
+			}
+			wantState := ""
+			if tt.want == nil {
+				wantState = "state-1"
+			}
+			if state != wantState {
+				t.Errorf("Redeem() state = %q, want %q", state, wantState)
+			}
+
+			// Tokens are single use whatever the outcome
+			if _, err := store.Redeem(token, "assets_delete", args); !errors.Is(err, ErrTokenUnknown) {
+				t.Errorf("second Redeem() = %v, want %v", err, ErrTokenUnknown)
+			}
+		})
+	}
+}
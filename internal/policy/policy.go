@@ -0,0 +1,197 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aaronsb/atlassian-assets/internal/config"
+)
+
+// Access is the level of access granted to a schema
+type Access string
+
+const (
+	AccessNone  Access = "none"
+	AccessRead  Access = "read"
+	AccessWrite Access = "write"
+)
+
+// ToolKind classifies a tool by the damage it can do
+type ToolKind string
+
+const (
+	ToolRead        ToolKind = "read"
+	ToolWrite       ToolKind = "write"
+	ToolDestructive ToolKind = "destructive"
+)
+
+// DefaultFileName is the policy file looked up in the config directory
+const DefaultFileName = "mcp-policy.yaml"
+
+// Policy controls which tools the MCP server exposes and what they may touch
+type Policy struct {
+	ReadOnly            bool               `yaml:"read_only"`
+	AllowTools          []string           `yaml:"allow_tools"`
+	DenyTools           []string           `yaml:"deny_tools"`
+	DefaultSchemaAccess Access             `yaml:"default_schema_access"`
+	Schemas             map[string]Access  `yaml:"schemas"` // Schema ID or name -> access
+	Confirmation        ConfirmationPolicy `yaml:"confirmation"`
+
+	// Source is the file the policy was loaded from, empty for the built-in default
+	Source string `yaml:"-"`
+}
+
+// ConfirmationPolicy controls the two-step confirmation of destructive tools
+type ConfirmationPolicy struct {
+	Disabled bool          `yaml:"disabled"`
+	TTL      time.Duration `yaml:"ttl"`
+}
+
+// Default returns the policy used when no policy file exists: every tool is
+// available, every schema is writable and destructive tools need a token
+func Default() *Policy {
+	p := &Policy{}
+	p.applyDefaults()
+	return p
+}
+
+// Load reads a policy file
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+
+	p.applyDefaults()
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+
+	p.Source = path
+	return &p, nil
+}
+
+// LoadDefault loads the policy named by ATLASSIAN_ASSETS_MCP_POLICY, then
+// mcp-policy.yaml in the config directory, falling back to Default
+func LoadDefault() (*Policy, error) {
+	if path := os.Getenv("ATLASSIAN_ASSETS_MCP_POLICY"); path != "" {
+		return Load(path)
+	}
+
+	if configDir, err := config.GetConfigDir(); err == nil {
+		path := filepath.Join(configDir, DefaultFileName)
+		if _, err := os.Stat(path); err == nil {
+			return Load(path)
+		}
+	}
+
+	return Default(), nil
+}
+
+// applyDefaults fills in unset fields
+func (p *Policy) applyDefaults() {
+	if p.DefaultSchemaAccess == "" {
+		p.DefaultSchemaAccess = AccessWrite
+	}
+	if p.Confirmation.TTL <= 0 {
+		p.Confirmation.TTL = 2 * time.Minute
+	}
+}
+
+// Validate checks that every access level is known
+func (p *Policy) Validate() error {
+	if !p.DefaultSchemaAccess.valid() {
+		return fmt.Errorf("unknown default_schema_access %q (expected none, read or write)", p.DefaultSchemaAccess)
+	}
+	for schema, access := range p.Schemas {
+		if !access.valid() {
+			return fmt.Errorf("unknown access %q for schema %s (expected none, read or write)", access, schema)
+		}
+	}
+	return nil
+}
+
+// ToolAllowed reports whether a tool of the given kind may be exposed
+func (p *Policy) ToolAllowed(name string, kind ToolKind) bool {
+	if p.ReadOnly && kind != ToolRead {
+		return false
+	}
+	if containsTool(p.DenyTools, name) {
+		return false
+	}
+	if len(p.AllowTools) > 0 && !containsTool(p.AllowTools, name) {
+		return false
+	}
+	return true
+}
+
+// RequiresConfirmation reports whether a tool of the given kind needs a confirmation token
+func (p *Policy) RequiresConfirmation(kind ToolKind) bool {
+	return kind == ToolDestructive && !p.Confirmation.Disabled
+}
+
+// HasSchemaRules reports whether any schema is restricted below write access
+func (p *Policy) HasSchemaRules() bool {
+	if p.DefaultSchemaAccess != AccessWrite {
+		return true
+	}
+	for _, access := range p.Schemas {
+		if access != AccessWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// SchemaAccess returns the access granted to a schema, matched by ID or by
+// case-insensitive name
+func (p *Policy) SchemaAccess(schemaID, schemaName string) Access {
+	if access, ok := p.Schemas[schemaID]; ok && schemaID != "" {
+		return access
+	}
+	for key, access := range p.Schemas {
+		if schemaName != "" && strings.EqualFold(key, schemaName) {
+			return access
+		}
+	}
+	return p.DefaultSchemaAccess
+}
+
+// Allows reports whether this access level covers the required one
+func (a Access) Allows(required Access) bool {
+	return a.rank() >= required.rank()
+}
+
+func (a Access) valid() bool {
+	return a == AccessNone || a == AccessRead || a == AccessWrite
+}
+
+func (a Access) rank() int {
+	switch a {
+	case AccessWrite:
+		return 2
+	case AccessRead:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func containsTool(tools []string, name string) bool {
+	for _, tool := range tools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToolAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		tool   string
+		kind   ToolKind
+		want   bool
+	}{
+		{"default allows writes", Policy{}, "assets_create_object", ToolWrite, true},
+		{"read-only allows reads", Policy{ReadOnly: true}, "assets_search", ToolRead, true},
+		{"read-only blocks writes", Policy{ReadOnly: true}, "assets_create_object", ToolWrite, false},
+		{"read-only blocks destructive tools", Policy{ReadOnly: true}, "assets_delete", ToolDestructive, false},
+		{"deny list blocks a tool", Policy{DenyTools: []string{"assets_delete"}}, "assets_delete", ToolDestructive, false},
+		{"allow list admits listed tools", Policy{AllowTools: []string{"assets_get"}}, "assets_get", ToolRead, true},
+		{"allow list excludes other tools", Policy{AllowTools: []string{"assets_get"}}, "assets_search", ToolRead, false},
+		{"deny wins over allow", Policy{AllowTools: []string{"assets_get"}, DenyTools: []string{"assets_get"}}, "assets_get", ToolRead, false},
+		{"read-only wins over allow", Policy{ReadOnly: true, AllowTools: []string{"assets_delete"}}, "assets_delete", ToolDestructive, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.ToolAllowed(tt.tool, tt.kind); got != tt.want {
+				t.Errorf("ToolAllowed(%s) = %v, want %v", tt.tool, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaAccess(t *testing.T) {
+	p := &Policy{
+		DefaultSchemaAccess: AccessRead,
+		Schemas: map[string]Access{
+			"6":       AccessWrite,
+			"Finance": AccessNone,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		schemaID   string
+		schemaName string
+		want       Access
+	}{
+		{"matches by ID", "6", "Hardware", AccessWrite},
+		{"matches by name ignoring case", "9", "finance", AccessNone},
+		{"falls back to the default", "7", "Facilities", AccessRead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.SchemaAccess(tt.schemaID, tt.schemaName); got != tt.want {
+				t.Errorf("SchemaAccess(%s, %s) = %s, want %s", tt.schemaID, tt.schemaName, got, tt.want)
+			}
+		})
+	}
+
+	if !AccessWrite.Allows(AccessRead) || AccessRead.Allows(AccessWrite) || AccessNone.Allows(AccessRead) {
+		t.Error("access levels are not ordered none < read < write")
+	}
+	if !p.HasSchemaRules() || Default().HasSchemaRules() {
+		t.Error("HasSchemaRules should only report restricted policies")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantError string
+		check     func(t *testing.T, p *Policy)
+	}{
+		{
+			name: "parses a full policy",
+			content: `read_only: true
+deny_tools: [assets_delete_object_type]
+default_schema_access: read
+schemas:
+  Hardware: write
+confirmation:
+  ttl: 30s
+`,
+			check: func(t *testing.T, p *Policy) {
+				if !p.ReadOnly || len(p.DenyTools) != 1 || p.Schemas["Hardware"] != AccessWrite {
+					t.Errorf("unexpected policy %+v", p)
+				}
+				if p.Confirmation.TTL != 30*time.Second {
+					t.Errorf("TTL = %s, want 30s", p.Confirmation.TTL)
+				}
+			},
+		},
+		{
+			name:    "applies defaults",
+			content: "deny_tools: []\n",
+			check: func(t *testing.T, p *Policy) {
+				if p.DefaultSchemaAccess != AccessWrite || p.Confirmation.TTL != 2*time.Minute || p.Confirmation.Disabled {
+					t.Errorf("defaults not applied: %+v", p)
+				}
+			},
+		},
+		{
+			name:      "rejects unknown access levels",
+			content:   "schemas:\n  Hardware: admin\n",
+			wantError: "unknown access",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), DefaultFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := Load(path)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Source != path {
+				t.Errorf("Source = %q, want %q", p.Source, path)
+			}
+			tt.check(t, p)
+		})
+	}
+}
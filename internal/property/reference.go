@@ -0,0 +1,121 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+)
+
+// Prefix that marks a reference value as an inline AQL expression
+const aqlPrefix = "aql:"
+
+// Object keys are the schema's key prefix, a hyphen and a number (e.g. HW-42)
+var objectKey = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-\d+$`)
+
+// Number of candidates listed when a reference matches several objects
+const maxCandidates = 5
+
+// resolveReference turns a reference value into an object ID. The value can be an
+// object ID, an object key, a label of an object of the referenced type, or an AQL
+// expression prefixed with "aql:". Anything but an ID must match exactly one object.
+func (pr *PropertyResolver) resolveReference(ctx context.Context, meta *AttributeMetadata, value string) (string, *ValidationError) {
+	if numericID.MatchString(value) {
+		return value, nil
+	}
+
+	var condition, description string
+	switch {
+	case strings.HasPrefix(strings.ToLower(value), aqlPrefix):
+		expression := strings.TrimSpace(value[len(aqlPrefix):])
+		if expression == "" {
+			return "", referenceError(meta, "INVALID_REFERENCE", "reference field '%s' has an empty AQL expression", meta.Name)
+		}
+		condition = "(" + expression + ")"
+		description = fmt.Sprintf("AQL '%s'", expression)
+	case objectKey.MatchString(value):
+		condition = fmt.Sprintf(`Key = "%s"`, escapeAQL(strings.ToUpper(value)))
+		description = fmt.Sprintf("key '%s'", value)
+	default:
+		condition = fmt.Sprintf(`Label = "%s"`, escapeAQL(value))
+		description = fmt.Sprintf("label '%s'", value)
+	}
+
+	// Keys and labels are only looked up among objects of the referenced type
+	query := condition
+	if meta.ReferenceObjectType != "" {
+		query = fmt.Sprintf("objectTypeId = %s AND %s", meta.ReferenceObjectType, condition)
+	}
+
+	objects, total, err := pr.searchReferences(ctx, query)
+	if err != nil {
+		return "", referenceError(meta, "REFERENCE_LOOKUP_FAILED", "reference field '%s' could not resolve %s: %v", meta.Name, description, err)
+	}
+
+	switch {
+	case total == 0:
+		return "", referenceError(meta, "REFERENCE_NOT_FOUND", "reference field '%s' found no object with %s", meta.Name, description)
+	case total > 1:
+		return "", referenceError(meta, "REFERENCE_AMBIGUOUS", "reference field '%s' matched %d objects with %s: %s; use the object key or ID",
+			meta.Name, total, description, describeCandidates(objects, total))
+	}
+	return objects[0].ID, nil
+}
+
+// searchReferences runs a reference lookup, fetching just enough objects to describe
+// an ambiguous match
+func (pr *PropertyResolver) searchReferences(ctx context.Context, query string) ([]*models.ObjectScheme, int, error) {
+	if pr.client == nil {
+		return nil, 0, fmt.Errorf("no client to search with")
+	}
+
+	response, err := pr.client.SearchObjects(ctx, query, maxCandidates)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !response.Success {
+		return nil, 0, fmt.Errorf("%s", response.Error)
+	}
+
+	data, ok := response.Data.(map[string]interface{})
+	if !ok {
+		return nil, 0, fmt.Errorf("unexpected response format")
+	}
+	objects, _ := data["objects"].([]*models.ObjectScheme)
+
+	total := len(objects)
+	switch t := data["total"].(type) {
+	case int:
+		total = t
+	case float64:
+		total = int(t)
+	}
+	if total > 0 && len(objects) == 0 {
+		return nil, 0, fmt.Errorf("search reported %d matches but returned none", total)
+	}
+	return objects, total, nil
+}
+
+// describeCandidates lists the matched objects as "KEY (label)"
+func describeCandidates(objects []*models.ObjectScheme, total int) string {
+	var candidates []string
+	for _, object := range objects {
+		if object != nil {
+			candidates = append(candidates, fmt.Sprintf("%s (%s)", object.ObjectKey, object.Label))
+		}
+	}
+	if total > len(candidates) {
+		candidates = append(candidates, fmt.Sprintf("and %d more", total-len(candidates)))
+	}
+	return strings.Join(candidates, ", ")
+}
+
+func referenceError(meta *AttributeMetadata, code, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{AttributeName: meta.Name, Message: fmt.Sprintf(format, args...), Code: code}
+}
+
+func escapeAQL(value string) string {
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
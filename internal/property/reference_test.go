@@ -0,0 +1,83 @@
+package property
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/client"
+)
+
+// fakeClient answers searches from a map of query to matching objects
+type fakeClient struct {
+	results map[string][]*models.ObjectScheme
+	queries []string
+}
+
+func (f *fakeClient) GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	return client.NewSuccessResponse(map[string]interface{}{"attributes": []*models.ObjectTypeAttributeScheme{}}), nil
+}
+
+func (f *fakeClient) SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error) {
+	f.queries = append(f.queries, query)
+	objects := f.results[query]
+	total := len(objects)
+	if limit < len(objects) {
+		objects = objects[:limit]
+	}
+	return client.NewSuccessResponse(map[string]interface{}{"objects": objects, "total": total}), nil
+}
+
+func TestResolveReference(t *testing.T) {
+	laptop := func(id, key, label string) *models.ObjectScheme {
+		return &models.ObjectScheme{ID: id, ObjectKey: key, Label: label}
+	}
+	fake := &fakeClient{results: map[string][]*models.ObjectScheme{
+		`objectTypeId = 5 AND Key = "HW-42"`:                    {laptop("42", "HW-42", "dev-box")},
+		`objectTypeId = 5 AND Label = "dev-box"`:                {laptop("42", "HW-42", "dev-box")},
+		`objectTypeId = 5 AND Label = "spare"`:                  {laptop("7", "HW-7", "spare"), laptop("8", "HW-8", "spare")},
+		`objectTypeId = 5 AND (Serial = "A1" OR Serial = "B2")`: {laptop("9", "HW-9", "build-1")},
+	}}
+	pr := NewPropertyResolver(fake)
+	meta := &AttributeMetadata{ID: "12", Name: "Laptop", DataType: "Reference", Editable: true, ReferenceObjectType: "5"}
+
+	tests := []struct {
+		value    interface{}
+		want     interface{}
+		wantCode string
+	}{
+		{"1001", "1001", ""},
+		{"hw-42", "42", ""},
+		{"dev-box", "42", ""},
+		{`aql: Serial = "A1" OR Serial = "B2"`, "9", ""},
+		{[]string{"HW-42", "1001"}, []string{"42", "1001"}, ""},
+		{"spare", nil, "REFERENCE_AMBIGUOUS"},
+		{"HW-404", nil, "REFERENCE_NOT_FOUND"},
+		{"aql:", nil, "INVALID_REFERENCE"},
+	}
+
+	for _, tt := range tests {
+		resolved, err := pr.ResolveProperty(context.Background(), meta, tt.value)
+		if tt.wantCode != "" {
+			verr, ok := err.(*ValidationError)
+			if !ok || verr.Code != tt.wantCode {
+				t.Errorf("ResolveProperty(%v) error = %v, want code %s", tt.value, err, tt.wantCode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ResolveProperty(%v) failed: %v", tt.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(resolved.Value, tt.want) {
+			t.Errorf("ResolveProperty(%v) = %#v, want %#v", tt.value, resolved.Value, tt.want)
+		}
+	}
+
+	_, err := pr.ResolveProperty(context.Background(), meta, "spare")
+	if err == nil || !strings.Contains(err.Error(), "HW-7 (spare), HW-8 (spare)") {
+		t.Errorf("ambiguous error = %v, want the candidates listed", err)
+	}
+}
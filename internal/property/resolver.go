@@ -3,49 +3,134 @@ package property
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"sort"
 	"strings"
-	"time"
 
 	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
 	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/resolver"
 )
 
+// AttributeClient is the part of the Assets client the resolver reads attributes from
+// and looks up referenced objects with
+type AttributeClient interface {
+	GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error)
+	SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error)
+}
+
+// AttributeSource provides the attribute definitions of object types, such as the
+// resolver's cache of them
+type AttributeSource interface {
+	GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error)
+}
+
+// Directory looks up the Atlassian account IDs of users and the names of groups
+type Directory interface {
+	ResolveUser(ctx context.Context, value string) (string, error)
+	ResolveGroup(ctx context.Context, value string) (string, error)
+}
+
+// StatusSource lists the status types of a schema, global ones included
+type StatusSource interface {
+	GetStatusTypes(ctx context.Context, schemaID string) (*client.Response, error)
+}
+
 // PropertyResolver handles resolution and validation of object properties
 type PropertyResolver struct {
-	client *client.AssetsClient
+	client     AttributeClient
+	attributes AttributeSource
+	directory  Directory
+	statuses   StatusSource
 }
 
 // NewPropertyResolver creates a new property resolver
-func NewPropertyResolver(client *client.AssetsClient) *PropertyResolver {
+func NewPropertyResolver(client AttributeClient) *PropertyResolver {
 	return &PropertyResolver{
-		client: client,
+		client:     client,
+		attributes: client,
 	}
 }
 
+// WithAttributeCache reads attribute definitions from a cache instead of the client
+func (pr *PropertyResolver) WithAttributeCache(attributes AttributeSource) *PropertyResolver {
+	pr.attributes = attributes
+	return pr
+}
+
+// WithDirectory lets User and Group attributes take emails, display names and
+// group names in any case
+func (pr *PropertyResolver) WithDirectory(directory Directory) *PropertyResolver {
+	pr.directory = directory
+	return pr
+}
+
+// WithStatuses lets Status attributes take status names in any case as well as IDs
+func (pr *PropertyResolver) WithStatuses(statuses StatusSource) *PropertyResolver {
+	pr.statuses = statuses
+	return pr
+}
+
 // AttributeMetadata holds metadata about an object type attribute
 type AttributeMetadata struct {
-	ID                  string   `json:"id"`
-	Name                string   `json:"name"`
-	DataType            string   `json:"data_type"`
-	Required            bool     `json:"required"`
-	Editable            bool     `json:"editable"`
-	System              bool     `json:"system"`
-	MaxCardinality      int      `json:"max_cardinality"`
-	MinCardinality      int      `json:"min_cardinality"`
-	ReferenceObjectType string   `json:"reference_object_type,omitempty"`
-	StatusValues        []string `json:"status_values,omitempty"`
-	SelectOptions       []string `json:"select_options,omitempty"`
-	Description         string   `json:"description,omitempty"`
+	ID                  string            `json:"id"`
+	Name                string            `json:"name"`
+	DataType            string            `json:"data_type"`
+	Required            bool              `json:"required"`
+	Editable            bool              `json:"editable"`
+	System              bool              `json:"system"`
+	MaxCardinality      int               `json:"max_cardinality"`
+	MinCardinality      int               `json:"min_cardinality"`
+	ReferenceObjectType string            `json:"reference_object_type,omitempty"`
+	StatusValues        []string          `json:"status_values,omitempty"`
+	StatusNames         map[string]string `json:"status_names,omitempty"` // Status ID -> name
+	SelectOptions       []string          `json:"select_options,omitempty"`
+	Description         string            `json:"description,omitempty"`
+}
+
+// Choices lists the values a Status or Select attribute takes, with statuses by name
+// where it is known, or nil for other attributes
+func (m *AttributeMetadata) Choices() []string {
+	if m.DataType != "Status" {
+		return m.SelectOptions
+	}
+
+	ids := m.StatusValues
+	if len(ids) == 0 {
+		for id := range m.StatusNames {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+	}
+	choices := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if name := m.StatusNames[id]; name != "" {
+			choices = append(choices, name)
+		} else {
+			choices = append(choices, id)
+		}
+	}
+	return choices
 }
 
-// PropertyValue represents a resolved property value ready for API submission
+// PropertyValue represents a resolved property value ready for API submission.
+// Value is a string for a single value and a []string for several.
 type PropertyValue struct {
 	AttributeID string      `json:"attribute_id"`
 	Value       interface{} `json:"value"`
 	DataType    string      `json:"data_type"`
 }
 
+// Values returns the resolved values as a list
+func (v *PropertyValue) Values() []string {
+	switch value := v.Value.(type) {
+	case []string:
+		return value
+	case string:
+		return []string{value}
+	}
+	return nil
+}
+
 // ValidationError represents a property validation error
 type ValidationError struct {
 	AttributeName string `json:"attribute_name"`
@@ -59,7 +144,7 @@ func (e *ValidationError) Error() string {
 
 // GetObjectTypeMetadata retrieves and parses metadata for all attributes of an object type
 func (pr *PropertyResolver) GetObjectTypeMetadata(ctx context.Context, objectTypeID string) (map[string]*AttributeMetadata, error) {
-	response, err := pr.client.GetObjectTypeAttributes(ctx, objectTypeID)
+	response, err := pr.attributes.GetObjectTypeAttributes(ctx, objectTypeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get object type attributes: %w", err)
 	}
@@ -80,6 +165,7 @@ func (pr *PropertyResolver) GetObjectTypeMetadata(ctx context.Context, objectTyp
 	}
 
 	metadata := make(map[string]*AttributeMetadata)
+	var statusNames map[string]string
 
 	for _, attr := range attributes {
 		if attr == nil {
@@ -89,7 +175,7 @@ func (pr *PropertyResolver) GetObjectTypeMetadata(ctx context.Context, objectTyp
 		meta := &AttributeMetadata{
 			ID:             attr.ID,
 			Name:           attr.Name,
-			Required:       attr.MinimumCardinality == 1,
+			Required:       attr.MinimumCardinality >= 1,
 			Editable:       attr.Editable,
 			System:         attr.System,
 			MaxCardinality: attr.MaximumCardinality,
@@ -97,25 +183,20 @@ func (pr *PropertyResolver) GetObjectTypeMetadata(ctx context.Context, objectTyp
 			Description:    attr.Description,
 		}
 
-		// Determine data type
-		if attr.DefaultType != nil {
-			if attr.DefaultType.Name != "" {
-				meta.DataType = attr.DefaultType.Name
-			} else if attr.DefaultType.ID > 0 {
-				meta.DataType = fmt.Sprintf("type_%d", attr.DefaultType.ID)
-			}
-		}
+		meta.DataType = dataTypeOf(attr)
 
 		// Handle reference attributes
-		if attr.Type == 1 && attr.ReferenceObjectTypeID != "" {
-			meta.DataType = "Reference"
+		if meta.DataType == "Reference" {
 			meta.ReferenceObjectType = attr.ReferenceObjectTypeID
 		}
 
-		// Handle status attributes (type 7)
-		if attr.Type == 7 && len(attr.TypeValueMulti) > 0 {
-			meta.DataType = "Status"
+		// Handle status attributes
+		if meta.DataType == "Status" {
 			meta.StatusValues = attr.TypeValueMulti
+			if statusNames == nil {
+				statusNames = pr.statusNames(ctx, attr)
+			}
+			meta.StatusNames = statusNames
 		}
 
 		// Handle select attributes
@@ -130,204 +211,182 @@ func (pr *PropertyResolver) GetObjectTypeMetadata(ctx context.Context, objectTyp
 	return metadata, nil
 }
 
+// statusNames maps the status types of an attribute's schema to their names. Without
+// them status values are only accepted as IDs.
+func (pr *PropertyResolver) statusNames(ctx context.Context, attr *models.ObjectTypeAttributeScheme) map[string]string {
+	names := make(map[string]string)
+	if pr.statuses == nil {
+		return names
+	}
+
+	schemaID := ""
+	if attr.ObjectType != nil {
+		schemaID = attr.ObjectType.ObjectSchemaID
+	}
+	response, err := pr.statuses.GetStatusTypes(ctx, schemaID)
+	if err != nil || !response.Success {
+		return names
+	}
+	data, _ := response.Data.(map[string]interface{})
+	statuses, _ := data["statuses"].([]*models.ObjectTypeAssetAttributeStatusScheme)
+	for _, status := range statuses {
+		names[status.ID] = status.Name
+	}
+	return names
+}
+
 // ValidateProperty validates a property value against its metadata
 func (pr *PropertyResolver) ValidateProperty(meta *AttributeMetadata, value interface{}) *ValidationError {
+	_, err := pr.normalizeProperty(meta, value)
+	return err
+}
+
+// normalizeProperty checks a property's cardinality and the type of each of its
+// values, returning the values in the form the API expects
+func (pr *PropertyResolver) normalizeProperty(meta *AttributeMetadata, value interface{}) ([]string, *ValidationError) {
+	values := valuesOf(value)
+
 	// Check if required field is missing
-	if meta.Required && (value == nil || value == "") {
-		return &ValidationError{
-			AttributeName: meta.Name,
-			Message:       "required field is missing",
-			Code:          "REQUIRED_FIELD_MISSING",
+	if len(values) == 0 {
+		if meta.Required {
+			return nil, &ValidationError{
+				AttributeName: meta.Name,
+				Message:       "required field is missing",
+				Code:          "REQUIRED_FIELD_MISSING",
+			}
 		}
-	}
-
-	// Skip validation for nil/empty non-required fields
-	if value == nil || value == "" {
-		return nil
+		return nil, nil
 	}
 
 	// Check if field is editable
 	if !meta.Editable && !meta.System {
-		return &ValidationError{
+		return nil, &ValidationError{
 			AttributeName: meta.Name,
 			Message:       "field is not editable",
 			Code:          "FIELD_NOT_EDITABLE",
 		}
 	}
 
-	valueStr := fmt.Sprintf("%v", value)
-
-	// Validate based on data type
-	switch meta.DataType {
-	case "Date":
-		if err := pr.validateDate(valueStr); err != nil {
-			return &ValidationError{
-				AttributeName: meta.Name,
-				Message:       fmt.Sprintf("invalid date format: %s", err.Error()),
-				Code:          "INVALID_DATE_FORMAT",
-			}
-		}
-	case "DateTime", "type_6":
-		if err := pr.validateDateTime(valueStr); err != nil {
-			return &ValidationError{
-				AttributeName: meta.Name,
-				Message:       fmt.Sprintf("invalid datetime format: %s", err.Error()),
-				Code:          "INVALID_DATETIME_FORMAT",
-			}
-		}
-	case "Select":
-		if !pr.validateSelectOption(valueStr, meta.SelectOptions) {
-			return &ValidationError{
-				AttributeName: meta.Name,
-				Message:       fmt.Sprintf("invalid option '%s', allowed: %v", valueStr, meta.SelectOptions),
-				Code:          "INVALID_SELECT_OPTION",
-			}
-		}
-	case "Status":
-		if !pr.validateStatusValue(valueStr, meta.StatusValues) {
-			return &ValidationError{
-				AttributeName: meta.Name,
-				Message:       fmt.Sprintf("invalid status value '%s', allowed IDs: %v", valueStr, meta.StatusValues),
-				Code:          "INVALID_STATUS_VALUE",
-			}
+	// A maximum cardinality of -1 (or unset) allows any number of values
+	if meta.MaxCardinality > 0 && len(values) > meta.MaxCardinality {
+		return nil, &ValidationError{
+			AttributeName: meta.Name,
+			Message:       fmt.Sprintf("too many values: %d given, at most %d allowed", len(values), meta.MaxCardinality),
+			Code:          "TOO_MANY_VALUES",
 		}
 	}
-
-	return nil
-}
-
-// validateDate validates date format (YYYY-MM-DD)
-func (pr *PropertyResolver) validateDate(value string) error {
-	_, err := time.Parse("2006-01-02", value)
-	return err
-}
-
-// validateDateTime validates datetime format (ISO 8601)
-func (pr *PropertyResolver) validateDateTime(value string) error {
-	formats := []string{
-		time.RFC3339,
-		"2006-01-02T15:04:05Z",
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04:05",
-	}
-	
-	for _, format := range formats {
-		if _, err := time.Parse(format, value); err == nil {
-			return nil
+	if len(values) < meta.MinCardinality {
+		return nil, &ValidationError{
+			AttributeName: meta.Name,
+			Message:       fmt.Sprintf("too few values: %d given, at least %d required", len(values), meta.MinCardinality),
+			Code:          "TOO_FEW_VALUES",
 		}
 	}
-	
-	return fmt.Errorf("unable to parse datetime, supported formats: ISO 8601, RFC3339")
-}
 
-// validateSelectOption validates select field option
-func (pr *PropertyResolver) validateSelectOption(value string, options []string) bool {
-	for _, option := range options {
-		if strings.EqualFold(value, option) {
-			return true
+	normalized := make([]string, 0, len(values))
+	for _, v := range values {
+		text, err := normalizeValue(meta, v)
+		if err != nil {
+			return nil, err
 		}
+		normalized = append(normalized, text)
 	}
-	return false
-}
 
-// validateStatusValue validates status field value (accepts both names and IDs)
-func (pr *PropertyResolver) validateStatusValue(value string, allowedIDs []string) bool {
-	// Check if value is a valid status ID
-	for _, id := range allowedIDs {
-		if value == id {
-			return true
-		}
-	}
-	
-	// TODO: Add status name to ID resolution
-	// For now, only accept direct status IDs
-	return false
+	return normalized, nil
 }
 
 // ResolveProperty resolves a property value to the format expected by the API
 func (pr *PropertyResolver) ResolveProperty(ctx context.Context, meta *AttributeMetadata, value interface{}) (*PropertyValue, error) {
-	// Validate the property first
-	if err := pr.ValidateProperty(meta, value); err != nil {
+	values, err := pr.normalizeProperty(meta, value)
+	if err != nil {
 		return nil, err
 	}
 
-	// Skip nil/empty values for non-required fields
-	if value == nil || value == "" {
-		if !meta.Required {
-			return nil, nil
+	// Skip empty values for non-required fields
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	// References, users and groups are named by the user and resolved to IDs here
+	for i, v := range values {
+		id, err := pr.resolveValue(ctx, meta, v)
+		if err != nil {
+			return nil, err
 		}
+		values[i] = id
 	}
 
 	resolved := &PropertyValue{
 		AttributeID: meta.ID,
 		DataType:    meta.DataType,
+		Value:       values[0],
+	}
+	if len(values) > 1 {
+		resolved.Value = values
 	}
 
-	valueStr := fmt.Sprintf("%v", value)
+	return resolved, nil
+}
 
+// resolveValue turns a name for a referenced object, user or group into the ID the API expects
+func (pr *PropertyResolver) resolveValue(ctx context.Context, meta *AttributeMetadata, value string) (string, *ValidationError) {
 	switch meta.DataType {
 	case "Reference":
-		// TODO: Resolve reference object names to IDs
-		// For now, assume the value is already an object ID
-		if _, err := strconv.Atoi(valueStr); err != nil {
-			return nil, fmt.Errorf("reference field '%s' requires object ID, got: %s", meta.Name, valueStr)
-		}
-		resolved.Value = valueStr
+		return pr.resolveReference(ctx, meta, value)
 
-	case "Status":
-		// Ensure status value is a valid ID
-		if !pr.validateStatusValue(valueStr, meta.StatusValues) {
-			return nil, fmt.Errorf("invalid status value '%s' for field '%s'", valueStr, meta.Name)
+	case "User":
+		if resolver.IsAccountID(value) {
+			return value, nil
 		}
-		resolved.Value = valueStr
-
-	case "Select":
-		// Validate and normalize select option
-		for _, option := range meta.SelectOptions {
-			if strings.EqualFold(valueStr, option) {
-				resolved.Value = option // Use the exact case from options
-				break
+		if pr.directory == nil {
+			return "", &ValidationError{
+				AttributeName: meta.Name,
+				Message:       fmt.Sprintf("user field '%s' requires an Atlassian account ID, got: %s", meta.Name, value),
+				Code:          "INVALID_USER",
 			}
 		}
-
-	case "Date":
-		// Parse and format date
-		date, err := time.Parse("2006-01-02", valueStr)
+		id, err := pr.directory.ResolveUser(ctx, value)
 		if err != nil {
-			return nil, fmt.Errorf("invalid date format for field '%s': %s", meta.Name, valueStr)
-		}
-		resolved.Value = date.Format("2006-01-02")
-
-	case "DateTime", "type_6":
-		// Parse and format datetime
-		var parsedTime time.Time
-		var err error
-		
-		formats := []string{
-			time.RFC3339,
-			"2006-01-02T15:04:05Z",
-			"2006-01-02T15:04:05",
-			"2006-01-02 15:04:05",
+			return "", &ValidationError{AttributeName: meta.Name, Message: fmt.Sprintf("user field '%s': %v", meta.Name, err), Code: "INVALID_USER"}
 		}
-		
-		for _, format := range formats {
-			if parsedTime, err = time.Parse(format, valueStr); err == nil {
-				break
-			}
+		return id, nil
+
+	case "Group":
+		if pr.directory == nil {
+			return value, nil
 		}
-		
+		name, err := pr.directory.ResolveGroup(ctx, value)
 		if err != nil {
-			return nil, fmt.Errorf("invalid datetime format for field '%s': %s", meta.Name, valueStr)
+			return "", &ValidationError{AttributeName: meta.Name, Message: fmt.Sprintf("group field '%s': %v", meta.Name, err), Code: "INVALID_GROUP"}
 		}
-		
-		resolved.Value = parsedTime.Format(time.RFC3339)
+		return name, nil
+	}
+	return value, nil
+}
 
-	default:
-		// Text and other simple types
-		resolved.Value = valueStr
+// LookupMetadata finds an attribute by name, ID, or a name written in snake or kebab case
+func LookupMetadata(metadata map[string]*AttributeMetadata, key string) (*AttributeMetadata, bool) {
+	lower := strings.ToLower(strings.TrimSpace(key))
+	if meta, ok := metadata[lower]; ok {
+		return meta, true
 	}
 
-	return resolved, nil
+	spaced := strings.NewReplacer("_", " ", "-", " ").Replace(lower)
+	for _, meta := range metadata {
+		if meta.ID == key || strings.EqualFold(meta.Name, spaced) {
+			return meta, true
+		}
+	}
+	return nil, false
+}
+
+// PayloadAttributes keys resolved values by attribute ID, ready for CreateObject
+func PayloadAttributes(values []*PropertyValue) map[string]interface{} {
+	attributes := make(map[string]interface{}, len(values))
+	for _, v := range values {
+		attributes[v.AttributeID] = v.Value
+	}
+	return attributes
 }
 
 // ResolveObjectProperties resolves a map of property names to values
@@ -342,7 +401,7 @@ func (pr *PropertyResolver) ResolveObjectProperties(ctx context.Context, objectT
 
 	// Process provided properties
 	for propName, propValue := range properties {
-		meta, exists := metadata[strings.ToLower(propName)]
+		meta, exists := LookupMetadata(metadata, propName)
 		if !exists {
 			errors = append(errors, fmt.Errorf("unknown property: %s", propName))
 			continue
@@ -360,16 +419,15 @@ func (pr *PropertyResolver) ResolveObjectProperties(ctx context.Context, objectT
 	}
 
 	// Check for missing required properties
+	provided := make(map[string]bool)
+	for propName := range properties {
+		if meta, ok := LookupMetadata(metadata, propName); ok {
+			provided[meta.ID] = true
+		}
+	}
 	for _, meta := range metadata {
 		if meta.Required && !meta.System {
-			found := false
-			for propName := range properties {
-				if strings.EqualFold(propName, meta.Name) {
-					found = true
-					break
-				}
-			}
-			if !found {
+			if !provided[meta.ID] {
 				errors = append(errors, &ValidationError{
 					AttributeName: meta.Name,
 					Message:       "required field is missing",
@@ -380,4 +438,4 @@ func (pr *PropertyResolver) ResolveObjectProperties(ctx context.Context, objectT
 	}
 
 	return resolved, errors
-}
\ No newline at end of file
+}
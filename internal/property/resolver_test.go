@@ -0,0 +1,99 @@
+package property
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/client"
+)
+
+// fakeAttributeCache serves fixed attribute definitions and counts its reads
+type fakeAttributeCache struct {
+	reads int
+}
+
+func (f *fakeAttributeCache) GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	f.reads++
+	return client.NewSuccessResponse(map[string]interface{}{"attributes": []*models.ObjectTypeAttributeScheme{
+		{ID: "701", Name: "Name", MinimumCardinality: 1},
+	}}), nil
+}
+
+func TestGetObjectTypeMetadataWithAttributeCache(t *testing.T) {
+	cache := &fakeAttributeCache{}
+	pr := NewPropertyResolver(&fakeClient{}).WithAttributeCache(cache)
+
+	metadata, err := pr.GetObjectTypeMetadata(context.Background(), "141")
+	if err != nil {
+		t.Fatalf("GetObjectTypeMetadata failed: %v", err)
+	}
+	if meta := metadata["name"]; meta == nil || meta.ID != "701" || !meta.Required {
+		t.Errorf("metadata = %+v, want the required Name attribute from the cache", metadata)
+	}
+	if cache.reads != 1 {
+		t.Errorf("cache reads = %d, want 1", cache.reads)
+	}
+}
+
+// fakeStatuses serves the status types of every schema
+type fakeStatuses struct {
+	schemaID string
+}
+
+func (f *fakeStatuses) GetStatusTypes(ctx context.Context, schemaID string) (*client.Response, error) {
+	f.schemaID = schemaID
+	return client.NewSuccessResponse(map[string]interface{}{"statuses": []*models.ObjectTypeAssetAttributeStatusScheme{
+		{ID: "1", Name: "In use"}, {ID: "3", Name: "Retired"}, {ID: "9", Name: "Lost"},
+	}}), nil
+}
+
+func TestStatusNames(t *testing.T) {
+	statuses := &fakeStatuses{}
+	pr := NewPropertyResolver(&fakeClient{}).WithAttributeCache(attributeList{
+		{ID: "53", Name: "Status", Type: 7, TypeValueMulti: []string{"1", "3"}, ObjectType: &models.ObjectTypeScheme{ObjectSchemaID: "7"}},
+	}).WithStatuses(statuses)
+
+	metadata, err := pr.GetObjectTypeMetadata(context.Background(), "141")
+	if err != nil {
+		t.Fatalf("GetObjectTypeMetadata failed: %v", err)
+	}
+	meta := metadata["status"]
+	if statuses.schemaID != "7" || meta.StatusNames["3"] != "Retired" {
+		t.Fatalf("status metadata = %+v (schema %q)", meta, statuses.schemaID)
+	}
+
+	tests := []struct {
+		value    string
+		want     string
+		wantCode string
+	}{
+		{value: "Retired", want: "3"},
+		{value: "in USE", want: "1"},
+		{value: "3", want: "3"},
+		{value: "Lost", wantCode: "INVALID_STATUS_VALUE"}, // Not allowed on this attribute
+		{value: "Broken", wantCode: "INVALID_STATUS_VALUE"},
+	}
+	for _, tt := range tests {
+		got, verr := normalizeValue(meta, tt.value)
+		if tt.wantCode != "" {
+			if verr == nil || verr.Code != tt.wantCode {
+				t.Errorf("normalizeValue(%q) error = %v, want %s", tt.value, verr, tt.wantCode)
+			}
+			continue
+		}
+		if verr != nil || got != tt.want {
+			t.Errorf("normalizeValue(%q) = %q, %v; want %q", tt.value, got, verr, tt.want)
+		}
+	}
+	if _, verr := normalizeValue(meta, "Broken"); verr.Message != "invalid status value 'Broken', allowed: [In use (1), Retired (3)]" {
+		t.Errorf("error message = %q", verr.Message)
+	}
+}
+
+// attributeList serves the same attributes for every object type
+type attributeList []*models.ObjectTypeAttributeScheme
+
+func (a attributeList) GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	return client.NewSuccessResponse(map[string]interface{}{"attributes": []*models.ObjectTypeAttributeScheme(a)}), nil
+}
@@ -0,0 +1,269 @@
+package property
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+)
+
+// Names of the default attribute types, by default type ID
+var defaultTypeNames = map[int]string{
+	0:  "Text",
+	1:  "Integer",
+	2:  "Boolean",
+	3:  "Double",
+	4:  "Date",
+	5:  "Time",
+	6:  "DateTime",
+	7:  "URL",
+	8:  "Email",
+	9:  "Textarea",
+	10: "Select",
+	11: "IP Address",
+}
+
+// Names of the non-default attribute types, by attribute type
+var attributeTypeNames = map[int]string{
+	1: "Reference",
+	2: "User",
+	3: "Confluence",
+	4: "Group",
+	5: "Version",
+	6: "Project",
+	7: "Status",
+}
+
+var numericID = regexp.MustCompile(`^\d+$`)
+
+var dateTimeFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+var timeFormats = []string{"15:04", "15:04:05", "3:04PM", "3:04 PM"}
+
+// dataTypeOf names an attribute's data type
+func dataTypeOf(attr *models.ObjectTypeAttributeScheme) string {
+	if attr.Type != 0 {
+		if name, ok := attributeTypeNames[attr.Type]; ok {
+			return name
+		}
+		return fmt.Sprintf("type_%d", attr.Type)
+	}
+	if attr.DefaultType == nil {
+		return "Text"
+	}
+	if attr.DefaultType.Name != "" {
+		return attr.DefaultType.Name
+	}
+	if name, ok := defaultTypeNames[attr.DefaultType.ID]; ok {
+		return name
+	}
+	return fmt.Sprintf("type_%d", attr.DefaultType.ID)
+}
+
+// valuesOf splits a property value into its individual values, dropping empty ones
+func valuesOf(value interface{}) []interface{} {
+	var values []interface{}
+	switch v := value.(type) {
+	case nil:
+	case []interface{}:
+		values = v
+	case []string:
+		for _, s := range v {
+			values = append(values, s)
+		}
+	default:
+		values = []interface{}{v}
+	}
+
+	nonEmpty := values[:0:0]
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		if s, ok := v.(string); ok && strings.TrimSpace(s) == "" {
+			continue
+		}
+		nonEmpty = append(nonEmpty, v)
+	}
+	return nonEmpty
+}
+
+// normalizeValue checks one value against the attribute's type and returns it in
+// the form the Assets API expects
+func normalizeValue(meta *AttributeMetadata, value interface{}) (string, *ValidationError) {
+	invalid := func(code, format string, args ...interface{}) (string, *ValidationError) {
+		return "", &ValidationError{AttributeName: meta.Name, Message: fmt.Sprintf(format, args...), Code: code}
+	}
+
+	text, err := scalarString(value)
+	if err != nil {
+		return invalid("INVALID_VALUE", "%s", err.Error())
+	}
+	text = strings.TrimSpace(text)
+
+	switch meta.DataType {
+	case "Integer":
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil || number != math.Trunc(number) || math.Abs(number) > 1<<53 {
+			return invalid("INVALID_INTEGER", "invalid integer '%s'", text)
+		}
+		return strconv.FormatInt(int64(number), 10), nil
+
+	case "Double", "Float":
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return invalid("INVALID_DOUBLE", "invalid number '%s'", text)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+
+	case "Boolean":
+		switch strings.ToLower(text) {
+		case "true", "yes", "y", "on", "1":
+			return "true", nil
+		case "false", "no", "n", "off", "0":
+			return "false", nil
+		}
+		return invalid("INVALID_BOOLEAN", "invalid boolean '%s', use true or false", text)
+
+	case "Date":
+		if date, err := time.Parse("2006-01-02", text); err == nil {
+			return date.Format("2006-01-02"), nil
+		}
+		if date, err := time.Parse(time.RFC3339, text); err == nil {
+			return date.Format("2006-01-02"), nil
+		}
+		return invalid("INVALID_DATE_FORMAT", "invalid date format: '%s' is not YYYY-MM-DD", text)
+
+	case "Time":
+		for _, format := range timeFormats {
+			if parsed, err := time.Parse(format, strings.ToUpper(text)); err == nil {
+				return parsed.Format("15:04"), nil
+			}
+		}
+		return invalid("INVALID_TIME_FORMAT", "invalid time format: '%s' is not HH:MM", text)
+
+	case "DateTime", "type_6":
+		for _, format := range dateTimeFormats {
+			if parsed, err := time.Parse(format, text); err == nil {
+				return parsed.Format(time.RFC3339), nil
+			}
+		}
+		return invalid("INVALID_DATETIME_FORMAT", "invalid datetime format: '%s' is not ISO 8601", text)
+
+	case "URL":
+		parsed, err := url.Parse(text)
+		if err != nil || parsed.Scheme == "" || (parsed.Host == "" && parsed.Opaque == "") {
+			return invalid("INVALID_URL", "invalid URL '%s', include the scheme (e.g. https://)", text)
+		}
+		return parsed.String(), nil
+
+	case "Email":
+		address, err := mail.ParseAddress(text)
+		if err != nil || address.Address != text {
+			return invalid("INVALID_EMAIL", "invalid email address '%s'", text)
+		}
+		return address.Address, nil
+
+	case "IP Address":
+		ip := net.ParseIP(text)
+		if ip == nil {
+			return invalid("INVALID_IP_ADDRESS", "invalid IP address '%s'", text)
+		}
+		return ip.String(), nil
+
+	case "Select":
+		if len(meta.SelectOptions) == 0 {
+			return text, nil
+		}
+		for _, option := range meta.SelectOptions {
+			if strings.EqualFold(text, option) {
+				return option, nil
+			}
+		}
+		return invalid("INVALID_SELECT_OPTION", "invalid option '%s', allowed: %v", text, meta.SelectOptions)
+
+	case "Status":
+		allowed := meta.StatusValues
+		if len(allowed) == 0 {
+			if numericID.MatchString(text) {
+				return text, nil
+			}
+			for id := range meta.StatusNames {
+				allowed = append(allowed, id)
+			}
+			sort.Strings(allowed)
+		}
+		for _, id := range allowed {
+			if text == id || (meta.StatusNames[id] != "" && strings.EqualFold(text, meta.StatusNames[id])) {
+				return id, nil
+			}
+		}
+		return invalid("INVALID_STATUS_VALUE", "invalid status value '%s', allowed: %s", text, statusChoices(meta, allowed))
+
+	case "Version", "Project", "Confluence":
+		if !numericID.MatchString(text) {
+			return invalid("INVALID_"+strings.ToUpper(meta.DataType), "%s field '%s' requires a numeric ID, got: %s", strings.ToLower(meta.DataType), meta.Name, text)
+		}
+		return text, nil
+
+	default:
+		// Text, Textarea and unknown types take the value as written; references, users
+		// and groups are resolved by ResolveProperty
+		return text, nil
+	}
+}
+
+// statusChoices lists status IDs with their names where known
+func statusChoices(meta *AttributeMetadata, ids []string) string {
+	choices := make([]string, len(ids))
+	for i, id := range ids {
+		choices[i] = id
+		if name := meta.StatusNames[id]; name != "" {
+			choices[i] = fmt.Sprintf("%s (%s)", name, id)
+		}
+	}
+	return "[" + strings.Join(choices, ", ") + "]"
+}
+
+// scalarString formats a single JSON-style value without losing precision
+func scalarString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
@@ -0,0 +1,109 @@
+package property
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+)
+
+func TestNormalizeValue(t *testing.T) {
+	tests := []struct {
+		dataType string
+		value    interface{}
+		want     string
+		wantCode string
+	}{
+		{"Integer", float64(42), "42", ""},
+		{"Integer", "-7", "-7", ""},
+		{"Integer", "4.5", "", "INVALID_INTEGER"},
+		{"Double", "3.50", "3.5", ""},
+		{"Double", "abc", "", "INVALID_DOUBLE"},
+		{"Boolean", true, "true", ""},
+		{"Boolean", "No", "false", ""},
+		{"Boolean", "perhaps", "", "INVALID_BOOLEAN"},
+		{"Date", "2024-01-15", "2024-01-15", ""},
+		{"Date", "15/01/2024", "", "INVALID_DATE_FORMAT"},
+		{"Time", "9:30 pm", "21:30", ""},
+		{"Time", "25:00", "", "INVALID_TIME_FORMAT"},
+		{"DateTime", "2024-01-15 10:30:00", "2024-01-15T10:30:00Z", ""},
+		{"DateTime", "yesterday", "", "INVALID_DATETIME_FORMAT"},
+		{"URL", "https://example.com/a", "https://example.com/a", ""},
+		{"URL", "example.com", "", "INVALID_URL"},
+		{"Email", "ada@example.com", "ada@example.com", ""},
+		{"Email", "Ada <ada@example.com>", "", "INVALID_EMAIL"},
+		{"IP Address", "2001:db8::0:1", "2001:db8::1", ""},
+		{"IP Address", "10.0.0", "", "INVALID_IP_ADDRESS"},
+		{"Reference", "123", "123", ""},
+		{"Reference", "HW-1", "HW-1", ""},
+		{"User", "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077", "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077", ""},
+		{"User", "ada@example.com", "ada@example.com", ""},
+		{"Version", "10001", "10001", ""},
+		{"Project", "PROJ", "", "INVALID_PROJECT"},
+		{"Group", "jira-admins", "jira-admins", ""},
+		{"Textarea", " multi\nline ", "multi\nline", ""},
+		{"Text", []int{1}, "", "INVALID_VALUE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dataType, func(t *testing.T) {
+			got, err := normalizeValue(&AttributeMetadata{Name: "field", DataType: tt.dataType}, tt.value)
+			if tt.wantCode != "" {
+				if err == nil || err.Code != tt.wantCode {
+					t.Fatalf("normalizeValue(%v) error = %v, want code %s", tt.value, err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("normalizeValue(%v) = %q, %v, want %q", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolvePropertyCardinality(t *testing.T) {
+	pr := &PropertyResolver{}
+	multi := &AttributeMetadata{ID: "6", Name: "Tags", DataType: "Select", Editable: true, MinCardinality: 2, MaxCardinality: 3,
+		SelectOptions: []string{"Linux", "Mac", "Windows"}}
+
+	resolved, err := pr.ResolveProperty(nil, multi, []interface{}{"linux", "", "mac"})
+	if err != nil {
+		t.Fatalf("ResolveProperty failed: %v", err)
+	}
+	if !reflect.DeepEqual(resolved.Value, []string{"Linux", "Mac"}) || !reflect.DeepEqual(resolved.Values(), []string{"Linux", "Mac"}) {
+		t.Errorf("resolved = %v", resolved.Value)
+	}
+
+	if err := pr.ValidateProperty(multi, []string{"Linux"}); err == nil || err.Code != "TOO_FEW_VALUES" {
+		t.Errorf("one value error = %v, want TOO_FEW_VALUES", err)
+	}
+	if err := pr.ValidateProperty(multi, []string{"Linux", "Mac", "Windows", "Linux"}); err == nil || err.Code != "TOO_MANY_VALUES" {
+		t.Errorf("four values error = %v, want TOO_MANY_VALUES", err)
+	}
+
+	single := &AttributeMetadata{ID: "2", Name: "Name", DataType: "Text", Editable: true, MaxCardinality: 1}
+	resolved, _ = pr.ResolveProperty(nil, single, "laptop")
+	if resolved.Value != "laptop" {
+		t.Errorf("single value = %#v, want a plain string", resolved.Value)
+	}
+}
+
+func TestDataTypeOf(t *testing.T) {
+	tests := []struct {
+		attr *models.ObjectTypeAttributeScheme
+		want string
+	}{
+		{&models.ObjectTypeAttributeScheme{DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: 11}}, "IP Address"},
+		{&models.ObjectTypeAttributeScheme{DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: 3, Name: "Float"}}, "Float"},
+		{&models.ObjectTypeAttributeScheme{}, "Text"},
+		{&models.ObjectTypeAttributeScheme{Type: 1, ReferenceObjectTypeID: "5"}, "Reference"},
+		{&models.ObjectTypeAttributeScheme{Type: 4}, "Group"},
+		{&models.ObjectTypeAttributeScheme{Type: 7}, "Status"},
+	}
+
+	for _, tt := range tests {
+		if got := dataTypeOf(tt.attr); got != tt.want {
+			t.Errorf("dataTypeOf(%+v) = %s, want %s", tt.attr, got, tt.want)
+		}
+	}
+}
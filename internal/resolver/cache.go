@@ -6,22 +6,37 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
 	"github.com/aaronsb/atlassian-assets/internal/logger"
 )
 
 // PersistentCacheEntry represents a cached resolver entry with metadata
 type PersistentCacheEntry struct {
-	WorkspaceID string                `json:"workspace_id"`
-	SiteURL     string                `json:"site_url"`
-	Schemas     map[string]*EntityInfo `json:"schemas"`
-	SchemasByName map[string]*EntityInfo `json:"schemas_by_name"`
-	ObjectTypes map[string]*EntityInfo `json:"object_types"`
-	TypesByName map[string]*EntityInfo `json:"types_by_name"`
-	CachedAt    time.Time             `json:"cached_at"`
-	ExpiresAt   time.Time             `json:"expires_at"`
-	Version     int                   `json:"version"`
+	WorkspaceID    string                 `json:"workspace_id"`
+	SiteURL        string                 `json:"site_url"`
+	Schemas        map[string]*EntityInfo `json:"schemas"`
+	SchemasByName  map[string]*EntityInfo `json:"schemas_by_name"`
+	ObjectTypes    map[string]*EntityInfo `json:"object_types"`
+	TypesByName    map[string]*EntityInfo `json:"types_by_name"`
+	Objects        map[string]*EntityInfo `json:"objects,omitempty"`
+	ObjectsByKey   map[string]*EntityInfo `json:"objects_by_key,omitempty"`
+	ObjectsByLabel map[string]*EntityInfo `json:"objects_by_label,omitempty"`
+	CachedAt       time.Time              `json:"cached_at"`
+	ExpiresAt      time.Time              `json:"expires_at"`
+	Version        int                    `json:"version"`
+
+	Attributes map[string]*AttributeCacheEntry `json:"attributes,omitempty"` // By object type ID
+}
+
+// AttributeCacheEntry holds the attribute definitions of an object type. Entries expire
+// with the cache TTL and are dropped when their schema changes.
+type AttributeCacheEntry struct {
+	SchemaID   string                              `json:"schema_id,omitempty"`
+	Attributes []*models.ObjectTypeAttributeScheme `json:"attributes"`
+	CachedAt   time.Time                           `json:"cached_at"`
 }
 
 // DiskCache handles persistent caching of resolver data
@@ -52,13 +67,38 @@ func (dc *DiskCache) getCacheKey(workspaceID, siteURL string) string {
 	return fmt.Sprintf("workspace_%x.json", hasher.Sum(nil))
 }
 
+// getDirectoryFilePath returns the path of the workspace's user and group cache
+func (dc *DiskCache) getDirectoryFilePath(workspaceID, siteURL string) string {
+	return filepath.Join(dc.cacheDir, "directory_"+strings.TrimPrefix(dc.getCacheKey(workspaceID, siteURL), "workspace_"))
+}
+
 // getCacheFilePath returns the full path to the cache file
 func (dc *DiskCache) getCacheFilePath(workspaceID, siteURL string) string {
 	return filepath.Join(dc.cacheDir, dc.getCacheKey(workspaceID, siteURL))
 }
 
+// Expired reports whether the entry is past its TTL
+func (e *PersistentCacheEntry) Expired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
 // LoadCache loads cached resolver data from disk
 func (dc *DiskCache) LoadCache(workspaceID, siteURL string) (*PersistentCacheEntry, error) {
+	entry, err := dc.readCache(workspaceID, siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if cache has expired
+	if entry.Expired() {
+		return nil, fmt.Errorf("cache has expired")
+	}
+
+	return entry, nil
+}
+
+// readCache reads cached resolver data from disk whether or not it has expired
+func (dc *DiskCache) readCache(workspaceID, siteURL string) (*PersistentCacheEntry, error) {
 	filePath := dc.getCacheFilePath(workspaceID, siteURL)
 	
 	// Check if cache file exists
@@ -78,11 +118,6 @@ func (dc *DiskCache) LoadCache(workspaceID, siteURL string) (*PersistentCacheEnt
 		return nil, fmt.Errorf("failed to parse cache file: %w", err)
 	}
 
-	// Check if cache has expired
-	if time.Now().After(entry.ExpiresAt) {
-		return nil, fmt.Errorf("cache has expired")
-	}
-
 	// Validate workspace ID matches
 	if entry.WorkspaceID != workspaceID {
 		return nil, fmt.Errorf("workspace ID mismatch in cache")
@@ -91,18 +126,36 @@ func (dc *DiskCache) LoadCache(workspaceID, siteURL string) (*PersistentCacheEnt
 	return &entry, nil
 }
 
-// SaveCache saves resolver data to disk cache
+// modTime returns when the cache file of a workspace was last written, the zero time
+// when there is none
+func (dc *DiskCache) modTime(workspaceID, siteURL string) time.Time {
+	info, err := os.Stat(dc.getCacheFilePath(workspaceID, siteURL))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// SaveCache saves resolver data to disk cache. The entry expires a TTL after the
+// schemas and object types were loaded, however often the object index or attributes
+// are saved; an entry saved before they were ever loaded has expired already.
 func (dc *DiskCache) SaveCache(workspaceID, siteURL string, cache *ResolverCache) error {
+	cachedAt := cache.lastRefresh
+
 	entry := &PersistentCacheEntry{
-		WorkspaceID:   workspaceID,
-		SiteURL:       siteURL,
-		Schemas:       make(map[string]*EntityInfo),
-		SchemasByName: make(map[string]*EntityInfo),
-		ObjectTypes:   make(map[string]*EntityInfo),
-		TypesByName:   make(map[string]*EntityInfo),
-		CachedAt:      time.Now(),
-		ExpiresAt:     time.Now().Add(dc.ttl),
-		Version:       1,
+		WorkspaceID:    workspaceID,
+		SiteURL:        siteURL,
+		Schemas:        make(map[string]*EntityInfo),
+		SchemasByName:  make(map[string]*EntityInfo),
+		ObjectTypes:    make(map[string]*EntityInfo),
+		TypesByName:    make(map[string]*EntityInfo),
+		Objects:        make(map[string]*EntityInfo),
+		ObjectsByKey:   make(map[string]*EntityInfo),
+		ObjectsByLabel: make(map[string]*EntityInfo),
+		Attributes:     make(map[string]*AttributeCacheEntry),
+		CachedAt:       cachedAt,
+		ExpiresAt:      cachedAt.Add(dc.ttl),
+		Version:        3,
 	}
 
 	// Copy cache data (thread-safe copy)
@@ -118,6 +171,18 @@ func (dc *DiskCache) SaveCache(workspaceID, siteURL string, cache *ResolverCache
 	for k, v := range cache.typesByName {
 		entry.TypesByName[k] = v
 	}
+	for k, v := range cache.objects {
+		entry.Objects[k] = v
+	}
+	for k, v := range cache.objectsByKey {
+		entry.ObjectsByKey[k] = v
+	}
+	for k, v := range cache.objectsByLabel {
+		entry.ObjectsByLabel[k] = v
+	}
+	for k, v := range cache.attributes {
+		entry.Attributes[k] = v
+	}
 
 	// Marshal to JSON
 	data, err := json.MarshalIndent(entry, "", "  ")
@@ -125,10 +190,13 @@ func (dc *DiskCache) SaveCache(workspaceID, siteURL string, cache *ResolverCache
 		return fmt.Errorf("failed to marshal cache data: %w", err)
 	}
 
-	// Write to file atomically
-	filePath := dc.getCacheFilePath(workspaceID, siteURL)
+	return writeCacheFile(dc.getCacheFilePath(workspaceID, siteURL), data)
+}
+
+// writeCacheFile writes a cache file atomically
+func writeCacheFile(filePath string, data []byte) error {
 	tempPath := filePath + ".tmp"
-	
+
 	if err := os.WriteFile(tempPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
@@ -141,6 +209,53 @@ func (dc *DiskCache) SaveCache(workspaceID, siteURL string, cache *ResolverCache
 	return nil
 }
 
+// DirectoryCacheEntry holds the Jira users and groups resolved for a workspace
+type DirectoryCacheEntry struct {
+	WorkspaceID string                     `json:"workspace_id"`
+	SiteURL     string                     `json:"site_url"`
+	Users       map[string]*DirectoryEntry `json:"users"`
+	Groups      map[string]*DirectoryEntry `json:"groups"`
+}
+
+// LoadDirectory loads the resolved users and groups of a workspace, dropping
+// entries older than the cache TTL
+func (dc *DiskCache) LoadDirectory(workspaceID, siteURL string) (*DirectoryCacheEntry, error) {
+	data, err := os.ReadFile(dc.getDirectoryFilePath(workspaceID, siteURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory cache: %w", err)
+	}
+
+	var entry DirectoryCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse directory cache: %w", err)
+	}
+	if entry.WorkspaceID != workspaceID {
+		return nil, fmt.Errorf("workspace ID mismatch in directory cache")
+	}
+
+	for _, entries := range []map[string]*DirectoryEntry{entry.Users, entry.Groups} {
+		for key, e := range entries {
+			if time.Since(e.ResolvedAt) > dc.ttl {
+				delete(entries, key)
+			}
+		}
+	}
+	return &entry, nil
+}
+
+// SaveDirectory saves the resolved users and groups of a workspace
+func (dc *DiskCache) SaveDirectory(workspaceID, siteURL string, entry *DirectoryCacheEntry) error {
+	entry.WorkspaceID = workspaceID
+	entry.SiteURL = siteURL
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal directory cache: %w", err)
+	}
+
+	return writeCacheFile(dc.getDirectoryFilePath(workspaceID, siteURL), data)
+}
+
 // ListCachedWorkspaces returns information about all cached workspaces
 func (dc *DiskCache) ListCachedWorkspaces() ([]*CacheInfo, error) {
 	files, err := os.ReadDir(dc.cacheDir)
@@ -150,7 +265,7 @@ func (dc *DiskCache) ListCachedWorkspaces() ([]*CacheInfo, error) {
 
 	var cacheInfos []*CacheInfo
 	for _, file := range files {
-		if !file.IsDir() && filepath.Ext(file.Name()) == ".json" {
+		if !file.IsDir() && strings.HasPrefix(file.Name(), "workspace_") && filepath.Ext(file.Name()) == ".json" {
 			info, err := dc.getCacheInfo(filepath.Join(dc.cacheDir, file.Name()))
 			if err != nil {
 				// Log error but continue with other files
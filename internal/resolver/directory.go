@@ -0,0 +1,255 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+	"github.com/aaronsb/atlassian-assets/internal/logger"
+)
+
+// ErrNotFound is returned when no Jira user or group matches a lookup
+var ErrNotFound = errors.New("not found")
+
+// Number of search results fetched per directory lookup
+const directorySearchLimit = 20
+
+// Atlassian account IDs are 24 hex digits, or a numeric prefix and a UUID
+var accountIDPattern = regexp.MustCompile(`(?i)^(?:[0-9a-f]{24}|\d+:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|qm:[0-9a-f-]+:[0-9a-f-]+)$`)
+
+// IsAccountID reports whether value is already an Atlassian account ID
+func IsAccountID(value string) bool {
+	return accountIDPattern.MatchString(value)
+}
+
+// DirectoryAPI is the subset of the client the directory looks users and groups up with
+type DirectoryAPI interface {
+	SearchUsers(ctx context.Context, query string, limit int) (*client.Response, error)
+	SearchGroups(ctx context.Context, query string, limit int) (*client.Response, error)
+	GetWorkspaceID() string
+	GetConfig() *config.Config
+}
+
+// DirectoryEntry is a resolved Jira user or group
+type DirectoryEntry struct {
+	ID          string    `json:"id"` // Account ID for users, group name for groups
+	DisplayName string    `json:"display_name"`
+	Email       string    `json:"email,omitempty"`
+	ResolvedAt  time.Time `json:"resolved_at"`
+}
+
+// Directory resolves emails and display names to Atlassian account IDs, and group
+// names to their exact spelling. Resolutions are kept next to the resolver's disk cache.
+type Directory struct {
+	api       DirectoryAPI
+	diskCache *DiskCache
+	ttl       time.Duration
+
+	mu     sync.Mutex
+	loaded bool
+	users  map[string]*DirectoryEntry // lowercase email or display name -> user
+	groups map[string]*DirectoryEntry // lowercase group name -> group
+}
+
+// NewDirectory creates a user and group directory for the client's workspace
+func NewDirectory(api DirectoryAPI) *Directory {
+	cfg := api.GetConfig()
+
+	// Without a TTL cached entries would expire at once, so skip the disk cache
+	var diskCache *DiskCache
+	if ttl := cfg.GetCacheTTL(); ttl > 0 {
+		if cacheDir, err := cfg.GetCacheDir(); err == nil {
+			if dc, err := NewDiskCache(cacheDir, ttl); err == nil {
+				diskCache = dc
+			}
+		}
+	}
+
+	return &Directory{
+		api:       api,
+		diskCache: diskCache,
+		ttl:       cfg.GetCacheTTL(),
+		users:     make(map[string]*DirectoryEntry),
+		groups:    make(map[string]*DirectoryEntry),
+	}
+}
+
+// ResolveUser returns the account ID of the user with the given email address or
+// display name. Account IDs are returned unchanged.
+func (d *Directory) ResolveUser(ctx context.Context, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if IsAccountID(value) {
+		return value, nil
+	}
+
+	key := strings.ToLower(value)
+	if entry := d.cached(d.users, key); entry != nil {
+		return entry.ID, nil
+	}
+
+	response, err := d.api.SearchUsers(ctx, value, directorySearchLimit)
+	if err != nil {
+		return "", fmt.Errorf("user search failed: %w", err)
+	}
+	if !response.Success {
+		return "", fmt.Errorf("user search failed: %s", response.Error)
+	}
+
+	var users []*models.UserScheme
+	if data, ok := response.Data.(map[string]interface{}); ok {
+		users, _ = data["users"].([]*models.UserScheme)
+	}
+
+	user, err := matchUser(value, users)
+	if err != nil {
+		return "", err
+	}
+
+	d.store(d.users, key, &DirectoryEntry{ID: user.AccountID, DisplayName: user.DisplayName, Email: user.EmailAddress})
+	return user.AccountID, nil
+}
+
+// ResolveGroup returns the exact name of the Jira group matching value case-insensitively
+func (d *Directory) ResolveGroup(ctx context.Context, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	key := strings.ToLower(value)
+	if entry := d.cached(d.groups, key); entry != nil {
+		return entry.ID, nil
+	}
+
+	response, err := d.api.SearchGroups(ctx, value, directorySearchLimit)
+	if err != nil {
+		return "", fmt.Errorf("group search failed: %w", err)
+	}
+	if !response.Success {
+		return "", fmt.Errorf("group search failed: %s", response.Error)
+	}
+
+	var groups []*models.GroupDetailScheme
+	if data, ok := response.Data.(map[string]interface{}); ok {
+		groups, _ = data["groups"].([]*models.GroupDetailScheme)
+	}
+
+	var names []string
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		if strings.EqualFold(group.Name, value) {
+			d.store(d.groups, key, &DirectoryEntry{ID: group.Name, DisplayName: group.Name})
+			return group.Name, nil
+		}
+		names = append(names, group.Name)
+	}
+
+	return "", fmt.Errorf("no Jira group named '%s'%s: %w", value, suggest(names), ErrNotFound)
+}
+
+// matchUser picks the single user matching an email address or display name
+func matchUser(value string, users []*models.UserScheme) (*models.UserScheme, error) {
+	byEmail := strings.Contains(value, "@")
+
+	var people, exact []*models.UserScheme
+	var names []string
+	for _, user := range users {
+		if user == nil || user.AccountType == "app" {
+			continue
+		}
+		people = append(people, user)
+		names = append(names, user.DisplayName)
+		if (byEmail && strings.EqualFold(user.EmailAddress, value)) || (!byEmail && strings.EqualFold(user.DisplayName, value)) {
+			exact = append(exact, user)
+		}
+	}
+
+	// Sites that hide email addresses still match on them, so a single result for an
+	// email search is the user
+	if byEmail && len(exact) == 0 && len(people) == 1 {
+		return people[0], nil
+	}
+
+	switch len(exact) {
+	case 0:
+		return nil, fmt.Errorf("no Jira user matches '%s'%s: %w", value, suggest(names), ErrNotFound)
+	case 1:
+		return exact[0], nil
+	}
+
+	var candidates []string
+	for _, user := range exact {
+		candidates = append(candidates, fmt.Sprintf("%s (%s)", user.DisplayName, user.AccountID))
+	}
+	return nil, fmt.Errorf("'%s' matches %d Jira users: %s; use the email address or account ID",
+		value, len(exact), strings.Join(candidates, ", "))
+}
+
+// suggest lists near matches for a not-found error
+func suggest(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	if len(names) > 5 {
+		names = append(names[:5:5], "...")
+	}
+	return " (did you mean " + strings.Join(names, ", ") + "?)"
+}
+
+// cached returns an unexpired entry, loading the disk cache on first use
+func (d *Directory) cached(entries map[string]*DirectoryEntry, key string) *DirectoryEntry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.loadFromDisk()
+	entry, ok := entries[key]
+	if !ok || (d.ttl > 0 && time.Since(entry.ResolvedAt) > d.ttl) {
+		return nil
+	}
+	return entry
+}
+
+// store records a resolution and persists the directory
+func (d *Directory) store(entries map[string]*DirectoryEntry, key string, entry *DirectoryEntry) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry.ResolvedAt = time.Now()
+	entries[key] = entry
+
+	if d.diskCache == nil {
+		return
+	}
+	err := d.diskCache.SaveDirectory(d.api.GetWorkspaceID(), d.api.GetConfig().GetBaseURL(), &DirectoryCacheEntry{
+		Users:  d.users,
+		Groups: d.groups,
+	})
+	if err != nil {
+		logger.Warning("failed to save directory cache to disk: %v", err)
+	}
+}
+
+// loadFromDisk reads the persisted directory once; callers hold d.mu
+func (d *Directory) loadFromDisk() {
+	if d.loaded || d.diskCache == nil {
+		d.loaded = true
+		return
+	}
+	d.loaded = true
+
+	entry, err := d.diskCache.LoadDirectory(d.api.GetWorkspaceID(), d.api.GetConfig().GetBaseURL())
+	if err != nil {
+		return
+	}
+	for k, v := range entry.Users {
+		d.users[k] = v
+	}
+	for k, v := range entry.Groups {
+		d.groups[k] = v
+	}
+}
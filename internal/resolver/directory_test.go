@@ -0,0 +1,86 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+)
+
+// fakeDirectoryAPI serves users and groups and counts the searches it receives
+type fakeDirectoryAPI struct {
+	config   *config.Config
+	users    []*models.UserScheme
+	groups   []*models.GroupDetailScheme
+	searches int
+}
+
+func (f *fakeDirectoryAPI) SearchUsers(ctx context.Context, query string, limit int) (*client.Response, error) {
+	f.searches++
+	var users []*models.UserScheme
+	for _, user := range f.users {
+		if strings.Contains(strings.ToLower(user.EmailAddress+" "+user.DisplayName), strings.ToLower(query)) {
+			users = append(users, user)
+		}
+	}
+	return client.NewSuccessResponse(map[string]interface{}{"users": users}), nil
+}
+
+func (f *fakeDirectoryAPI) SearchGroups(ctx context.Context, query string, limit int) (*client.Response, error) {
+	f.searches++
+	return client.NewSuccessResponse(map[string]interface{}{"groups": f.groups}), nil
+}
+
+func (f *fakeDirectoryAPI) GetWorkspaceID() string     { return "ws-1" }
+func (f *fakeDirectoryAPI) GetConfig() *config.Config { return f.config }
+
+func TestDirectoryCachesResolutions(t *testing.T) {
+	api := &fakeDirectoryAPI{
+		config: &config.Config{Host: "https://example.atlassian.net", CacheDir: t.TempDir(), CacheTTLHours: 1},
+		users: []*models.UserScheme{
+			{AccountID: "5b10ac8d82e05b22cc7d4ef5", DisplayName: "Ada Lovelace", EmailAddress: "ada@example.com"},
+			{AccountID: "5b10ac8d82e05b22cc7d4ef6", DisplayName: "Ada Byron", AccountType: "app"},
+		},
+		groups: []*models.GroupDetailScheme{{Name: "it-ops"}},
+	}
+	ctx := context.Background()
+
+	id, err := NewDirectory(api).ResolveUser(ctx, "Ada Lovelace")
+	if err != nil || id != "5b10ac8d82e05b22cc7d4ef5" {
+		t.Fatalf("ResolveUser = %q, %v", id, err)
+	}
+	if name, err := NewDirectory(api).ResolveGroup(ctx, "IT-OPS"); err != nil || name != "it-ops" {
+		t.Fatalf("ResolveGroup = %q, %v", name, err)
+	}
+
+	// A new directory reads earlier resolutions from disk instead of searching again
+	api.searches = 0
+	directory := NewDirectory(api)
+	if id, _ := directory.ResolveUser(ctx, "ada lovelace"); id != "5b10ac8d82e05b22cc7d4ef5" {
+		t.Errorf("cached ResolveUser = %q", id)
+	}
+	if name, _ := directory.ResolveGroup(ctx, "it-ops"); name != "it-ops" {
+		t.Errorf("cached ResolveGroup = %q", name)
+	}
+	if id, _ := directory.ResolveUser(ctx, "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077"); id != "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077" {
+		t.Errorf("account ID was not passed through, got %q", id)
+	}
+	if api.searches != 0 {
+		t.Errorf("made %d searches, want none", api.searches)
+	}
+
+	// App accounts are never matched, and misses are not cached
+	if _, err := directory.ResolveUser(ctx, "Ada Byron"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("app account error = %v, want ErrNotFound", err)
+	}
+
+	// The directory cache is not listed as a workspace cache
+	infos, err := directory.diskCache.ListCachedWorkspaces()
+	if err != nil || len(infos) != 0 {
+		t.Errorf("ListCachedWorkspaces = %v, %v", infos, err)
+	}
+}
@@ -2,14 +2,18 @@ package resolver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
 	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/config"
 	"github.com/aaronsb/atlassian-assets/internal/logger"
 )
 
@@ -31,37 +35,73 @@ type EntityInfo struct {
 	SchemaID    string    `json:"schema_id,omitempty"`
 	ParentID    string    `json:"parent_id,omitempty"`
 	LastUpdated time.Time `json:"last_updated"`
+
+	// Schema change markers, compared with the API on refresh
+	Updated         string `json:"updated,omitempty"`
+	ObjectTypeCount int    `json:"object_type_count,omitempty"`
+	Stale           bool   `json:"stale,omitempty"` // Invalidated by a change made through this tool
 }
 
 // ResolverCache holds cached resolution data
 type ResolverCache struct {
-	mu            sync.RWMutex
-	schemas       map[string]*EntityInfo  // schema_id -> EntityInfo
-	schemasByName map[string]*EntityInfo  // schema_name -> EntityInfo
-	objectTypes   map[string]*EntityInfo  // object_type_id -> EntityInfo
-	typesByName   map[string]*EntityInfo  // "schema_name/object_type_name" -> EntityInfo
-	objects       map[string]*EntityInfo  // object_id -> EntityInfo
-	objectsByKey  map[string]*EntityInfo  // "schema_name/object_key" -> EntityInfo
-	lastRefresh   time.Time
-	ttl           time.Duration
+	mu             sync.RWMutex
+	schemas        map[string]*EntityInfo // schema_id -> EntityInfo
+	schemasByName  map[string]*EntityInfo // schema_name -> EntityInfo
+	objectTypes    map[string]*EntityInfo // object_type_id -> EntityInfo
+	typesByName    map[string]*EntityInfo // "schema_name/object_type_name" -> EntityInfo
+	objects        map[string]*EntityInfo // object_id -> EntityInfo
+	objectsByKey   map[string]*EntityInfo // "schema_name/object_key" -> EntityInfo
+	objectsByLabel map[string]*EntityInfo // "schema_name/label" -> EntityInfo, for labels looked up before
+	lastRefresh    time.Time
+	ttl            time.Duration
+
+	reloadedSchemas []string                        // Schemas whose object types the last sync reloaded
+	attributes      map[string]*AttributeCacheEntry // object_type_id -> attribute definitions
+}
+
+// AssetsAPI is the subset of the assets client the resolver depends on.
+// *client.AssetsClient satisfies it, as does the shared command-layer client interface.
+type AssetsAPI interface {
+	ListSchemas(ctx context.Context) (*client.Response, error)
+	GetObjectTypes(ctx context.Context, schemaID string) (*client.Response, error)
+	GetObject(ctx context.Context, objectID string) (*client.Response, error)
+	GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error)
+	SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error)
+	GetWorkspaceID() string
+	GetConfig() *config.Config
 }
 
+// Object keys are the schema's key prefix, a hyphen and a number (e.g. HW-42)
+var objectKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-\d+$`)
+
+// Number of matches fetched when looking an object up, to describe an ambiguous match
+const maxLookupCandidates = 5
+
+// errObjectNotFound is returned by lookupObject when nothing matches
+var errObjectNotFound = errors.New("object not found")
+
 // Resolver provides bidirectional ID resolution between human names and internal IDs
 type Resolver struct {
-	client    *client.AssetsClient
+	client    AssetsAPI
 	cache     *ResolverCache
 	diskCache *DiskCache
+	onRefresh []func()
+
+	// When the disk cache was last read or written by this resolver, in Unix nanoseconds
+	diskModTime atomic.Int64
 }
 
 // NewResolver creates a new ID resolver
-func NewResolver(client *client.AssetsClient) *Resolver {
+func NewResolver(client AssetsAPI) *Resolver {
 	config := client.GetConfig()
 	
-	// Initialize disk cache
+	// Initialize disk cache; without a TTL its entries would expire at once
 	var diskCache *DiskCache
-	if cacheDir, err := config.GetCacheDir(); err == nil {
-		if dc, err := NewDiskCache(cacheDir, config.GetCacheTTL()); err == nil {
-			diskCache = dc
+	if ttl := config.GetCacheTTL(); ttl > 0 {
+		if cacheDir, err := config.GetCacheDir(); err == nil {
+			if dc, err := NewDiskCache(cacheDir, ttl); err == nil {
+				diskCache = dc
+			}
 		}
 	}
 
@@ -69,52 +109,70 @@ func NewResolver(client *client.AssetsClient) *Resolver {
 		client:    client,
 		diskCache: diskCache,
 		cache: &ResolverCache{
-			schemas:       make(map[string]*EntityInfo),
-			schemasByName: make(map[string]*EntityInfo),
-			objectTypes:   make(map[string]*EntityInfo),
-			typesByName:   make(map[string]*EntityInfo),
-			objects:       make(map[string]*EntityInfo),
-			objectsByKey:  make(map[string]*EntityInfo),
-			ttl:           5 * time.Minute, // In-memory cache for 5 minutes
+			schemas:        make(map[string]*EntityInfo),
+			schemasByName:  make(map[string]*EntityInfo),
+			objectTypes:    make(map[string]*EntityInfo),
+			typesByName:    make(map[string]*EntityInfo),
+			objects:        make(map[string]*EntityInfo),
+			objectsByKey:   make(map[string]*EntityInfo),
+			objectsByLabel: make(map[string]*EntityInfo),
+			attributes:     make(map[string]*AttributeCacheEntry),
+			ttl:            5 * time.Minute, // In-memory cache for 5 minutes
 		},
 	}
 }
 
-// RefreshCache refreshes the resolver cache with latest data from API
+// OnRefresh registers a callback invoked after every successful cache refresh.
+// Callbacks run without the cache lock held and must be registered before the
+// resolver is shared between goroutines.
+func (r *Resolver) OnRefresh(fn func()) {
+	r.onRefresh = append(r.onRefresh, fn)
+}
+
+// RefreshCache brings the resolver cache up to date with the API. Only schemas whose
+// updated timestamp or object type count changed, or that were invalidated, have
+// their object types reloaded.
 func (r *Resolver) RefreshCache(ctx context.Context) error {
-	// Try to load from disk cache first
-	if r.diskCache != nil {
-		if err := r.loadFromDiskCache(); err == nil {
-			return nil // Successfully loaded from disk
-		}
-		// If disk cache failed, continue with API refresh
+	return r.refresh(ctx, true)
+}
+
+// refresh updates the cache and runs the refresh callbacks. Unless forced, a disk
+// cache within its TTL and with no stale schemas is used without calling the API.
+func (r *Resolver) refresh(ctx context.Context, force bool) error {
+	if err := r.refreshCache(ctx, force); err != nil {
+		return err
+	}
+
+	for _, fn := range r.onRefresh {
+		fn()
+	}
+
+	return nil
+}
+
+// refreshCache loads the disk cache and, when it is out of date or the refresh is
+// forced, syncs it with the schema list from the API
+func (r *Resolver) refreshCache(ctx context.Context, force bool) error {
+	// An expired disk cache still tells which schemas are unchanged
+	if fresh, err := r.loadFromDiskCache(); err == nil && fresh && !force {
+		return nil
 	}
 
 	// Add timeout to prevent hanging
 	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer cancel()
 
-	r.cache.mu.Lock()
-	defer r.cache.mu.Unlock()
-
-	// Clear existing cache
-	r.cache.schemas = make(map[string]*EntityInfo)
-	r.cache.schemasByName = make(map[string]*EntityInfo)
-	r.cache.objectTypes = make(map[string]*EntityInfo)
-	r.cache.typesByName = make(map[string]*EntityInfo)
-
-	// Load schemas
-	if err := r.loadSchemas(timeoutCtx); err != nil {
+	schemas, err := r.fetchSchemas(timeoutCtx)
+	if err != nil {
 		return fmt.Errorf("failed to load schemas: %w", err)
 	}
 
-	// Load object types for all schemas
-	if err := r.loadObjectTypes(timeoutCtx); err != nil {
-		return fmt.Errorf("failed to load object types: %w", err)
-	}
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
 
+	r.syncSchemas(timeoutCtx, schemas)
 	r.cache.lastRefresh = time.Now()
-	
+
 	// Save to disk cache
 	if r.diskCache != nil {
 		r.saveToDiskCache()
@@ -123,70 +181,226 @@ func (r *Resolver) RefreshCache(ctx context.Context) error {
 	return nil
 }
 
-// loadSchemas loads all schemas into cache
-func (r *Resolver) loadSchemas(ctx context.Context) error {
+// fetchSchemas lists the schemas of the workspace
+func (r *Resolver) fetchSchemas(ctx context.Context) ([]*models.ObjectSchemaScheme, error) {
 	response, err := r.client.ListSchemas(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !response.Success {
-		return fmt.Errorf("API error: %s", response.Error)
+		return nil, fmt.Errorf("API error: %s", response.Error)
 	}
 
 	data, ok := response.Data.(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("unexpected response format, got type: %T", response.Data)
+		return nil, fmt.Errorf("unexpected response format, got type: %T", response.Data)
 	}
 
 	schemasValue := data["schemas"]
 	schemas, ok := schemasValue.([]*models.ObjectSchemaScheme)
 	if !ok {
-		return fmt.Errorf("schemas type mismatch: expected []*models.ObjectSchemaScheme, got %T", schemasValue)
+		return nil, fmt.Errorf("schemas type mismatch: expected []*models.ObjectSchemaScheme, got %T", schemasValue)
 	}
 
+	return schemas, nil
+}
+
+// syncSchemas reloads the object types of new, changed and stale schemas and drops
+// the schemas that no longer exist. The caller must hold the cache write lock.
+func (r *Resolver) syncSchemas(ctx context.Context, schemas []*models.ObjectSchemaScheme) {
+	r.cache.reloadedSchemas = nil
+
+	current := make(map[string]bool)
 	for _, schema := range schemas {
 		if schema == nil {
 			continue
 		}
+		current[schema.ID] = true
 
-		id := schema.ID
-		
-		name := schema.Name
-		
+		cached, exists := r.cache.schemas[schema.ID]
+		changed := !exists || cached.Name != schema.Name ||
+			cached.Updated != schema.Updated || cached.ObjectTypeCount != schema.ObjectTypeCount
+		if !changed && !cached.Stale {
+			continue
+		}
+
+		// Marking a schema stale already dropped its attributes, and those of a schema
+		// not loaded before were fetched since
+		if exists && changed {
+			r.dropAttributes(schema.ID)
+		}
+		r.dropSchema(schema.ID, false)
 		entity := &EntityInfo{
-			ID:          id,
-			Name:        name,
-			Type:        IDTypeSchema,
-			LastUpdated: time.Now(),
+			ID:              schema.ID,
+			Name:            schema.Name,
+			Type:            IDTypeSchema,
+			Updated:         schema.Updated,
+			ObjectTypeCount: schema.ObjectTypeCount,
+			LastUpdated:     time.Now(),
+		}
+		r.cache.schemas[schema.ID] = entity
+		r.cache.schemasByName[strings.ToLower(schema.Name)] = entity
+
+		logger.Info("Loading object types for schema %s (ID: %s)...", schema.Name, schema.ID)
+		if err := r.loadObjectTypesForSchema(ctx, schema.ID); err != nil {
+			// Keep the schema stale so that the next refresh tries again
+			logger.Error("failed to load object types for schema %s: %v", schema.ID, err)
+			entity.Stale = true
 		}
+		r.cache.reloadedSchemas = append(r.cache.reloadedSchemas, schema.ID)
+	}
 
-		r.cache.schemas[id] = entity
-		r.cache.schemasByName[strings.ToLower(name)] = entity
+	for id := range r.cache.schemas {
+		if !current[id] {
+			r.dropSchema(id, true)
+		}
 	}
 
-	return nil
+	logger.Info("Reloaded %d of %d schemas", len(r.cache.reloadedSchemas), len(current))
 }
 
-// loadObjectTypes loads object types for all schemas
-func (r *Resolver) loadObjectTypes(ctx context.Context) error {
-	schemaCount := len(r.cache.schemas)
-	logger.Info("Loading object types for %d schemas...", schemaCount)
-	
-	processed := 0
-	for schemaID := range r.cache.schemas {
-		processed++
-		logger.Info("Loading schema %d/%d (ID: %s)...", processed, schemaCount, schemaID)
-		
-		if err := r.loadObjectTypesForSchema(ctx, schemaID); err != nil {
-			// Log error but continue with other schemas
-			logger.Error("failed to load object types for schema %s: %v", schemaID, err)
-		} else {
-			logger.Info("SUCCESS: loaded object types for schema %s", schemaID)
+// dropSchema removes a schema and its object types from the cache, and its objects and
+// attributes as well when the schema no longer exists. The caller must hold the cache
+// write lock.
+func (r *Resolver) dropSchema(schemaID string, withObjects bool) {
+	if schema, exists := r.cache.schemas[schemaID]; exists {
+		delete(r.cache.schemasByName, strings.ToLower(schema.Name))
+		delete(r.cache.schemas, schemaID)
+	}
+
+	for _, index := range []map[string]*EntityInfo{r.cache.objectTypes, r.cache.typesByName} {
+		for key, entity := range index {
+			if entity.SchemaID == schemaID {
+				delete(index, key)
+			}
 		}
 	}
-	logger.Info("Finished loading object types for all schemas")
-	return nil
+
+	if withObjects {
+		r.dropAttributes(schemaID)
+		for _, index := range []map[string]*EntityInfo{r.cache.objects, r.cache.objectsByKey, r.cache.objectsByLabel} {
+			for key, entity := range index {
+				if entity.SchemaID == schemaID {
+					delete(index, key)
+				}
+			}
+		}
+	}
+}
+
+// InvalidateSchema marks a schema stale, in memory and in the disk cache, so that the
+// next refresh reloads its object types whatever its updated timestamp says. The
+// attributes cached for its object types are dropped.
+func (r *Resolver) InvalidateSchema(schemaID string) {
+	r.loadFromDiskCache()
+	r.markStale(schemaID, "")
+}
+
+// InvalidateObjectType invalidates the schema of an object type, and the cached
+// attributes of the object type even when its schema is unknown
+func (r *Resolver) InvalidateObjectType(objectTypeID string) {
+	r.loadFromDiskCache()
+
+	r.cache.mu.RLock()
+	schemaID := ""
+	if entity, exists := r.cache.objectTypes[objectTypeID]; exists {
+		schemaID = entity.SchemaID
+	} else if entry, exists := r.cache.attributes[objectTypeID]; exists {
+		schemaID = entry.SchemaID
+	}
+	r.cache.mu.RUnlock()
+
+	r.markStale(schemaID, objectTypeID)
+}
+
+// markStale marks a cached schema stale, drops the attributes cached for its object
+// types and saves the change to disk, where every resolver of the workspace picks it up
+func (r *Resolver) markStale(schemaID, objectTypeID string) {
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
+
+	_, changed := r.cache.attributes[objectTypeID]
+	delete(r.cache.attributes, objectTypeID)
+	if schemaID != "" && r.dropAttributes(schemaID) > 0 {
+		changed = true
+	}
+	if schema, exists := r.cache.schemas[schemaID]; exists && !schema.Stale {
+		schema.Stale = true
+		changed = true
+	}
+
+	if changed {
+		r.saveToDiskCache()
+	}
+}
+
+// dropAttributes removes the cached attributes of a schema's object types and returns
+// how many were removed. The caller must hold the cache write lock.
+func (r *Resolver) dropAttributes(schemaID string) int {
+	dropped := 0
+	for objectTypeID, entry := range r.cache.attributes {
+		if entry.SchemaID == schemaID {
+			delete(r.cache.attributes, objectTypeID)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// GetObjectTypeAttributes returns the attributes of an object type, from the disk cache
+// when it holds them and from the API otherwise. The response has the same shape as the
+// client's.
+func (r *Resolver) GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	if r.diskCache == nil {
+		return r.client.GetObjectTypeAttributes(ctx, objectTypeID)
+	}
+
+	// Pick up attributes other resolvers of the workspace have saved
+	r.loadFromDiskCache()
+
+	r.cache.mu.RLock()
+	entry, exists := r.cache.attributes[objectTypeID]
+	r.cache.mu.RUnlock()
+	if exists && time.Since(entry.CachedAt) < r.diskCache.ttl {
+		return attributesResponse(objectTypeID, entry.Attributes), nil
+	}
+
+	response, err := r.client.GetObjectTypeAttributes(ctx, objectTypeID)
+	if err != nil || !response.Success {
+		return response, err
+	}
+	data, _ := response.Data.(map[string]interface{})
+	attributes, ok := data["attributes"].([]*models.ObjectTypeAttributeScheme)
+	if !ok {
+		return response, nil
+	}
+
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
+
+	entry = &AttributeCacheEntry{Attributes: attributes, CachedAt: time.Now()}
+	if objectType, exists := r.cache.objectTypes[objectTypeID]; exists {
+		entry.SchemaID = objectType.SchemaID
+	}
+	for _, attribute := range attributes {
+		if entry.SchemaID == "" && attribute != nil && attribute.ObjectType != nil {
+			entry.SchemaID = attribute.ObjectType.ObjectSchemaID
+		}
+	}
+	r.cache.attributes[objectTypeID] = entry
+	r.saveToDiskCache()
+
+	return response, nil
+}
+
+// attributesResponse builds the client's response for a list of attributes
+func attributesResponse(objectTypeID string, attributes []*models.ObjectTypeAttributeScheme) *client.Response {
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object_type_id": objectTypeID,
+		"attributes":     attributes,
+		"count":          len(attributes),
+	})
 }
 
 // loadObjectTypesForSchema loads object types for a specific schema
@@ -255,10 +469,23 @@ func (r *Resolver) needsRefresh() bool {
 	return time.Since(r.cache.lastRefresh) > r.cache.ttl
 }
 
-// ensureCache ensures cache is fresh
+// diskCacheChanged reports whether the disk cache was written since this resolver last
+// read or wrote it, as when another resolver marks a schema stale after a change
+func (r *Resolver) diskCacheChanged() bool {
+	if r.diskCache == nil {
+		return false
+	}
+
+	modTime := r.diskCache.modTime(r.client.GetWorkspaceID(), r.client.GetConfig().GetBaseURL())
+	return !modTime.IsZero() && modTime.UnixNano() != r.diskModTime.Load()
+}
+
+// ensureCache ensures cache is fresh. A long-lived resolver also reloads the disk
+// cache when another resolver has changed it, so that it never answers from schemas
+// invalidated elsewhere.
 func (r *Resolver) ensureCache(ctx context.Context) error {
-	if r.needsRefresh() {
-		return r.RefreshCache(ctx)
+	if r.needsRefresh() || r.diskCacheChanged() {
+		return r.refresh(ctx, false)
 	}
 	return nil
 }
@@ -382,11 +609,12 @@ func (r *Resolver) GetObjectInfo(ctx context.Context, objectID string) (*EntityI
 		return nil, fmt.Errorf("unexpected response format: expected *models.ObjectScheme, got %T", response.Data)
 	}
 
-	// Extract object information
-	id := object.ID
-	label := object.Label
-	objectKey := object.ObjectKey
+	return r.cacheObject(object, false), nil
+}
 
+// cacheObject adds an object to the in-memory index under its ID and its
+// "schema/key", and under its "schema/label" when it was looked up by label
+func (r *Resolver) cacheObject(object *models.ObjectScheme, byLabel bool) *EntityInfo {
 	// Extract object type and schema info
 	var schemaID, objectTypeID string
 	if object.ObjectType != nil {
@@ -395,33 +623,42 @@ func (r *Resolver) GetObjectInfo(ctx context.Context, objectID string) (*EntityI
 	}
 
 	entity := &EntityInfo{
-		ID:          id,
-		Name:        objectKey,
-		DisplayName: label,
+		ID:          object.ID,
+		Name:        object.ObjectKey,
+		DisplayName: object.Label,
 		Type:        IDTypeObject,
 		SchemaID:    schemaID,
 		ParentID:    objectTypeID,
 		LastUpdated: time.Now(),
 	}
 
-	// Cache the object info
 	r.cache.mu.Lock()
-	r.cache.objects[id] = entity
-	if objectKey != "" && schemaID != "" {
-		// Resolve schema name for compound key
-		if schemaEntity, exists := r.cache.schemas[schemaID]; exists {
-			compoundKey := fmt.Sprintf("%s/%s", strings.ToLower(schemaEntity.Name), strings.ToLower(objectKey))
-			r.cache.objectsByKey[compoundKey] = entity
+	defer r.cache.mu.Unlock()
+
+	r.cache.objects[entity.ID] = entity
+	if schemaEntity, exists := r.cache.schemas[schemaID]; exists {
+		if entity.Name != "" {
+			r.cache.objectsByKey[compoundName(schemaEntity.Name, entity.Name)] = entity
+		}
+		if byLabel && entity.DisplayName != "" {
+			r.cache.objectsByLabel[compoundName(schemaEntity.Name, entity.DisplayName)] = entity
 		}
 	}
-	r.cache.mu.Unlock()
 
-	return entity, nil
+	return entity
 }
 
-// ResolveObjectID resolves an object reference to its ID
-// Supports: numeric ID, object key, "schema/object_key" format
+// compoundName builds the lowercase "schema/name" index key
+func compoundName(schemaName, name string) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(schemaName), strings.ToLower(name))
+}
+
+// ResolveObjectID resolves an object reference to its ID.
+// Supports: numeric ID, object key, "schema/object_key" and "schema/label". Keys and
+// labels not yet in the index are looked up with AQL and kept in the disk cache.
 func (r *Resolver) ResolveObjectID(ctx context.Context, reference string) (string, error) {
+	reference = strings.TrimSpace(reference)
+
 	// Check if it's a numeric ID
 	if _, err := strconv.Atoi(reference); err == nil {
 		// Try to get the object info (this will fetch from API if not cached)
@@ -431,22 +668,43 @@ func (r *Resolver) ResolveObjectID(ctx context.Context, reference string) (strin
 		return reference, nil
 	}
 
-	// Check if it's a compound reference (schema/object_key)
-	if strings.Contains(reference, "/") {
+	// Keys and labels are indexed by schema name, so the schemas must be loaded
+	if err := r.ensureCache(ctx); err != nil {
+		return "", err
+	}
+
+	// Check if it's a compound reference (schema/object_key or schema/label)
+	if schemaPart, name, found := strings.Cut(reference, "/"); found {
+		if schemaPart == "" || name == "" {
+			return "", fmt.Errorf("invalid compound reference format: %s", reference)
+		}
+		schemaID, err := r.ResolveSchemaID(ctx, schemaPart)
+		if err != nil {
+			return "", err
+		}
+		schemaName, _ := r.ResolveSchemaName(ctx, schemaID)
+
 		r.cache.mu.RLock()
-		if entity, exists := r.cache.objectsByKey[strings.ToLower(reference)]; exists {
-			r.cache.mu.RUnlock()
-			return entity.ID, nil
+		entity, exists := r.cache.objectsByKey[compoundName(schemaName, name)]
+		if !exists {
+			entity, exists = r.cache.objectsByLabel[compoundName(schemaName, name)]
 		}
 		r.cache.mu.RUnlock()
-		
-		// Parse schema and object key
-		parts := strings.SplitN(reference, "/", 2)
-		if len(parts) != 2 {
-			return "", fmt.Errorf("invalid compound reference format: %s", reference)
+		if exists {
+			return entity.ID, nil
 		}
-		
-		return "", fmt.Errorf("compound reference '%s' not found in cache. Use numeric ID instead (e.g., '384') or access the object by ID first to cache it", reference)
+
+		if objectKeyPattern.MatchString(name) {
+			id, err := r.lookupObject(ctx, fmt.Sprintf(`objectSchemaId = %s AND Key = "%s"`, schemaID, escapeAQL(strings.ToUpper(name))), false)
+			if err == nil || !errors.Is(err, errObjectNotFound) {
+				return id, err
+			}
+		}
+		id, err := r.lookupObject(ctx, fmt.Sprintf(`objectSchemaId = %s AND Label = "%s"`, schemaID, escapeAQL(name)), true)
+		if errors.Is(err, errObjectNotFound) {
+			return "", fmt.Errorf("no object with key or label '%s' in schema %s", name, schemaName)
+		}
+		return id, err
 	}
 
 	// Try to find by object key across all cached objects first
@@ -459,8 +717,55 @@ func (r *Resolver) ResolveObjectID(ctx context.Context, reference string) (strin
 	}
 	r.cache.mu.RUnlock()
 
-	// Cache miss - provide helpful guidance to user
-	return "", fmt.Errorf("object key '%s' not found in cache. Use numeric ID instead (e.g., '384') or first access the object by ID to cache it", reference)
+	if !objectKeyPattern.MatchString(reference) {
+		return "", fmt.Errorf("'%s' is not an object ID or key; give a label with its schema, e.g. 'Hardware/%s'", reference, reference)
+	}
+
+	id, err := r.lookupObject(ctx, fmt.Sprintf(`Key = "%s"`, escapeAQL(strings.ToUpper(reference))), false)
+	if errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("no object with key '%s'", reference)
+	}
+	return id, err
+}
+
+// lookupObject finds the one object an AQL query matches, adds it to the index and
+// saves the index to disk
+func (r *Resolver) lookupObject(ctx context.Context, query string, byLabel bool) (string, error) {
+	response, err := r.client.SearchObjects(ctx, query, maxLookupCandidates)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up object: %w", err)
+	}
+	if !response.Success {
+		return "", fmt.Errorf("failed to look up object: %s", response.Error)
+	}
+
+	data, ok := response.Data.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("unexpected response format")
+	}
+	objects, _ := data["objects"].([]*models.ObjectScheme)
+
+	switch {
+	case len(objects) == 0:
+		return "", errObjectNotFound
+	case len(objects) > 1:
+		var candidates []string
+		for _, object := range objects {
+			candidates = append(candidates, fmt.Sprintf("%s (%s)", object.ObjectKey, object.Label))
+		}
+		return "", fmt.Errorf("reference matches several objects: %s; use the object key or ID", strings.Join(candidates, ", "))
+	}
+
+	entity := r.cacheObject(objects[0], byLabel)
+	r.cache.mu.RLock()
+	r.saveToDiskCache()
+	r.cache.mu.RUnlock()
+
+	return entity.ID, nil
+}
+
+func escapeAQL(value string) string {
+	return strings.ReplaceAll(value, `"`, `\"`)
 }
 
 // GetCacheStats returns statistics about the resolver cache
@@ -469,12 +774,13 @@ func (r *Resolver) GetCacheStats() map[string]interface{} {
 	defer r.cache.mu.RUnlock()
 
 	return map[string]interface{}{
-		"schemas":      len(r.cache.schemas),
-		"object_types": len(r.cache.objectTypes),
-		"objects":      len(r.cache.objects),
-		"last_refresh": r.cache.lastRefresh,
-		"ttl_minutes":  r.cache.ttl.Minutes(),
-		"needs_refresh": time.Since(r.cache.lastRefresh) > r.cache.ttl,
+		"schemas":          len(r.cache.schemas),
+		"object_types":     len(r.cache.objectTypes),
+		"objects":          len(r.cache.objects),
+		"last_refresh":     r.cache.lastRefresh,
+		"ttl_minutes":      r.cache.ttl.Minutes(),
+		"needs_refresh":    time.Since(r.cache.lastRefresh) > r.cache.ttl,
+		"reloaded_schemas": len(r.cache.reloadedSchemas),
 	}
 }
 
@@ -495,6 +801,19 @@ func (r *Resolver) ListResolvedSchemas(ctx context.Context) ([]*EntityInfo, erro
 	return schemas, nil
 }
 
+// CachedSchemas returns the schemas currently held in memory without triggering a refresh
+func (r *Resolver) CachedSchemas() []*EntityInfo {
+	r.cache.mu.RLock()
+	defer r.cache.mu.RUnlock()
+
+	schemas := make([]*EntityInfo, 0, len(r.cache.schemas))
+	for _, entity := range r.cache.schemas {
+		schemas = append(schemas, entity)
+	}
+
+	return schemas
+}
+
 // ListResolvedObjectTypes returns all cached object types for a schema
 func (r *Resolver) ListResolvedObjectTypes(ctx context.Context, schemaNameOrID string) ([]*EntityInfo, error) {
 	if err := r.ensureCache(ctx); err != nil {
@@ -519,19 +838,22 @@ func (r *Resolver) ListResolvedObjectTypes(ctx context.Context, schemaNameOrID s
 	return objectTypes, nil
 }
 
-// loadFromDiskCache loads cache data from disk
-func (r *Resolver) loadFromDiskCache() error {
+// loadFromDiskCache replaces the cache with the disk cache, expired or not, and reports
+// whether it is current: within its TTL and with no stale schemas
+func (r *Resolver) loadFromDiskCache() (bool, error) {
 	if r.diskCache == nil {
-		return fmt.Errorf("disk cache not available")
+		return false, fmt.Errorf("disk cache not available")
 	}
 
 	workspaceID := r.client.GetWorkspaceID()
 	siteURL := r.client.GetConfig().GetBaseURL()
 
-	entry, err := r.diskCache.LoadCache(workspaceID, siteURL)
+	modTime := r.diskCache.modTime(workspaceID, siteURL)
+	entry, err := r.diskCache.readCache(workspaceID, siteURL)
 	if err != nil {
-		return err
+		return false, err
 	}
+	r.diskModTime.Store(modTime.UnixNano())
 
 	r.cache.mu.Lock()
 	defer r.cache.mu.Unlock()
@@ -543,7 +865,20 @@ func (r *Resolver) loadFromDiskCache() error {
 	r.cache.typesByName = entry.TypesByName
 	r.cache.lastRefresh = entry.CachedAt
 
-	return nil
+	// Caches written before the object index existed have none
+	r.cache.objects = orEmpty(entry.Objects)
+	r.cache.objectsByKey = orEmpty(entry.ObjectsByKey)
+	r.cache.objectsByLabel = orEmpty(entry.ObjectsByLabel)
+	r.cache.attributes = entry.Attributes
+	if r.cache.attributes == nil {
+		r.cache.attributes = make(map[string]*AttributeCacheEntry)
+	}
+
+	fresh := !entry.Expired()
+	for _, schema := range entry.Schemas {
+		fresh = fresh && !schema.Stale
+	}
+	return fresh, nil
 }
 
 // saveToDiskCache saves current cache data to disk
@@ -557,6 +892,14 @@ func (r *Resolver) saveToDiskCache() {
 
 	if err := r.diskCache.SaveCache(workspaceID, siteURL, r.cache); err != nil {
 		logger.Warning("failed to save cache to disk: %v", err)
+		return
 	}
+	r.diskModTime.Store(r.diskCache.modTime(workspaceID, siteURL).UnixNano())
 }
 
+func orEmpty(m map[string]*EntityInfo) map[string]*EntityInfo {
+	if m == nil {
+		return make(map[string]*EntityInfo)
+	}
+	return m
+}
@@ -0,0 +1,296 @@
+package resolver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+)
+
+// fakeAssetsAPI serves schemas, object types, attributes and a fixed set of objects,
+// answering key and label queries and recording the calls it receives
+type fakeAssetsAPI struct {
+	config         *config.Config
+	schemas        []*models.ObjectSchemaScheme
+	objectTypes    map[string][]*models.ObjectTypeScheme          // By schema ID
+	attributes     map[string][]*models.ObjectTypeAttributeScheme // By object type ID
+	objects        []*models.ObjectScheme
+	queries        []string
+	listCalls      int
+	typeCalls      []string // Schema IDs whose object types were fetched
+	attributeCalls []string // Object type IDs whose attributes were fetched
+}
+
+func (f *fakeAssetsAPI) ListSchemas(ctx context.Context) (*client.Response, error) {
+	f.listCalls++
+	return client.NewSuccessResponse(map[string]interface{}{"schemas": f.schemas}), nil
+}
+
+func (f *fakeAssetsAPI) GetObjectTypes(ctx context.Context, schemaID string) (*client.Response, error) {
+	f.typeCalls = append(f.typeCalls, schemaID)
+	return client.NewSuccessResponse(map[string]interface{}{"object_types": f.objectTypes[schemaID]}), nil
+}
+
+func (f *fakeAssetsAPI) GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	f.attributeCalls = append(f.attributeCalls, objectTypeID)
+	return attributesResponse(objectTypeID, f.attributes[objectTypeID]), nil
+}
+
+func (f *fakeAssetsAPI) GetObject(ctx context.Context, objectID string) (*client.Response, error) {
+	for _, object := range f.objects {
+		if object.ID == objectID {
+			return client.NewSuccessResponse(object), nil
+		}
+	}
+	return client.NewErrorResponse(errObjectNotFound), nil
+}
+
+func (f *fakeAssetsAPI) SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error) {
+	f.queries = append(f.queries, query)
+
+	// AQL compares keys and labels case-insensitively
+	query = strings.ToLower(query)
+	var matches []*models.ObjectScheme
+	for _, object := range f.objects {
+		if strings.Contains(query, strings.ToLower(`Key = "`+object.ObjectKey+`"`)) || strings.Contains(query, strings.ToLower(`Label = "`+object.Label+`"`)) {
+			matches = append(matches, object)
+		}
+	}
+	return client.NewSuccessResponse(map[string]interface{}{"objects": matches}), nil
+}
+
+func (f *fakeAssetsAPI) GetWorkspaceID() string    { return "ws-1" }
+func (f *fakeAssetsAPI) GetConfig() *config.Config { return f.config }
+
+func newFakeAssetsAPI(t *testing.T) *fakeAssetsAPI {
+	laptops := &models.ObjectTypeScheme{ID: "141", Name: "Laptops", ObjectSchemaID: "7"}
+	return &fakeAssetsAPI{
+		config: &config.Config{Host: "https://example.atlassian.net", CacheDir: t.TempDir(), CacheTTLHours: 1},
+		schemas: []*models.ObjectSchemaScheme{
+			{ID: "7", Name: "Hardware", Updated: "2026-10-01T10:00:00.000Z", ObjectTypeCount: 1},
+			{ID: "8", Name: "Facilities", Updated: "2026-10-01T10:00:00.000Z", ObjectTypeCount: 1},
+		},
+		objectTypes: map[string][]*models.ObjectTypeScheme{
+			"7": {laptops},
+			"8": {{ID: "200", Name: "Buildings", ObjectSchemaID: "8"}},
+		},
+		attributes: map[string][]*models.ObjectTypeAttributeScheme{
+			"141": {{ID: "701", Name: "Name"}},
+			"200": {{ID: "801", Name: "Address"}},
+		},
+		objects: []*models.ObjectScheme{
+			{ID: "1001", ObjectKey: "HW-1", Label: "Laptop 42", ObjectType: laptops},
+			{ID: "1002", ObjectKey: "HW-2", Label: "Spare", ObjectType: laptops},
+			{ID: "1003", ObjectKey: "HW-3", Label: "Spare", ObjectType: laptops},
+		},
+	}
+}
+
+func TestResolveObjectIDPersistsIndex(t *testing.T) {
+	api := newFakeAssetsAPI(t)
+	ctx := context.Background()
+
+	references := map[string]string{
+		"hw-1":               "1001",
+		"Hardware/HW-2":      "1002",
+		"hardware/laptop 42": "1001",
+	}
+	for reference, want := range references {
+		if id, err := NewResolver(api).ResolveObjectID(ctx, reference); err != nil || id != want {
+			t.Fatalf("ResolveObjectID(%q) = %q, %v; want %q", reference, id, err, want)
+		}
+	}
+	searches := len(api.queries)
+
+	// A new resolver answers from the index saved to disk
+	for reference, want := range references {
+		if id, err := NewResolver(api).ResolveObjectID(ctx, reference); err != nil || id != want {
+			t.Errorf("cached ResolveObjectID(%q) = %q, %v; want %q", reference, id, err, want)
+		}
+	}
+	if len(api.queries) != searches {
+		t.Errorf("cached lookups searched again: %v", api.queries[searches:])
+	}
+}
+
+func TestResolveObjectIDErrors(t *testing.T) {
+	api := newFakeAssetsAPI(t)
+	r := NewResolver(api)
+	ctx := context.Background()
+
+	tests := []struct {
+		reference string
+		wantError string
+	}{
+		{reference: "Hardware/Spare", wantError: "HW-2 (Spare), HW-3 (Spare)"},
+		{reference: "Hardware/Missing", wantError: "no object with key or label 'Missing' in schema Hardware"},
+		{reference: "HW-99", wantError: "no object with key 'HW-99'"},
+		{reference: "Laptop 42", wantError: "e.g. 'Hardware/Laptop 42'"},
+		{reference: "Software/HW-1", wantError: "schema not found: Software"},
+	}
+	for _, tt := range tests {
+		if _, err := r.ResolveObjectID(ctx, tt.reference); err == nil || !strings.Contains(err.Error(), tt.wantError) {
+			t.Errorf("ResolveObjectID(%q) error = %v, want %q", tt.reference, err, tt.wantError)
+		}
+	}
+	if query := api.queries[0]; query != `objectSchemaId = 7 AND Label = "Spare"` {
+		t.Errorf("label query = %q", query)
+	}
+}
+
+func TestRefreshCacheReloadsChangedSchemas(t *testing.T) {
+	api := newFakeAssetsAPI(t)
+	ctx := context.Background()
+
+	r := NewResolver(api)
+	if err := r.RefreshCache(ctx); err != nil {
+		t.Fatalf("RefreshCache failed: %v", err)
+	}
+	if len(api.typeCalls) != 2 {
+		t.Fatalf("first refresh loaded %v, want both schemas", api.typeCalls)
+	}
+
+	// Only the schema whose object type count changed is reloaded
+	api.schemas[1].ObjectTypeCount = 2
+	api.objectTypes["8"] = append(api.objectTypes["8"], &models.ObjectTypeScheme{ID: "201", Name: "Rooms", ObjectSchemaID: "8"})
+	api.typeCalls = nil
+	if err := NewResolver(api).RefreshCache(ctx); err != nil {
+		t.Fatalf("RefreshCache failed: %v", err)
+	}
+	if len(api.typeCalls) != 1 || api.typeCalls[0] != "8" {
+		t.Errorf("refresh loaded %v, want only schema 8", api.typeCalls)
+	}
+
+	// A removed schema is dropped along with its object types
+	api.schemas = api.schemas[1:]
+	api.typeCalls = nil
+	r = NewResolver(api)
+	if err := r.RefreshCache(ctx); err != nil {
+		t.Fatalf("RefreshCache failed: %v", err)
+	}
+	if len(api.typeCalls) != 0 {
+		t.Errorf("refresh loaded %v, want nothing", api.typeCalls)
+	}
+	if _, err := r.ResolveSchemaID(ctx, "Hardware"); err == nil {
+		t.Error("removed schema still resolves")
+	}
+	if id, err := r.ResolveObjectTypeID(ctx, "Facilities", "Rooms"); err != nil || id != "201" {
+		t.Errorf("ResolveObjectTypeID = %q, %v", id, err)
+	}
+	if stats := r.GetCacheStats(); stats["object_types"] != 2 {
+		t.Errorf("object_types = %v, want 2", stats["object_types"])
+	}
+}
+
+func TestInvalidateObjectType(t *testing.T) {
+	api := newFakeAssetsAPI(t)
+	ctx := context.Background()
+
+	if _, err := NewResolver(api).ResolveSchemaID(ctx, "Hardware"); err != nil {
+		t.Fatalf("ResolveSchemaID failed: %v", err)
+	}
+
+	// A current disk cache is used without calling the API
+	api.listCalls, api.typeCalls = 0, nil
+	if _, err := NewResolver(api).ResolveSchemaID(ctx, "Hardware"); err != nil || api.listCalls != 0 {
+		t.Fatalf("cached ResolveSchemaID = %v with %d list calls", err, api.listCalls)
+	}
+
+	// A change made by this tool reloads the schema even though its timestamp is unchanged
+	api.objectTypes["7"] = append(api.objectTypes["7"], &models.ObjectTypeScheme{ID: "142", Name: "Monitors", ObjectSchemaID: "7"})
+	NewResolver(api).InvalidateObjectType("141")
+	id, err := NewResolver(api).ResolveObjectTypeID(ctx, "Hardware", "Monitors")
+	if err != nil || id != "142" {
+		t.Fatalf("ResolveObjectTypeID = %q, %v", id, err)
+	}
+	if api.listCalls != 1 || len(api.typeCalls) != 1 || api.typeCalls[0] != "7" {
+		t.Errorf("invalidated refresh made %d list calls and loaded %v, want 1 and schema 7", api.listCalls, api.typeCalls)
+	}
+
+	// The stale mark is cleared once the schema is reloaded
+	api.listCalls = 0
+	if _, err := NewResolver(api).ResolveSchemaID(ctx, "Hardware"); err != nil || api.listCalls != 0 {
+		t.Errorf("ResolveSchemaID after reload = %v with %d list calls", err, api.listCalls)
+	}
+}
+
+func TestInvalidationReachesLongLivedResolver(t *testing.T) {
+	api := newFakeAssetsAPI(t)
+	ctx := context.Background()
+
+	// A resolver kept for the life of a server, as the MCP server does
+	shared := NewResolver(api)
+	if _, err := shared.ResolveSchemaID(ctx, "Hardware"); err != nil {
+		t.Fatalf("ResolveSchemaID failed: %v", err)
+	}
+
+	// A change made through another resolver marks the schema stale on disk
+	api.objectTypes["7"] = append(api.objectTypes["7"], &models.ObjectTypeScheme{ID: "142", Name: "Monitors", ObjectSchemaID: "7"})
+	NewResolver(api).InvalidateObjectType("141")
+
+	// The shared resolver sees the mark before its in-memory cache expires
+	api.listCalls, api.typeCalls = 0, nil
+	id, err := shared.ResolveObjectTypeID(ctx, "Hardware", "Monitors")
+	if err != nil || id != "142" {
+		t.Fatalf("ResolveObjectTypeID = %q, %v", id, err)
+	}
+	if api.listCalls != 1 || len(api.typeCalls) != 1 || api.typeCalls[0] != "7" {
+		t.Errorf("refresh made %d list calls and loaded %v, want 1 and schema 7", api.listCalls, api.typeCalls)
+	}
+
+	// Its own writes do not make it reload
+	api.listCalls = 0
+	if _, err := shared.ResolveSchemaID(ctx, "Hardware"); err != nil || api.listCalls != 0 {
+		t.Errorf("ResolveSchemaID after reload = %v with %d list calls", err, api.listCalls)
+	}
+}
+
+func TestGetObjectTypeAttributesCached(t *testing.T) {
+	api := newFakeAssetsAPI(t)
+	ctx := context.Background()
+
+	attributeNames := func(objectTypeID string) []string {
+		response, err := NewResolver(api).GetObjectTypeAttributes(ctx, objectTypeID)
+		if err != nil || !response.Success {
+			t.Fatalf("GetObjectTypeAttributes(%s) failed: %v %v", objectTypeID, err, response.Error)
+		}
+		var names []string
+		for _, attribute := range response.Data.(map[string]interface{})["attributes"].([]*models.ObjectTypeAttributeScheme) {
+			names = append(names, attribute.Name)
+		}
+		return names
+	}
+
+	if err := NewResolver(api).RefreshCache(ctx); err != nil {
+		t.Fatalf("RefreshCache failed: %v", err)
+	}
+	attributeNames("141")
+	attributeNames("200")
+
+	// Later resolvers read the attributes from disk
+	api.attributes["141"] = append(api.attributes["141"], &models.ObjectTypeAttributeScheme{ID: "702", Name: "CPU"})
+	if names := attributeNames("141"); len(names) != 1 || len(api.attributeCalls) != 2 {
+		t.Fatalf("cached attributes = %v after calls %v", names, api.attributeCalls)
+	}
+
+	// A change made by this tool drops the cached attributes of the schema
+	NewResolver(api).InvalidateObjectType("141")
+	if names := attributeNames("141"); len(names) != 2 {
+		t.Errorf("attributes after invalidation = %v, want Name and CPU", names)
+	}
+
+	// A schema change found by a refresh drops only that schema's attributes
+	api.schemas[1].Updated = "2026-10-02T10:00:00.000Z"
+	api.attributeCalls = nil
+	if err := NewResolver(api).RefreshCache(ctx); err != nil {
+		t.Fatalf("RefreshCache failed: %v", err)
+	}
+	attributeNames("141")
+	attributeNames("200")
+	if len(api.attributeCalls) != 1 || api.attributeCalls[0] != "200" {
+		t.Errorf("attribute calls after schema change = %v, want only 200", api.attributeCalls)
+	}
+}
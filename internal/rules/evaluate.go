@@ -0,0 +1,348 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+
+	"github.com/aaronsb/atlassian-assets/internal/client"
+)
+
+// API is the part of the Assets client rules need to scope and run lookups
+type API interface {
+	GetObjectType(ctx context.Context, objectTypeID string) (*client.Response, error)
+	GetSchema(ctx context.Context, schemaID string) (*client.Response, error)
+	SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error)
+}
+
+// Violation is a rule that an object's properties failed
+type Violation struct {
+	RuleID     string   `json:"rule_id"`
+	Field      string   `json:"field,omitempty"`
+	Severity   Severity `json:"severity"`
+	Code       string   `json:"code"`
+	Message    string   `json:"message"`
+	Suggestion string   `json:"suggestion,omitempty"`
+}
+
+// target identifies the object type being validated, for matching rule scopes
+type target struct {
+	objectTypeID   string
+	objectTypeName string
+	schemaID       string
+	schemaName     string
+}
+
+// Empty reports whether the set has no rules
+func (s *RuleSet) Empty() bool {
+	return s == nil || len(s.Rules) == 0
+}
+
+// Check evaluates every rule that applies to the object type against the properties
+func (s *RuleSet) Check(ctx context.Context, api API, objectTypeID string, properties map[string]interface{}) ([]Violation, error) {
+	if s.Empty() {
+		return nil, nil
+	}
+
+	t := target{objectTypeID: objectTypeID}
+	if s.scoped() {
+		if err := t.load(ctx, api); err != nil {
+			return nil, err
+		}
+	}
+
+	values := normalizeProperties(properties)
+
+	var violations []Violation
+	for i := range s.Rules {
+		rule := &s.Rules[i]
+		if !rule.appliesTo(t) || !allHold(rule.When, values) {
+			continue
+		}
+
+		failed, err := rule.failed(ctx, api, t, values)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+		if failed {
+			violations = append(violations, rule.violation(values))
+		}
+	}
+
+	return violations, nil
+}
+
+// HasErrors reports whether any violation has error severity
+func HasErrors(violations []Violation) bool {
+	for _, v := range violations {
+		if v.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// scoped reports whether any rule is limited to a schema or object type
+func (s *RuleSet) scoped() bool {
+	for _, rule := range s.Rules {
+		if rule.Schema != "" || rule.ObjectType != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// load fetches the object type and schema names used to match rule scopes
+func (t *target) load(ctx context.Context, api API) error {
+	response, err := api.GetObjectType(ctx, t.objectTypeID)
+	if err != nil {
+		return fmt.Errorf("failed to get object type for rules: %w", err)
+	}
+	if !response.Success {
+		return fmt.Errorf("failed to get object type for rules: %s", response.Error)
+	}
+	objectType, ok := response.Data.(*models.ObjectTypeScheme)
+	if !ok {
+		return fmt.Errorf("unexpected object type response for rules")
+	}
+	t.objectTypeName = objectType.Name
+	t.schemaID = objectType.ObjectSchemaID
+
+	if t.schemaID == "" {
+		return nil
+	}
+	response, err = api.GetSchema(ctx, t.schemaID)
+	if err != nil {
+		return fmt.Errorf("failed to get schema for rules: %w", err)
+	}
+	if response.Success {
+		if schema, ok := response.Data.(*models.ObjectSchemaScheme); ok {
+			t.schemaName = schema.Name
+		}
+	}
+	return nil
+}
+
+// appliesTo reports whether the rule's scope covers the target
+func (r *Rule) appliesTo(t target) bool {
+	if r.ObjectType != "" && r.ObjectType != t.objectTypeID && !strings.EqualFold(r.ObjectType, t.objectTypeName) {
+		return false
+	}
+	if r.Schema != "" && r.Schema != t.schemaID && !strings.EqualFold(r.Schema, t.schemaName) {
+		return false
+	}
+	return true
+}
+
+// failed reports whether the properties violate the rule
+func (r *Rule) failed(ctx context.Context, api API, t target, values map[string]string) (bool, error) {
+	value, present := lookup(values, r.Field)
+
+	switch r.Kind {
+	case KindRequired:
+		return !present, nil
+	case KindCondition:
+		return !allHold(r.Then, values), nil
+	}
+
+	// The remaining kinds only check values that were provided
+	if !present {
+		return false, nil
+	}
+
+	switch r.Kind {
+	case KindRegex:
+		return r.pattern.MatchString(value) == r.Negate, nil
+	case KindRange:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return true, nil
+		}
+		return !within(number, r.Min, r.Max), nil
+	case KindLength:
+		return !within(float64(utf8.RuneCountInString(value)), r.Min, r.Max), nil
+	case KindUnique:
+		return r.exists(ctx, api, t, value)
+	}
+
+	return false, nil
+}
+
+// exists runs the rule's AQL lookup and reports whether it found any object
+func (r *Rule) exists(ctx context.Context, api API, t target, value string) (bool, error) {
+	query := r.AQL
+	if query == "" {
+		query = `objectTypeId = {object_type_id} AND "{field}" = "{value}"`
+	}
+	query = strings.NewReplacer(
+		"{value}", escapeAQL(value),
+		"{field}", escapeAQL(r.Field),
+		"{object_type_id}", t.objectTypeID,
+	).Replace(query)
+
+	response, err := api.SearchObjects(ctx, query, 1)
+	if err != nil {
+		return false, fmt.Errorf("uniqueness lookup failed: %w", err)
+	}
+	if !response.Success {
+		return false, errors.New("uniqueness lookup failed: " + response.Error)
+	}
+
+	data, ok := response.Data.(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	switch total := data["total"].(type) {
+	case int:
+		return total > 0, nil
+	case float64:
+		return total > 0, nil
+	}
+	return false, nil
+}
+
+// violation builds the violation reported for the rule
+func (r *Rule) violation(values map[string]string) Violation {
+	message := r.Message
+	if message == "" {
+		message = r.defaultMessage()
+	}
+	if value, ok := lookup(values, r.Field); ok {
+		message = strings.ReplaceAll(message, "{value}", value)
+	}
+
+	return Violation{
+		RuleID:     r.ID,
+		Field:      r.Field,
+		Severity:   r.Severity,
+		Code:       strings.ToUpper(strings.NewReplacer("-", "_", " ", "_").Replace(r.ID)),
+		Message:    message,
+		Suggestion: r.Suggestion,
+	}
+}
+
+// defaultMessage describes a violation for rules without a message
+func (r *Rule) defaultMessage() string {
+	switch r.Kind {
+	case KindRequired:
+		return fmt.Sprintf("Field '%s' is required", r.Field)
+	case KindRegex:
+		if r.Negate {
+			return fmt.Sprintf("Field '%s' must not match %s", r.Field, r.Pattern)
+		}
+		return fmt.Sprintf("Field '%s' must match %s", r.Field, r.Pattern)
+	case KindRange:
+		return fmt.Sprintf("Field '%s' must be a number%s", r.Field, bounds(r.Min, r.Max))
+	case KindLength:
+		return fmt.Sprintf("Field '%s' length must be%s", r.Field, bounds(r.Min, r.Max))
+	case KindUnique:
+		return fmt.Sprintf("An object with %s '{value}' already exists", r.Field)
+	default:
+		if r.Description != "" {
+			return r.Description
+		}
+		return fmt.Sprintf("Rule %s failed", r.ID)
+	}
+}
+
+// holds reports whether the condition is true for the properties
+func (c *Condition) holds(values map[string]string) bool {
+	value, present := lookup(values, c.Field)
+
+	if c.Present != nil && present != *c.Present {
+		return false
+	}
+	if c.Equals != "" && (!present || !strings.EqualFold(value, c.Equals)) {
+		return false
+	}
+	if c.NotEquals != "" && present && strings.EqualFold(value, c.NotEquals) {
+		return false
+	}
+	if len(c.In) > 0 {
+		if !present {
+			return false
+		}
+		found := false
+		for _, option := range c.In {
+			if strings.EqualFold(value, option) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if c.matches != nil && (!present || !c.matches.MatchString(value)) {
+		return false
+	}
+	return true
+}
+
+func allHold(conditions []Condition, values map[string]string) bool {
+	for i := range conditions {
+		if !conditions[i].holds(values) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeProperties keys property values by normalized field name, dropping empty values
+func normalizeProperties(properties map[string]interface{}) map[string]string {
+	values := make(map[string]string, len(properties))
+	for key, value := range properties {
+		if value == nil {
+			continue
+		}
+		text := strings.TrimSpace(fmt.Sprintf("%v", value))
+		if text == "" {
+			continue
+		}
+		values[normalizeField(key)] = text
+	}
+	return values
+}
+
+// lookup finds a field's value, treating "Serial Number", "serial_number" and
+// "serial-number" as the same field
+func lookup(values map[string]string, field string) (string, bool) {
+	value, ok := values[normalizeField(field)]
+	return value, ok
+}
+
+func normalizeField(field string) string {
+	field = strings.NewReplacer("_", " ", "-", " ").Replace(strings.ToLower(field))
+	return strings.Join(strings.Fields(field), " ")
+}
+
+func within(number float64, min, max *float64) bool {
+	if min != nil && number < *min {
+		return false
+	}
+	if max != nil && number > *max {
+		return false
+	}
+	return true
+}
+
+func bounds(min, max *float64) string {
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf(" between %g and %g", *min, *max)
+	case min != nil:
+		return fmt.Sprintf(" at least %g", *min)
+	case max != nil:
+		return fmt.Sprintf(" at most %g", *max)
+	}
+	return ""
+}
+
+func escapeAQL(value string) string {
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
@@ -0,0 +1,232 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aaronsb/atlassian-assets/internal/config"
+)
+
+// Kind selects how a rule checks its field
+type Kind string
+
+const (
+	KindRegex     Kind = "regex"     // Field must (or with negate, must not) match pattern
+	KindRange     Kind = "range"     // Numeric field must lie within min and max
+	KindLength    Kind = "length"    // Field length must lie within min and max
+	KindRequired  Kind = "required"  // Field must be provided
+	KindCondition Kind = "condition" // When the when-conditions hold, the then-conditions must too
+	KindUnique    Kind = "unique"    // An AQL lookup for the value must find no existing objects
+)
+
+// Severity controls whether a violation blocks the operation
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// DefaultDirName is the rules directory looked up in the config directory
+const DefaultDirName = "rules"
+
+// Rule is a single declarative validation rule
+type Rule struct {
+	ID          string      `yaml:"id"`
+	Description string      `yaml:"description"`
+	Schema      string      `yaml:"schema"`      // Schema ID or name the rule is limited to
+	ObjectType  string      `yaml:"object_type"` // Object type ID or name the rule is limited to
+	Kind        Kind        `yaml:"kind"`
+	Field       string      `yaml:"field"`
+	Severity    Severity    `yaml:"severity"`
+	Message     string      `yaml:"message"`
+	Suggestion  string      `yaml:"suggestion"`
+	When        []Condition `yaml:"when"` // The rule only applies when all of these hold
+
+	Pattern string      `yaml:"pattern"`
+	Negate  bool        `yaml:"negate"`
+	Min     *float64    `yaml:"min"`
+	Max     *float64    `yaml:"max"`
+	Then    []Condition `yaml:"then"`
+	AQL     string      `yaml:"aql"` // Supports {value}, {field} and {object_type_id}
+
+	pattern *regexp.Regexp
+}
+
+// Condition tests one field; every operator that is set must hold
+type Condition struct {
+	Field     string   `yaml:"field"`
+	Equals    string   `yaml:"equals"`
+	NotEquals string   `yaml:"not_equals"`
+	In        []string `yaml:"in"`
+	Matches   string   `yaml:"matches"`
+	Present   *bool    `yaml:"present"`
+
+	matches *regexp.Regexp
+}
+
+// file is the layout of a rules file. Scope set at the top applies to every rule
+// in the file that does not set its own.
+type file struct {
+	Schema     string `yaml:"schema"`
+	ObjectType string `yaml:"object_type"`
+	Rules      []Rule `yaml:"rules"`
+}
+
+// RuleSet is the collection of rules loaded from one or more files
+type RuleSet struct {
+	Rules   []Rule
+	Sources []string
+}
+
+// Load reads rules from files or directories of *.yaml and *.yml files
+func Load(paths ...string) (*RuleSet, error) {
+	set := &RuleSet{}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rules path: %w", err)
+		}
+
+		files := []string{path}
+		if info.IsDir() {
+			files = nil
+			for _, pattern := range []string{"*.yaml", "*.yml"} {
+				matches, _ := filepath.Glob(filepath.Join(path, pattern))
+				files = append(files, matches...)
+			}
+			sort.Strings(files)
+		}
+
+		for _, name := range files {
+			if err := set.loadFile(name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return set, nil
+}
+
+// LoadDefault loads the rules named by ATLASSIAN_ASSETS_RULES, then the rules
+// directory in the config directory. No rules are loaded if neither exists.
+func LoadDefault() (*RuleSet, error) {
+	if path := os.Getenv("ATLASSIAN_ASSETS_RULES"); path != "" {
+		return Load(path)
+	}
+
+	if configDir, err := config.GetConfigDir(); err == nil {
+		path := filepath.Join(configDir, DefaultDirName)
+		if _, err := os.Stat(path); err == nil {
+			return Load(path)
+		}
+	}
+
+	return &RuleSet{}, nil
+}
+
+// loadFile parses and compiles the rules in one file
+func (s *RuleSet) loadFile(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("failed to parse rules file %s: %w", name, err)
+	}
+
+	for i := range f.Rules {
+		rule := f.Rules[i]
+		if rule.ID == "" {
+			rule.ID = fmt.Sprintf("%s-%d", strings.TrimSuffix(filepath.Base(name), filepath.Ext(name)), i+1)
+		}
+		if rule.Schema == "" {
+			rule.Schema = f.Schema
+		}
+		if rule.ObjectType == "" {
+			rule.ObjectType = f.ObjectType
+		}
+		if rule.Severity == "" {
+			rule.Severity = SeverityError
+		}
+
+		if err := rule.compile(); err != nil {
+			return fmt.Errorf("invalid rule %s in %s: %w", rule.ID, name, err)
+		}
+		s.Rules = append(s.Rules, rule)
+	}
+
+	s.Sources = append(s.Sources, name)
+	return nil
+}
+
+// compile checks a rule's settings and compiles its patterns
+func (r *Rule) compile() error {
+	switch r.Severity {
+	case SeverityError, SeverityWarning, SeverityInfo:
+	default:
+		return fmt.Errorf("unknown severity %q (expected error, warning or info)", r.Severity)
+	}
+
+	switch r.Kind {
+	case KindRegex:
+		if r.Pattern == "" {
+			return fmt.Errorf("regex rule requires a pattern")
+		}
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		r.pattern = pattern
+	case KindRange, KindLength:
+		if r.Min == nil && r.Max == nil {
+			return fmt.Errorf("%s rule requires min or max", r.Kind)
+		}
+	case KindCondition:
+		if len(r.Then) == 0 {
+			return fmt.Errorf("condition rule requires at least one then-condition")
+		}
+	case KindRequired, KindUnique:
+	default:
+		return fmt.Errorf("unknown kind %q", r.Kind)
+	}
+
+	if r.Field == "" && r.Kind != KindCondition {
+		return fmt.Errorf("%s rule requires a field", r.Kind)
+	}
+
+	for _, conditions := range [][]Condition{r.When, r.Then} {
+		for i := range conditions {
+			if err := conditions[i].compile(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// compile checks a condition and compiles its pattern
+func (c *Condition) compile() error {
+	if c.Field == "" {
+		return fmt.Errorf("condition requires a field")
+	}
+	if c.Matches != "" {
+		pattern, err := regexp.Compile(c.Matches)
+		if err != nil {
+			return fmt.Errorf("invalid condition pattern for %s: %w", c.Field, err)
+		}
+		c.matches = pattern
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package rules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+
+	"github.com/aaronsb/atlassian-assets/internal/client"
+)
+
+// fakeAPI serves one object type in one schema and a fixed search total
+type fakeAPI struct {
+	total   int
+	queries []string
+}
+
+func (f *fakeAPI) GetObjectType(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	return client.NewSuccessResponse(&models.ObjectTypeScheme{ID: objectTypeID, Name: "Laptops", ObjectSchemaID: "6"}), nil
+}
+
+func (f *fakeAPI) GetSchema(ctx context.Context, schemaID string) (*client.Response, error) {
+	return client.NewSuccessResponse(&models.ObjectSchemaScheme{ID: schemaID, Name: "Hardware"}), nil
+}
+
+func (f *fakeAPI) SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error) {
+	f.queries = append(f.queries, query)
+	return client.NewSuccessResponse(map[string]interface{}{"objects": []interface{}{}, "total": f.total}), nil
+}
+
+func writeRules(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func loadRules(t *testing.T, content string) *RuleSet {
+	t.Helper()
+	set, err := Load(writeRules(t, t.TempDir(), "rules.yaml", content))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	return set
+}
+
+func TestCheck(t *testing.T) {
+	set := loadRules(t, `
+object_type: Laptops
+rules:
+  - id: asset-tag-length
+    kind: length
+    field: asset_tag
+    min: 3
+  - id: test-serial
+    kind: regex
+    field: serial_number
+    pattern: (?i)test
+    negate: true
+    severity: warning
+  - id: cost-range
+    kind: range
+    field: cost
+    min: 0
+    max: 5000
+  - id: virtual-not-byod
+    kind: condition
+    severity: warning
+    when:
+      - field: device_type
+        equals: Virtual
+    then:
+      - field: ownership_type
+        not_equals: BYOD
+  - id: model-required
+    kind: required
+    field: model_name
+    when:
+      - field: device_type
+        in: [Physical]
+`)
+
+	tests := []struct {
+		name       string
+		properties map[string]interface{}
+		want       []string
+	}{
+		{"valid object", map[string]interface{}{"asset_tag": "LAP-001", "cost": 1200}, nil},
+		{"short asset tag", map[string]interface{}{"asset_tag": "L1"}, []string{"asset-tag-length"}},
+		{"suspicious serial", map[string]interface{}{"Serial Number": "TEST123"}, []string{"test-serial"}},
+		{"cost out of range", map[string]interface{}{"cost": "9000"}, []string{"cost-range"}},
+		{"cost not a number", map[string]interface{}{"cost": "cheap"}, []string{"cost-range"}},
+		{"virtual BYOD", map[string]interface{}{"device_type": "virtual", "ownership_type": "BYOD"}, []string{"virtual-not-byod"}},
+		{"virtual company owned", map[string]interface{}{"device_type": "Virtual", "ownership_type": "Company"}, nil},
+		{"conditional required field", map[string]interface{}{"device-type": "Physical"}, []string{"model-required"}},
+		{"empty values are absent", map[string]interface{}{"asset_tag": "", "device_type": "Physical", "model_name": " "}, []string{"model-required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := set.Check(context.Background(), &fakeAPI{}, "23", tt.properties)
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+
+			var got []string
+			for _, v := range violations {
+				got = append(got, v.RuleID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckScope(t *testing.T) {
+	set := loadRules(t, `
+rules:
+  - id: hardware-only
+    schema: hardware
+    kind: required
+    field: asset_tag
+  - id: servers-only
+    object_type: Servers
+    kind: required
+    field: asset_tag
+  - id: by-id
+    object_type: "23"
+    kind: required
+    field: serial_number
+`)
+
+	violations, err := set.Check(context.Background(), &fakeAPI{}, "23", map[string]interface{}{"name": "x"})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(violations) != 2 || violations[0].RuleID != "hardware-only" || violations[1].RuleID != "by-id" {
+		t.Errorf("violations = %+v, want hardware-only and by-id", violations)
+	}
+}
+
+func TestCheckUnique(t *testing.T) {
+	set := loadRules(t, `
+rules:
+  - id: unique-serial
+    kind: unique
+    field: Serial Number
+    message: "Serial {value} is already registered"
+`)
+
+	api := &fakeAPI{total: 1}
+	violations, err := set.Check(context.Background(), api, "23", map[string]interface{}{"serial_number": `AB"1`})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	wantQuery := `objectTypeId = 23 AND "Serial Number" = "AB\"1"`
+	if len(api.queries) != 1 || api.queries[0] != wantQuery {
+		t.Errorf("queries = %v, want [%s]", api.queries, wantQuery)
+	}
+	if len(violations) != 1 {
+		t.Fatalf("got %d violations, want 1", len(violations))
+	}
+	v := violations[0]
+	if v.Code != "UNIQUE_SERIAL" || v.Severity != SeverityError || v.Message != `Serial AB"1 is already registered` {
+		t.Errorf("violation = %+v", v)
+	}
+	if !HasErrors(violations) {
+		t.Error("HasErrors = false, want true")
+	}
+
+	api = &fakeAPI{}
+	violations, _ = set.Check(context.Background(), api, "23", map[string]interface{}{"serial_number": "AB1"})
+	if len(violations) != 0 {
+		t.Errorf("violations = %+v, want none", violations)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeRules(t, dir, "a.yaml", "rules:\n  - kind: required\n    field: name\n")
+	writeRules(t, dir, "b.yml", "rules:\n  - id: b\n    kind: required\n    field: owner\n    severity: info\n")
+	writeRules(t, dir, "notes.txt", "not rules")
+
+	set, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(set.Rules) != 2 || len(set.Sources) != 2 {
+		t.Fatalf("loaded %d rules from %d files, want 2 from 2", len(set.Rules), len(set.Sources))
+	}
+	if set.Rules[0].ID != "a-1" || set.Rules[0].Severity != SeverityError {
+		t.Errorf("first rule = %+v, want generated ID and error severity", set.Rules[0])
+	}
+
+	invalid := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown kind", "rules:\n  - kind: magic\n    field: x\n", "unknown kind"},
+		{"unknown severity", "rules:\n  - kind: required\n    field: x\n    severity: fatal\n", "unknown severity"},
+		{"bad pattern", "rules:\n  - kind: regex\n    field: x\n    pattern: \"(\"\n", "invalid pattern"},
+		{"range without bounds", "rules:\n  - kind: range\n    field: x\n", "requires min or max"},
+		{"condition without then", "rules:\n  - kind: condition\n", "then-condition"},
+		{"missing field", "rules:\n  - kind: required\n", "requires a field"},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeRules(t, t.TempDir(), "rules.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDefaultFromEnv(t *testing.T) {
+	path := writeRules(t, t.TempDir(), "rules.yaml", "rules:\n  - kind: required\n    field: name\n")
+	t.Setenv("ATLASSIAN_ASSETS_RULES", path)
+
+	set, err := LoadDefault()
+	if err != nil {
+		t.Fatalf("LoadDefault failed: %v", err)
+	}
+	if len(set.Rules) != 1 || set.Sources[0] != path {
+		t.Errorf("LoadDefault = %+v, want the rules from %s", set, path)
+	}
+}
@@ -0,0 +1,377 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+)
+
+// ErrOffline is returned for every operation that needs a connection to Assets
+var ErrOffline = errors.New("not available offline - run the command without --offline")
+
+// Client answers read operations from a snapshot with the same response shapes as the
+// real client. Operations that change the workspace fail with ErrOffline.
+type Client struct {
+	snap   *Snapshot
+	config *config.Config
+
+	objects        map[string]*models.ObjectScheme
+	objectTypes    map[string]*models.ObjectTypeScheme
+	attributes     map[string][]*models.ObjectTypeAttributeScheme // By object type ID
+	attributeNames map[string]string                              // By attribute ID
+}
+
+// NewClient returns a client that serves the snapshot. The resolver disk cache is
+// turned off so names resolve against the snapshot only.
+func NewClient(snap *Snapshot, cfg *config.Config) *Client {
+	offlineConfig := *cfg
+	offlineConfig.CacheTTLHours = 0
+	offlineConfig.AllowDelete = false
+	if offlineConfig.WorkspaceID == "" {
+		offlineConfig.WorkspaceID = snap.WorkspaceID
+	}
+
+	c := &Client{
+		snap:           snap,
+		config:         &offlineConfig,
+		objects:        make(map[string]*models.ObjectScheme),
+		objectTypes:    make(map[string]*models.ObjectTypeScheme),
+		attributes:     make(map[string][]*models.ObjectTypeAttributeScheme),
+		attributeNames: make(map[string]string),
+	}
+	for _, schema := range snap.Schemas {
+		for _, objectType := range schema.ObjectTypes {
+			c.objectTypes[objectType.ID] = objectType
+		}
+		for objectTypeID, attributes := range schema.Attributes {
+			c.attributes[objectTypeID] = attributes
+			for _, attribute := range attributes {
+				c.attributeNames[attribute.ID] = attribute.Name
+			}
+		}
+		for _, object := range schema.Objects {
+			c.objects[object.ID] = object
+		}
+	}
+	return c
+}
+
+// Snapshot returns the snapshot the client serves
+func (c *Client) Snapshot() *Snapshot {
+	return c.snap
+}
+
+func (c *Client) SearchObjects(ctx context.Context, query string, limit int) (*client.Response, error) {
+	return c.SearchObjectsWithPagination(ctx, query, limit, 0)
+}
+
+func (c *Client) SearchObjectsWithPagination(ctx context.Context, query string, limit int, offset int) (*client.Response, error) {
+	q, err := ParseQuery(query)
+	if err != nil {
+		return client.NewErrorResponse(fmt.Errorf("offline search: %w", err)), nil
+	}
+
+	var matches []*objectView
+	for _, schema := range c.snap.Schemas {
+		for _, object := range schema.Objects {
+			if view := c.view(object); q.matches(view) {
+				matches = append(matches, view)
+			}
+		}
+	}
+	q.sort(matches)
+
+	total := len(matches)
+	if offset > len(matches) {
+		offset = len(matches)
+	}
+	matches = matches[offset:]
+	if limit > 0 && limit < len(matches) {
+		matches = matches[:limit]
+	}
+	objects := make([]*models.ObjectScheme, len(matches))
+	for i, view := range matches {
+		objects[i] = view.object
+	}
+
+	return client.NewSuccessResponse(map[string]interface{}{
+		"objects": objects,
+		"total":   total,
+		"query":   query,
+	}), nil
+}
+
+func (c *Client) ListObjects(ctx context.Context, schemaID string, limit int) (*client.Response, error) {
+	return c.ListObjectsWithPagination(ctx, schemaID, limit, 0)
+}
+
+func (c *Client) ListObjectsWithPagination(ctx context.Context, schemaID string, limit int, offset int) (*client.Response, error) {
+	if c.snap.Schema(schemaID) == nil {
+		return client.NewErrorResponse(c.missingSchema(schemaID)), nil
+	}
+
+	query := fmt.Sprintf("objectSchemaId = %s", schemaID)
+	response, err := c.SearchObjectsWithPagination(ctx, query, limit, offset)
+	if err != nil || !response.Success {
+		return response, err
+	}
+
+	data := response.Data.(map[string]interface{})
+	data["schema"] = schemaID
+	data["method"] = "snapshot"
+	return response, nil
+}
+
+func (c *Client) GetObject(ctx context.Context, objectID string) (*client.Response, error) {
+	object, ok := c.objects[objectID]
+	if !ok {
+		return client.NewErrorResponse(fmt.Errorf("object %s is not in the snapshot", objectID)), nil
+	}
+	return client.NewSuccessResponse(object), nil
+}
+
+// GetObjectHistory fails: snapshots keep objects as they were, not how they changed
+func (c *Client) GetObjectHistory(ctx context.Context, objectID string) (*client.Response, error) {
+	return client.NewErrorResponse(fmt.Errorf("object history is not in the snapshot - run without --offline")), nil
+}
+
+// SearchUsers finds no users, so owners are matched as written
+func (c *Client) SearchUsers(ctx context.Context, query string, limit int) (*client.Response, error) {
+	return client.NewSuccessResponse(map[string]interface{}{"users": []*models.UserScheme{}, "total": 0, "query": query}), nil
+}
+
+// SearchGroups finds no groups, so groups are matched as written
+func (c *Client) SearchGroups(ctx context.Context, query string, limit int) (*client.Response, error) {
+	return client.NewSuccessResponse(map[string]interface{}{"groups": []*models.GroupDetailScheme{}, "total": 0, "query": query}), nil
+}
+
+// GetStatusTypes lists the statuses the snapshot's objects hold, so status names
+// resolve for the statuses in use
+func (c *Client) GetStatusTypes(ctx context.Context, schemaID string) (*client.Response, error) {
+	seen := make(map[string]bool)
+	var statuses []*models.ObjectTypeAssetAttributeStatusScheme
+	for _, object := range c.objects {
+		for _, attribute := range object.Attributes {
+			for _, value := range attribute.ObjectAttributeValues {
+				if value.Status != nil && !seen[value.Status.ID] {
+					seen[value.Status.ID] = true
+					statuses = append(statuses, value.Status)
+				}
+			}
+		}
+	}
+	return client.NewSuccessResponse(map[string]interface{}{"statuses": statuses, "total": len(statuses), "schema_id": schemaID}), nil
+}
+
+func (c *Client) GetObjectTypeAttributes(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	if _, ok := c.objectTypes[objectTypeID]; !ok {
+		return client.NewErrorResponse(fmt.Errorf("object type %s is not in the snapshot", objectTypeID)), nil
+	}
+
+	attributes := c.attributes[objectTypeID]
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object_type_id": objectTypeID,
+		"attributes":     attributes,
+		"count":          len(attributes),
+	}), nil
+}
+
+func (c *Client) ListSchemas(ctx context.Context) (*client.Response, error) {
+	schemas := make([]*models.ObjectSchemaScheme, len(c.snap.Schemas))
+	for i, schema := range c.snap.Schemas {
+		schemas[i] = schema.Schema
+	}
+	return client.NewSuccessResponse(map[string]interface{}{
+		"schemas": schemas,
+		"total":   len(schemas),
+	}), nil
+}
+
+func (c *Client) GetSchema(ctx context.Context, schemaID string) (*client.Response, error) {
+	schema := c.snap.Schema(schemaID)
+	if schema == nil {
+		return client.NewErrorResponse(c.missingSchema(schemaID)), nil
+	}
+	return client.NewSuccessResponse(schema.Schema), nil
+}
+
+func (c *Client) GetObjectTypes(ctx context.Context, schemaID string) (*client.Response, error) {
+	schema := c.snap.Schema(schemaID)
+	if schema == nil {
+		return client.NewErrorResponse(c.missingSchema(schemaID)), nil
+	}
+	return client.NewSuccessResponse(map[string]interface{}{
+		"object_types": schema.ObjectTypes,
+		"schema":       schemaID,
+		"count":        len(schema.ObjectTypes),
+	}), nil
+}
+
+func (c *Client) GetObjectType(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	objectType, ok := c.objectTypes[objectTypeID]
+	if !ok {
+		return client.NewErrorResponse(fmt.Errorf("object type %s is not in the snapshot", objectTypeID)), nil
+	}
+	return client.NewSuccessResponse(objectType), nil
+}
+
+func (c *Client) missingSchema(schemaID string) error {
+	return fmt.Errorf("schema %s is not in the snapshot - pull it with 'assets snapshot pull --schema %s'", schemaID, schemaID)
+}
+
+// Operations that change the workspace
+
+func (c *Client) CreateObjectType(ctx context.Context, schemaID, name, description, iconID string, parentObjectTypeID *string) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) CreateObject(ctx context.Context, objectTypeID string, attributes map[string]interface{}) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) UpdateObject(ctx context.Context, objectID, objectTypeID string, attributes map[string]interface{}) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) DeleteObject(ctx context.Context, objectID string) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) CreateSchema(ctx context.Context, name, description string) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) UpdateObjectType(ctx context.Context, objectTypeID string, payload *models.ObjectTypePayloadScheme) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) DeleteObjectType(ctx context.Context, objectTypeID string) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) ListIcons(ctx context.Context) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) CreateObjectTypeAttribute(ctx context.Context, objectTypeID string, payload *models.ObjectTypeAttributePayloadScheme) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) RemoveAttribute(ctx context.Context, objectTypeID, attributeID string) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) RemoveRelationship(ctx context.Context, objectID, relationshipID string) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) RemoveRelationshipByType(ctx context.Context, objectID, relationshipType, targetID string) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) RemoveProperty(ctx context.Context, objectID, propertyID string) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) RemovePropertyByName(ctx context.Context, objectID, propertyName string) (*client.Response, error) {
+	return client.NewErrorResponse(ErrOffline), nil
+}
+
+func (c *Client) GetWorkspaceID() string                   { return c.config.WorkspaceID }
+func (c *Client) GetConfig() *config.Config                { return c.config }
+func (c *Client) IsDeleteAllowed() bool                    { return false }
+func (c *Client) TestConnection(ctx context.Context) error { return ErrOffline }
+func (c *Client) Close() error                             { return nil }
+
+// objectView exposes the fields of an object that queries match on
+type objectView struct {
+	client *Client
+	object *models.ObjectScheme
+}
+
+func (c *Client) view(object *models.ObjectScheme) *objectView {
+	return &objectView{client: c, object: object}
+}
+
+// objectType returns the object's type, preferring the snapshot's copy
+func (o *objectView) objectType() *models.ObjectTypeScheme {
+	if o.object.ObjectType != nil {
+		if objectType, ok := o.client.objectTypes[o.object.ObjectType.ID]; ok {
+			return objectType
+		}
+		return o.object.ObjectType
+	}
+	return &models.ObjectTypeScheme{}
+}
+
+// values returns the values of a field or attribute; an empty attribute has none
+func (o *objectView) values(field string) []string {
+	var value string
+	switch strings.ToLower(field) {
+	case "objectschemaid":
+		value = o.objectType().ObjectSchemaID
+	case "objecttypeid":
+		value = o.objectType().ID
+	case "objecttype":
+		value = o.objectType().Name
+	case "objectid", "id":
+		value = o.object.ID
+	case "key", "objectkey":
+		value = o.object.ObjectKey
+	case "label":
+		value = o.object.Label
+	case "created":
+		value = o.object.Created
+	case "updated":
+		value = o.object.Updated
+	default:
+		values, found := o.attributeValues(field)
+		// Objects are usually labelled by their Name attribute
+		if !found && strings.EqualFold(field, "name") {
+			value = o.object.Label
+			break
+		}
+		return values
+	}
+
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+// attributeValues returns the stored, display and search values of an attribute by name
+func (o *objectView) attributeValues(name string) ([]string, bool) {
+	var values []string
+	found := false
+	for _, attribute := range o.object.Attributes {
+		attributeName := o.client.attributeNames[attribute.ObjectTypeAttributeID]
+		if attribute.ObjectTypeAttribute != nil && attribute.ObjectTypeAttribute.Name != "" {
+			attributeName = attribute.ObjectTypeAttribute.Name
+		}
+		if !strings.EqualFold(attributeName, name) {
+			continue
+		}
+
+		found = true
+		seen := make(map[string]bool)
+		for _, v := range attribute.ObjectAttributeValues {
+			candidates := []string{v.Value, v.DisplayValue, v.SearchValue}
+			if v.Status != nil {
+				candidates = append(candidates, v.Status.Name)
+			}
+			for _, candidate := range candidates {
+				if candidate != "" && !seen[candidate] {
+					seen[candidate] = true
+					values = append(values, candidate)
+				}
+			}
+		}
+	}
+	return values, found
+}
@@ -0,0 +1,127 @@
+package snapshot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/aaronsb/atlassian-assets/internal/config"
+)
+
+// newTestClient serves two laptops and a building across two schemas
+func newTestClient() *Client {
+	laptops := &models.ObjectTypeScheme{ID: "141", Name: "Laptops", ObjectSchemaID: "7"}
+	buildings := &models.ObjectTypeScheme{ID: "200", Name: "Buildings", ObjectSchemaID: "8"}
+	attribute := func(id string, values ...string) *models.ObjectAttributeScheme {
+		a := &models.ObjectAttributeScheme{ObjectTypeAttributeID: id}
+		for _, v := range values {
+			a.ObjectAttributeValues = append(a.ObjectAttributeValues, &models.ObjectTypeAssetAttributeValueScheme{Value: v, DisplayValue: v})
+		}
+		return a
+	}
+
+	snap := New("ws-1", "https://example.atlassian.net")
+	snap.Put(&Schema{
+		Schema:      &models.ObjectSchemaScheme{ID: "7", Name: "Hardware"},
+		ObjectTypes: []*models.ObjectTypeScheme{laptops},
+		Attributes: map[string][]*models.ObjectTypeAttributeScheme{
+			"141": {{ID: "701", Name: "Name"}, {ID: "702", Name: "RAM"}, {ID: "703", Name: "Owner"}},
+		},
+		Objects: []*models.ObjectScheme{
+			{ID: "1001", ObjectKey: "HW-1", Label: "MacBook Pro 16", ObjectType: laptops,
+				Attributes: []*models.ObjectAttributeScheme{attribute("701", "MacBook Pro 16"), attribute("702", "32"), attribute("703", "ada@example.com")}},
+			{ID: "1002", ObjectKey: "HW-2", Label: "ThinkPad X1", ObjectType: laptops,
+				Attributes: []*models.ObjectAttributeScheme{attribute("701", "ThinkPad X1"), attribute("702", "16")}},
+		},
+	})
+	snap.Put(&Schema{
+		Schema:      &models.ObjectSchemaScheme{ID: "8", Name: "Facilities"},
+		ObjectTypes: []*models.ObjectTypeScheme{buildings},
+		Objects:     []*models.ObjectScheme{{ID: "2001", ObjectKey: "FAC-1", Label: "Main Office", ObjectType: buildings}},
+	})
+	return NewClient(snap, &config.Config{CacheTTLHours: 24, AllowDelete: true})
+}
+
+func TestSearchObjects(t *testing.T) {
+	c := newTestClient()
+
+	tests := []struct {
+		query string
+		want  string // Keys of the matches, in order
+	}{
+		{query: `objectSchemaId = 7`, want: "HW-1,HW-2"},
+		{query: `objectSchemaId = 7 AND (Name = "thinkpad x1" OR Key = "thinkpad x1")`, want: "HW-2"},
+		{query: `objectType = "Buildings"`, want: "FAC-1"},
+		{query: `objectTypeId = 141 AND Key = "HW-1"`, want: "HW-1"},
+		{query: `Label = "Main Office"`, want: "FAC-1"},
+		{query: `Name = "Main Office"`, want: "FAC-1"}, // No Name attribute, so the label is used
+		{query: `Name like "macbook%16"`, want: "HW-1"},
+		{query: `RAM >= 32`, want: "HW-1"},
+		{query: `RAM in (16, 64)`, want: "HW-2"},
+		{query: `Owner is EMPTY AND objectSchemaId = 7`, want: "HW-2"},
+		{query: `Owner != ""`, want: "HW-1"},
+		{query: `Key not in ("HW-1") order by Label desc`, want: "HW-2,FAC-1"},
+		{query: `objectSchemaId = 7 order by RAM`, want: "HW-2,HW-1"},
+	}
+	for _, tt := range tests {
+		response, err := c.SearchObjects(context.Background(), tt.query, 50)
+		if err != nil || !response.Success {
+			t.Errorf("SearchObjects(%q) failed: %v %s", tt.query, err, response.Error)
+			continue
+		}
+		var keys []string
+		for _, object := range response.Data.(map[string]interface{})["objects"].([]*models.ObjectScheme) {
+			keys = append(keys, object.ObjectKey)
+		}
+		if got := strings.Join(keys, ","); got != tt.want {
+			t.Errorf("SearchObjects(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchObjectsUnsupported(t *testing.T) {
+	c := newTestClient()
+
+	tests := []struct {
+		query     string
+		wantError string
+	}{
+		{query: `object HAVING inboundReferences()`, wantError: "operator \"having\" is not supported offline"},
+		{query: `Owner = currentUser()`, wantError: "AQL function currentUser() is not supported offline"},
+		{query: `Owner.Email = "ada@example.com"`, wantError: "dot notation"},
+		{query: `Name = "unterminated`, wantError: "unterminated \" quote"},
+		{query: `(Key = "HW-1"`, wantError: "missing ')'"},
+	}
+	for _, tt := range tests {
+		response, err := c.SearchObjects(context.Background(), tt.query, 50)
+		if err != nil || response.Success || !strings.Contains(response.Error, tt.wantError) {
+			t.Errorf("SearchObjects(%q) = %+v, %v; want error %q", tt.query, response, err, tt.wantError)
+		}
+	}
+}
+
+func TestClientReads(t *testing.T) {
+	c := newTestClient()
+	ctx := context.Background()
+
+	list, _ := c.ListObjectsWithPagination(ctx, "7", 1, 1)
+	data := list.Data.(map[string]interface{})
+	if objects := data["objects"].([]*models.ObjectScheme); len(objects) != 1 || objects[0].ID != "1002" || data["total"] != 2 {
+		t.Errorf("second page = %v", data)
+	}
+	if response, _ := c.GetObjectTypes(ctx, "9"); response.Success || !strings.Contains(response.Error, "snapshot pull --schema 9") {
+		t.Errorf("missing schema = %+v", response)
+	}
+	if response, _ := c.GetObject(ctx, "2001"); !response.Success {
+		t.Errorf("GetObject failed: %s", response.Error)
+	}
+
+	// The resolver disk cache and deletions are turned off
+	if c.GetConfig().CacheTTLHours != 0 || c.IsDeleteAllowed() || c.GetWorkspaceID() != "ws-1" {
+		t.Errorf("config = %+v", c.GetConfig())
+	}
+	if response, _ := c.DeleteObject(ctx, "1001"); response.Success || response.Error != ErrOffline.Error() {
+		t.Errorf("DeleteObject = %+v, want it refused", response)
+	}
+}
@@ -0,0 +1,414 @@
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The subset of AQL answered from a snapshot:
+//
+//	condition  = field operator value
+//	operator   = "=" | "==" | "!=" | "<" | ">" | "<=" | ">=" | like | not like
+//	           | startswith | endswith | in (v, ...) | not in (v, ...) | is EMPTY | is not EMPTY
+//	expression = condition, joined with AND and OR and grouped with parentheses
+//	query      = expression [order by field [asc | desc]]
+//
+// Fields are objectSchemaId, objectTypeId, objectType, objectId, Key, Label, Created,
+// Updated or an attribute name. Comparisons ignore case, and like matches substrings
+// with % as a wildcard. Functions, dot notation and reference queries are not supported.
+
+// Query is a parsed AQL query
+type Query struct {
+	where     node
+	orderBy   string
+	orderDesc bool
+}
+
+// node is a condition or a combination of conditions
+type node interface {
+	match(o *objectView) bool
+}
+
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+
+func (n andNode) match(o *objectView) bool { return n.left.match(o) && n.right.match(o) }
+func (n orNode) match(o *objectView) bool  { return n.left.match(o) || n.right.match(o) }
+
+type condition struct {
+	field  string
+	op     string
+	values []string
+}
+
+// ParseQuery parses a query in the supported subset of AQL
+func ParseQuery(query string) (*Query, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	q := &Query{}
+	if !p.peekWord("order") {
+		if q.where, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peekWord("order") {
+		p.next()
+		if !p.acceptWord("by") {
+			return nil, fmt.Errorf("expected 'by' after 'order'")
+		}
+		field := p.next()
+		if field.kind != wordToken && field.kind != stringToken {
+			return nil, fmt.Errorf("expected a field after 'order by'")
+		}
+		q.orderBy = field.text
+		if p.acceptWord("desc") {
+			q.orderDesc = true
+		} else {
+			p.acceptWord("asc")
+		}
+	}
+	if token := p.peek(); token.kind != endToken {
+		return nil, fmt.Errorf("unexpected %q in query", token.text)
+	}
+	return q, nil
+}
+
+// matches reports whether an object satisfies the query
+func (q *Query) matches(o *objectView) bool {
+	return q.where == nil || q.where.match(o)
+}
+
+// sort orders objects by the query's order by field
+func (q *Query) sort(views []*objectView) {
+	if q.orderBy == "" {
+		return
+	}
+	first := func(o *objectView) string {
+		if values := o.values(q.orderBy); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	sort.SliceStable(views, func(i, j int) bool {
+		cmp := compareValues(first(views[i]), first(views[j]))
+		if q.orderDesc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+}
+
+func (c *condition) match(o *objectView) bool {
+	values := o.values(c.field)
+
+	switch c.op {
+	case "is":
+		return len(values) == 0
+	case "is not":
+		return len(values) > 0
+	case "!=", "not like", "not in":
+		return !c.matchAny(values, strings.TrimPrefix(c.op, "not "))
+	}
+	return c.matchAny(values, c.op)
+}
+
+// matchAny reports whether any value satisfies the positive form of the operator
+func (c *condition) matchAny(values []string, op string) bool {
+	if op == "!=" {
+		op = "="
+	}
+	// An empty string stands for an empty attribute, as in Name != ""
+	if op == "=" && c.values[0] == "" {
+		return len(values) == 0
+	}
+
+	for _, value := range values {
+		for _, want := range c.values {
+			if compare(value, op, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func compare(value, op, want string) bool {
+	lowerValue, lowerWant := strings.ToLower(value), strings.ToLower(want)
+	switch op {
+	case "=", "in":
+		return lowerValue == lowerWant
+	case "like":
+		return likeMatch(lowerValue, lowerWant)
+	case "startswith":
+		return strings.HasPrefix(lowerValue, lowerWant)
+	case "endswith":
+		return strings.HasSuffix(lowerValue, lowerWant)
+	case "<":
+		return compareValues(value, want) < 0
+	case ">":
+		return compareValues(value, want) > 0
+	case "<=":
+		return compareValues(value, want) <= 0
+	case ">=":
+		return compareValues(value, want) >= 0
+	}
+	return false
+}
+
+// likeMatch reports whether value contains the pattern, with % matching any text
+func likeMatch(value, pattern string) bool {
+	for _, part := range strings.Split(pattern, "%") {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return true
+}
+
+// compareValues compares numbers numerically and anything else as text
+func compareValues(a, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// Tokens
+
+type tokenKind int
+
+const (
+	endToken tokenKind = iota
+	wordToken
+	stringToken
+	symbolToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			var text strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				text.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated %c quote in query", r)
+			}
+			tokens = append(tokens, token{kind: stringToken, text: text.String()})
+			i = j + 1
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, token{kind: symbolToken, text: string(r)})
+			i++
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			j := i + 1
+			if j < len(runes) && runes[j] == '=' {
+				j++
+			}
+			symbol := string(runes[i:j])
+			if symbol == "!" {
+				return nil, fmt.Errorf("unexpected '!' in query")
+			}
+			tokens = append(tokens, token{kind: symbolToken, text: symbol})
+			i = j
+		default:
+			j := i
+			for j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune(`"'(),=!<>`, runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: wordToken, text: string(runes[i:j])})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// Parser
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{kind: endToken}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.kind != endToken {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) peekWord(word string) bool {
+	t := p.peek()
+	return t.kind == wordToken && strings.EqualFold(t.text, word)
+}
+
+func (p *parser) acceptWord(word string) bool {
+	if p.peekWord(word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) acceptSymbol(symbol string) bool {
+	if t := p.peek(); t.kind == symbolToken && t.text == symbol {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptWord("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptWord("and") {
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseTerm() (node, error) {
+	if p.acceptSymbol("(") {
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptSymbol(")") {
+			return nil, fmt.Errorf("missing ')' in query")
+		}
+		return n, nil
+	}
+
+	field := p.next()
+	if field.kind != wordToken && field.kind != stringToken {
+		return nil, fmt.Errorf("expected a field name, got %q", field.text)
+	}
+	if t := p.peek(); t.kind == symbolToken && t.text == "(" {
+		return nil, fmt.Errorf("AQL function %s() is not supported offline", field.text)
+	}
+	if strings.Contains(field.text, ".") && field.kind == wordToken {
+		return nil, fmt.Errorf("dot notation (%s) is not supported offline", field.text)
+	}
+
+	c := &condition{field: field.text}
+	op := p.next()
+	switch {
+	case op.kind == symbolToken && op.text != "(" && op.text != ")" && op.text != ",":
+		c.op = op.text
+		if c.op == "==" {
+			c.op = "="
+		}
+	case op.kind == wordToken:
+		c.op = strings.ToLower(op.text)
+		if c.op == "not" || c.op == "is" && p.peekWord("not") {
+			c.op += " " + strings.ToLower(p.next().text)
+		}
+	default:
+		return nil, fmt.Errorf("expected an operator after %s", field.text)
+	}
+
+	switch c.op {
+	case "=", "!=", "<", ">", "<=", ">=", "like", "not like", "startswith", "endswith":
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		c.values = []string{value}
+	case "in", "not in":
+		if !p.acceptSymbol("(") {
+			return nil, fmt.Errorf("expected '(' after %s", c.op)
+		}
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			c.values = append(c.values, value)
+			if p.acceptSymbol(")") {
+				break
+			}
+			if !p.acceptSymbol(",") {
+				return nil, fmt.Errorf("expected ',' or ')' in the %s list", c.op)
+			}
+		}
+	case "is", "is not":
+		if !p.acceptWord("empty") {
+			return nil, fmt.Errorf("only 'is EMPTY' and 'is not EMPTY' are supported offline")
+		}
+	default:
+		return nil, fmt.Errorf("operator %q is not supported offline", c.op)
+	}
+	return c, nil
+}
+
+func (p *parser) parseValue() (string, error) {
+	value := p.next()
+	if value.kind != wordToken && value.kind != stringToken {
+		return "", fmt.Errorf("expected a value, got %q", value.text)
+	}
+	if t := p.peek(); value.kind == wordToken && t.kind == symbolToken && t.text == "(" {
+		return "", fmt.Errorf("AQL function %s() is not supported offline", value.text)
+	}
+	return value.text, nil
+}
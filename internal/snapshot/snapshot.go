@@ -0,0 +1,267 @@
+// Package snapshot keeps a local copy of workspace schemas, object types, attributes
+// and objects in a BoltDB file, so that read commands can answer without a connection
+// to Assets.
+package snapshot
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+// DefaultFileName is the snapshot written under the cache directory
+const DefaultFileName = "workspace.db"
+
+// Version of the snapshot file format
+const Version = 2
+
+// Time to wait for a pull that holds the snapshot file open
+const lockTimeout = 5 * time.Second
+
+// Buckets and keys of the snapshot file. The meta bucket describes the workspace; the
+// schemas bucket has a bucket per schema ID, holding the schema, its object types and
+// attributes as JSON and an objects bucket with one object per key in pull order.
+var (
+	metaBucket    = []byte("meta")
+	schemasBucket = []byte("schemas")
+	objectsBucket = []byte("objects")
+
+	versionKey   = []byte("version")
+	workspaceKey = []byte("workspace_id")
+	siteURLKey   = []byte("site_url")
+)
+
+// Schema is everything pulled for one object schema
+type Schema struct {
+	Schema      *models.ObjectSchemaScheme                     `json:"schema"`
+	ObjectTypes []*models.ObjectTypeScheme                     `json:"object_types"`
+	Attributes  map[string][]*models.ObjectTypeAttributeScheme `json:"attributes"` // By object type ID
+	Objects     []*models.ObjectScheme                         `json:"objects"`
+	PulledAt    time.Time                                      `json:"pulled_at"`
+}
+
+// Snapshot is the local copy of the pulled schemas of one workspace
+type Snapshot struct {
+	Version     int       `json:"version"`
+	WorkspaceID string    `json:"workspace_id"`
+	SiteURL     string    `json:"site_url"`
+	Schemas     []*Schema `json:"schemas"`
+}
+
+// New returns an empty snapshot of a workspace
+func New(workspaceID, siteURL string) *Snapshot {
+	return &Snapshot{Version: Version, WorkspaceID: workspaceID, SiteURL: siteURL}
+}
+
+// DefaultPath returns the snapshot file in the cache directory
+func DefaultPath(cacheDir string) string {
+	return filepath.Join(cacheDir, "snapshot", DefaultFileName)
+}
+
+// Load reads a snapshot file
+func Load(path string) (*Snapshot, error) {
+	// Opening a missing file read-only would fail without saying why
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{ReadOnly: true, Timeout: lockTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid snapshot: %w", path, err)
+	}
+	defer db.Close()
+
+	var snap Snapshot
+	err = db.View(func(tx *bolt.Tx) error {
+		meta := tx.Bucket(metaBucket)
+		if meta == nil {
+			return fmt.Errorf("%s: snapshot version 0 is not supported, pull it again", path)
+		}
+		snap.Version, _ = strconv.Atoi(string(meta.Get(versionKey)))
+		if snap.Version != Version {
+			return fmt.Errorf("%s: snapshot version %d is not supported, pull it again", path, snap.Version)
+		}
+		snap.WorkspaceID = string(meta.Get(workspaceKey))
+		snap.SiteURL = string(meta.Get(siteURLKey))
+
+		schemas := tx.Bucket(schemasBucket)
+		if schemas == nil {
+			return nil
+		}
+		return schemas.ForEachBucket(func(id []byte) error {
+			schema, err := readSchema(schemas.Bucket(id))
+			if err != nil {
+				return fmt.Errorf("%s: invalid snapshot of schema %s: %w", path, id, err)
+			}
+			snap.Schemas = append(snap.Schemas, schema)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snap.sortSchemas()
+	return &snap, nil
+}
+
+// schemaFields are the keys of a schema bucket and the parts of the schema they hold
+func schemaFields(schema *Schema) map[string]interface{} {
+	return map[string]interface{}{
+		"schema":       &schema.Schema,
+		"object_types": &schema.ObjectTypes,
+		"attributes":   &schema.Attributes,
+		"pulled_at":    &schema.PulledAt,
+	}
+}
+
+// readSchema decodes the bucket of a pulled schema
+func readSchema(bucket *bolt.Bucket) (*Schema, error) {
+	var schema Schema
+	for key, field := range schemaFields(&schema) {
+		if err := json.Unmarshal(bucket.Get([]byte(key)), field); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	if objects := bucket.Bucket(objectsBucket); objects != nil {
+		err := objects.ForEach(func(_, value []byte) error {
+			var object *models.ObjectScheme
+			if err := json.Unmarshal(value, &object); err != nil {
+				return err
+			}
+			schema.Objects = append(schema.Objects, object)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &schema, nil
+}
+
+// Save writes the snapshot in a single transaction, so that the file only changes once
+// it is complete
+func (s *Snapshot) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: lockTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer db.Close()
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{metaBucket, schemasBucket} {
+			if err := tx.DeleteBucket(name); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+				return err
+			}
+		}
+
+		meta, err := tx.CreateBucket(metaBucket)
+		if err != nil {
+			return err
+		}
+		for key, value := range map[string]string{
+			string(versionKey):   strconv.Itoa(Version),
+			string(workspaceKey): s.WorkspaceID,
+			string(siteURLKey):   s.SiteURL,
+		} {
+			if err := meta.Put([]byte(key), []byte(value)); err != nil {
+				return err
+			}
+		}
+
+		schemas, err := tx.CreateBucket(schemasBucket)
+		if err != nil {
+			return err
+		}
+		for _, schema := range s.Schemas {
+			bucket, err := schemas.CreateBucket([]byte(schema.Schema.ID))
+			if err != nil {
+				return err
+			}
+			if err := writeSchema(bucket, schema); err != nil {
+				return fmt.Errorf("schema %s: %w", schema.Schema.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// writeSchema encodes a pulled schema into its bucket
+func writeSchema(bucket *bolt.Bucket, schema *Schema) error {
+	for key, field := range schemaFields(schema) {
+		data, err := json.Marshal(field)
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put([]byte(key), data); err != nil {
+			return err
+		}
+	}
+
+	objects, err := bucket.CreateBucket(objectsBucket)
+	if err != nil {
+		return err
+	}
+	for i, object := range schema.Objects {
+		data, err := json.Marshal(object)
+		if err != nil {
+			return err
+		}
+		if err := objects.Put(sequenceKey(i), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sequenceKey orders the objects of a schema as they were pulled
+func sequenceKey(i int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(i))
+	return key
+}
+
+// Put adds a pulled schema, replacing an earlier pull of the same schema
+func (s *Snapshot) Put(schema *Schema) {
+	for i, existing := range s.Schemas {
+		if existing.Schema.ID == schema.Schema.ID {
+			s.Schemas[i] = schema
+			return
+		}
+	}
+	s.Schemas = append(s.Schemas, schema)
+	s.sortSchemas()
+}
+
+// sortSchemas orders the schemas by name
+func (s *Snapshot) sortSchemas() {
+	sort.Slice(s.Schemas, func(i, j int) bool { return s.Schemas[i].Schema.Name < s.Schemas[j].Schema.Name })
+}
+
+// Schema returns a pulled schema by ID
+func (s *Snapshot) Schema(schemaID string) *Schema {
+	for _, schema := range s.Schemas {
+		if schema.Schema.ID == schemaID {
+			return schema
+		}
+	}
+	return nil
+}
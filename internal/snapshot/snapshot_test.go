@@ -0,0 +1,73 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := DefaultPath(t.TempDir())
+	snap := New("ws-1", "https://example.atlassian.net")
+	snap.Put(&Schema{Schema: &models.ObjectSchemaScheme{ID: "8", Name: "Facilities"}})
+	snap.Put(&Schema{Schema: &models.ObjectSchemaScheme{ID: "7", Name: "Hardware"}, Objects: []*models.ObjectScheme{{ID: "1"}}})
+
+	// A repeated pull replaces the schema
+	snap.Put(&Schema{Schema: &models.ObjectSchemaScheme{ID: "7", Name: "Hardware"}, Objects: []*models.ObjectScheme{{ID: "1"}, {ID: "2"}}})
+
+	if err := snap.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Schemas) != 2 || loaded.Schemas[0].Schema.Name != "Facilities" || len(loaded.Schema("7").Objects) != 2 {
+		t.Errorf("loaded schemas = %+v", loaded.Schemas)
+	}
+	if objects := loaded.Schema("7").Objects; objects[0].ID != "1" || objects[1].ID != "2" {
+		t.Errorf("objects out of pull order: %+v", objects)
+	}
+
+	// Saving again replaces what the file held
+	loaded.Schemas = loaded.Schemas[1:]
+	if err := loaded.Save(path); err != nil {
+		t.Fatalf("second Save failed: %v", err)
+	}
+	if reloaded, err := Load(path); err != nil || len(reloaded.Schemas) != 1 || reloaded.Schema("8") != nil {
+		t.Errorf("reloaded = %+v, %v; want only schema 7", reloaded, err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := Load(filepath.Join(dir, "missing.db")); !os.IsNotExist(err) {
+		t.Errorf("missing file error = %v", err)
+	}
+
+	// Snapshots were JSON files before they were BoltDB files
+	old := filepath.Join(dir, "workspace.json")
+	os.WriteFile(old, []byte(`{"version": 1}`), 0600)
+	if _, err := Load(old); err == nil || !strings.Contains(err.Error(), "invalid snapshot") {
+		t.Errorf("JSON snapshot error = %v", err)
+	}
+
+	newer := filepath.Join(dir, "newer.db")
+	db, err := bolt.Open(newer, 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Update(func(tx *bolt.Tx) error {
+		meta, _ := tx.CreateBucket(metaBucket)
+		return meta.Put(versionKey, []byte("3"))
+	})
+	db.Close()
+	if _, err := Load(newer); err == nil || !strings.Contains(err.Error(), "version 3 is not supported, pull it again") {
+		t.Errorf("unsupported version error = %v", err)
+	}
+}
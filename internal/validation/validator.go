@@ -2,24 +2,29 @@ package validation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/aaronsb/atlassian-assets/internal/client"
+	"github.com/aaronsb/atlassian-assets/internal/defaults"
 	"github.com/aaronsb/atlassian-assets/internal/property"
+	"github.com/aaronsb/atlassian-assets/internal/resolver"
+	"github.com/aaronsb/atlassian-assets/internal/rules"
 )
 
 // ObjectValidator provides comprehensive validation for Assets objects
 type ObjectValidator struct {
 	client           *client.AssetsClient
 	propertyResolver *property.PropertyResolver
+	rules            *rules.RuleSet
 }
 
 // NewObjectValidator creates a new object validator
 func NewObjectValidator(client *client.AssetsClient) *ObjectValidator {
 	return &ObjectValidator{
 		client:           client,
-		propertyResolver: property.NewPropertyResolver(client),
+		propertyResolver: property.NewPropertyResolver(client).WithDirectory(resolver.NewDirectory(client)).WithStatuses(client),
 	}
 }
 
@@ -52,6 +57,7 @@ type ValidationWarning struct {
 	Field      string `json:"field"`
 	Message    string `json:"message"`
 	Code       string `json:"code"`
+	Severity   string `json:"severity,omitempty"`
 	Suggestion string `json:"suggestion,omitempty"`
 }
 
@@ -89,8 +95,15 @@ func (ov *ObjectValidator) ValidateObject(ctx context.Context, objectTypeID stri
 			Code:     "PROPERTY_ERROR",
 		}
 
+		// Typed property errors carry their own field and code
+		var propErr *property.ValidationError
+		if errors.As(err, &propErr) {
+			validationErr.Field = propErr.AttributeName
+			validationErr.Code = propErr.Code
+		}
+
 		// Extract field name from error if possible
-		if strings.Contains(err.Error(), "property '") {
+		if validationErr.Field == "" && strings.Contains(err.Error(), "property '") {
 			start := strings.Index(err.Error(), "property '") + 10
 			end := strings.Index(err.Error()[start:], "'")
 			if end > 0 {
@@ -102,15 +115,17 @@ func (ov *ObjectValidator) ValidateObject(ctx context.Context, objectTypeID stri
 		if strings.Contains(err.Error(), "required field") {
 			validationErr.Code = "REQUIRED_FIELD_MISSING"
 			validationErr.Suggestion = "Please provide a value for this required field"
+		} else if strings.Contains(err.Error(), "too many values") {
+			validationErr.Suggestion = "Provide fewer values; this attribute's maximum cardinality is lower"
 		} else if strings.Contains(err.Error(), "unknown property") {
 			validationErr.Code = "UNKNOWN_PROPERTY"
 			validationErr.Suggestion = "Check the property name spelling or use 'assets attributes --type " + objectTypeID + "' to see available fields"
-		} else if strings.Contains(err.Error(), "invalid date") {
-			validationErr.Code = "INVALID_DATE_FORMAT"
-			validationErr.Suggestion = "Use date format YYYY-MM-DD (e.g., 2024-01-15)"
 		} else if strings.Contains(err.Error(), "invalid datetime") {
 			validationErr.Code = "INVALID_DATETIME_FORMAT"
 			validationErr.Suggestion = "Use ISO 8601 format (e.g., 2024-01-15T10:30:00Z)"
+		} else if strings.Contains(err.Error(), "invalid date") {
+			validationErr.Code = "INVALID_DATE_FORMAT"
+			validationErr.Suggestion = "Use date format YYYY-MM-DD (e.g., 2024-01-15)"
 		} else if strings.Contains(err.Error(), "invalid option") {
 			validationErr.Code = "INVALID_SELECT_OPTION"
 			// Extract valid options from error message if available
@@ -119,110 +134,77 @@ func (ov *ObjectValidator) ValidateObject(ctx context.Context, objectTypeID stri
 				validationErr.Suggestion = "Valid options: " + err.Error()[start:]
 			}
 		} else if strings.Contains(err.Error(), "reference field") {
-			validationErr.Code = "INVALID_REFERENCE"
-			validationErr.Suggestion = "Reference fields require valid object IDs"
+			if validationErr.Code == "" {
+				validationErr.Code = "INVALID_REFERENCE"
+			}
+			validationErr.Suggestion = "Reference fields take an object ID, an object key (e.g. HW-42), a label of the referenced type, or aql:<query>"
 		}
 
 		result.Errors = append(result.Errors, validationErr)
 		result.Valid = false
 	}
 
-	// Add business rule validations
-	ov.validateBusinessRules(ctx, metadata, properties, result)
-
-	// Add warnings for best practices
-	ov.generateWarnings(metadata, properties, result)
+	// Apply the user-defined validation rules
+	ruleErrors, ruleWarnings, err := ov.applyRules(ctx, objectTypeID, properties)
+	if err != nil {
+		return nil, err
+	}
+	if len(ruleErrors) > 0 {
+		result.Errors = append(result.Errors, ruleErrors...)
+		result.Valid = false
+	}
+	result.Warnings = append(result.Warnings, ruleWarnings...)
 
 	return result, nil
 }
 
-// validateBusinessRules performs additional business logic validation
-func (ov *ObjectValidator) validateBusinessRules(ctx context.Context, metadata map[string]*property.AttributeMetadata, properties map[string]interface{}, result *ValidationResult) {
-	// Example business rules (these would be customized based on organization needs)
-	
-	// Check for reasonable asset tag format if provided
-	if assetTag, exists := properties["asset_tag"]; exists && assetTag != "" {
-		tagStr := fmt.Sprintf("%v", assetTag)
-		if len(tagStr) < 3 {
-			result.Errors = append(result.Errors, ValidationError{
-				Field:      "asset_tag",
-				Message:    "Asset tag should be at least 3 characters long",
-				Code:       "ASSET_TAG_TOO_SHORT",
-				Severity:   "error",
-				Suggestion: "Use a longer, more descriptive asset tag",
-			})
-			result.Valid = false
-		}
+// applyRules evaluates the user-defined validation rules against the properties
+func (ov *ObjectValidator) applyRules(ctx context.Context, objectTypeID string, properties map[string]interface{}) ([]ValidationError, []ValidationWarning, error) {
+	ruleSet, err := ov.loadRules()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Check for serial number format if provided
-	if serialNum, exists := properties["serial_number"]; exists && serialNum != "" {
-		serialStr := fmt.Sprintf("%v", serialNum)
-		if strings.Contains(strings.ToLower(serialStr), "test") {
-			result.Warnings = append(result.Warnings, ValidationWarning{
-				Field:      "serial_number",
-				Message:    "Serial number contains 'test' - ensure this is a real serial number",
-				Code:       "SUSPICIOUS_SERIAL_NUMBER",
-				Suggestion: "Use the actual hardware serial number",
-			})
-		}
+	violations, err := ruleSet.Check(ctx, ov.client, objectTypeID, properties)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to evaluate validation rules: %w", err)
 	}
 
-	// Validate device type and ownership consistency
-	deviceType, hasDeviceType := properties["device_type"]
-	ownershipType, hasOwnershipType := properties["ownership_type"]
-	
-	if hasDeviceType && hasOwnershipType {
-		deviceTypeStr := fmt.Sprintf("%v", deviceType)
-		ownershipTypeStr := fmt.Sprintf("%v", ownershipType)
-		
-		if strings.EqualFold(deviceTypeStr, "Virtual") && strings.EqualFold(ownershipTypeStr, "BYOD") {
-			result.Warnings = append(result.Warnings, ValidationWarning{
-				Field:      "ownership_type",
-				Message:    "Virtual devices are typically not BYOD",
-				Code:       "UNUSUAL_VIRTUAL_BYOD",
-				Suggestion: "Consider if this virtual device should be 'Company owned'",
+	var errs []ValidationError
+	var warnings []ValidationWarning
+	for _, v := range violations {
+		if v.Severity == rules.SeverityError {
+			errs = append(errs, ValidationError{
+				Field:      v.Field,
+				Message:    v.Message,
+				Code:       v.Code,
+				Severity:   string(v.Severity),
+				Suggestion: v.Suggestion,
 			})
+			continue
 		}
+		warnings = append(warnings, ValidationWarning{
+			Field:      v.Field,
+			Message:    v.Message,
+			Code:       v.Code,
+			Severity:   string(v.Severity),
+			Suggestion: v.Suggestion,
+		})
 	}
+
+	return errs, warnings, nil
 }
 
-// generateWarnings generates helpful warnings for best practices
-func (ov *ObjectValidator) generateWarnings(metadata map[string]*property.AttributeMetadata, properties map[string]interface{}, result *ValidationResult) {
-	// Warn about missing important optional fields
-	importantOptionalFields := []string{"asset_tag", "serial_number", "model_name"}
-	
-	for _, fieldName := range importantOptionalFields {
-		if _, provided := properties[strings.ToLower(fieldName)]; !provided {
-			// Check if field exists in metadata
-			if meta, exists := metadata[strings.ToLower(fieldName)]; exists && meta.Editable {
-				result.Warnings = append(result.Warnings, ValidationWarning{
-					Field:      fieldName,
-					Message:    fmt.Sprintf("Optional field '%s' not provided", fieldName),
-					Code:       "MISSING_RECOMMENDED_FIELD",
-					Suggestion: fmt.Sprintf("Consider providing %s for better asset tracking", fieldName),
-				})
-			}
-		}
-	}
-
-	// Warn about very generic names
-	if name, exists := properties["name"]; exists {
-		nameStr := strings.ToLower(fmt.Sprintf("%v", name))
-		genericNames := []string{"test", "laptop", "computer", "device", "asset"}
-		
-		for _, generic := range genericNames {
-			if nameStr == generic {
-				result.Warnings = append(result.Warnings, ValidationWarning{
-					Field:      "name",
-					Message:    "Name is very generic",
-					Code:       "GENERIC_NAME",
-					Suggestion: "Consider using a more specific name that includes model, user, or location",
-				})
-				break
-			}
+// loadRules loads the validation rules once per validator
+func (ov *ObjectValidator) loadRules() (*rules.RuleSet, error) {
+	if ov.rules == nil {
+		ruleSet, err := rules.LoadDefault()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load validation rules: %w", err)
 		}
+		ov.rules = ruleSet
 	}
+	return ov.rules, nil
 }
 
 // ValidateForCreate validates an object for creation (stricter validation)
@@ -285,6 +267,7 @@ type CompletionResult struct {
 	ResolvedProperties []*property.PropertyValue  `json:"resolved_properties"`
 	AppliedDefaults    []DefaultApplication       `json:"applied_defaults"`
 	Suggestions        []CompletionSuggestion     `json:"suggestions"`
+	Errors             []ValidationError          `json:"errors,omitempty"`
 	Warnings           []ValidationWarning        `json:"warnings,omitempty"`
 	MissingCritical    []string                   `json:"missing_critical,omitempty"`
 }
@@ -295,6 +278,8 @@ type DefaultApplication struct {
 	Value       interface{} `json:"value"`
 	Reason      string      `json:"reason"`
 	Confidence  string      `json:"confidence"` // "high", "medium", "low"
+	Source      string      `json:"source"`     // "static", "template", "reference", "first_option"
+	File        string      `json:"file,omitempty"`
 }
 
 // CompletionSuggestion represents a suggested completion for missing information
@@ -326,8 +311,10 @@ func (ov *ObjectValidator) CompleteObject(ctx context.Context, objectTypeID stri
 		return nil, fmt.Errorf("failed to get object type metadata: %w", err)
 	}
 
-	// Apply intelligent defaults and completion
-	ov.applyIntelligentDefaults(metadata, result)
+	// Apply the configured defaults and completion suggestions
+	if err := ov.applyDefaults(ctx, objectTypeID, metadata, result); err != nil {
+		return nil, err
+	}
 	ov.generateCompletionSuggestions(metadata, result)
 
 	// Try to resolve the completed properties
@@ -349,106 +336,70 @@ func (ov *ObjectValidator) CompleteObject(ctx context.Context, objectTypeID stri
 		}
 	}
 
-	// If we have critical missing fields, mark as unsuccessful but still return the partial completion
-	if len(result.MissingCritical) > 0 {
+	// Check the completed object against the user-defined validation rules
+	ruleErrors, ruleWarnings, err := ov.applyRules(ctx, objectTypeID, result.CompletedProperties)
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = append(result.Errors, ruleErrors...)
+	result.Warnings = append(result.Warnings, ruleWarnings...)
+
+	// If we have critical missing fields or rule errors, mark as unsuccessful but still return the partial completion
+	if len(result.MissingCritical) > 0 || len(result.Errors) > 0 {
 		result.Success = false
 	}
 
 	return result, nil
 }
 
-// applyIntelligentDefaults applies reasonable defaults for missing fields
-func (ov *ObjectValidator) applyIntelligentDefaults(metadata map[string]*property.AttributeMetadata, result *CompletionResult) {
-	// Default strategies based on field names and types
-	
-	// Asset Status defaults
-	if _, exists := result.CompletedProperties["asset_status"]; !exists {
-		if meta, found := metadata["asset status"]; found && len(meta.StatusValues) > 0 {
-			// Default to first status value (often "In Stock" or similar)
-			defaultStatus := meta.StatusValues[0]
-			result.CompletedProperties["asset_status"] = defaultStatus
-			result.AppliedDefaults = append(result.AppliedDefaults, DefaultApplication{
-				Field:      "asset_status",
-				Value:      defaultStatus,
-				Reason:     "Applied default status value",
-				Confidence: "medium",
-			})
-		}
+// applyDefaults fills missing fields from the defaults policy for the object type
+func (ov *ObjectValidator) applyDefaults(ctx context.Context, objectTypeID string, metadata map[string]*property.AttributeMetadata, result *CompletionResult) error {
+	defaultSet, err := defaults.LoadDefault()
+	if err != nil {
+		return fmt.Errorf("failed to load defaults policy: %w", err)
+	}
+	if defaultSet.Empty() {
+		return nil
 	}
 
-	// Device Type defaults
-	if _, exists := result.CompletedProperties["device_type"]; !exists {
-		if meta, found := metadata["device type"]; found && len(meta.SelectOptions) > 0 {
-			// Default to "Physical" if available
-			defaultType := "Physical"
-			found := false
-			for _, option := range meta.SelectOptions {
-				if strings.EqualFold(option, "Physical") {
-					defaultType = option
-					found = true
-					break
-				}
-			}
-			if !found {
-				defaultType = meta.SelectOptions[0] // Fallback to first option
-			}
-			
-			result.CompletedProperties["device_type"] = defaultType
-			result.AppliedDefaults = append(result.AppliedDefaults, DefaultApplication{
-				Field:      "device_type",
-				Value:      defaultType,
-				Reason:     "Applied default device type",
-				Confidence: "high",
-			})
-		}
+	sequences, err := defaults.DefaultSequenceStore()
+	if err != nil {
+		return fmt.Errorf("failed to open sequence store: %w", err)
 	}
 
-	// Ownership Type defaults
-	if _, exists := result.CompletedProperties["ownership_type"]; !exists {
-		if meta, found := metadata["ownership type"]; found && len(meta.SelectOptions) > 0 {
-			// Default to "Company owned" if available
-			defaultOwnership := "Company owned"
-			found := false
-			for _, option := range meta.SelectOptions {
-				if strings.Contains(strings.ToLower(option), "company") {
-					defaultOwnership = option
-					found = true
-					break
-				}
-			}
-			if !found {
-				defaultOwnership = meta.SelectOptions[0] // Fallback to first option
-			}
-			
-			result.CompletedProperties["ownership_type"] = defaultOwnership
-			result.AppliedDefaults = append(result.AppliedDefaults, DefaultApplication{
-				Field:      "ownership_type",
-				Value:      defaultOwnership,
-				Reason:     "Applied default ownership type",
-				Confidence: "medium",
-			})
+	// Offer status values and select options so static defaults match their spelling
+	choices := make(map[string][]string)
+	for _, meta := range metadata {
+		if values := meta.Choices(); len(values) > 0 {
+			choices[meta.Name] = values
 		}
 	}
 
-	// Generate Asset Tag if name is provided but asset tag isn't
-	if name, hasName := result.CompletedProperties["name"]; hasName {
-		if _, hasAssetTag := result.CompletedProperties["asset_tag"]; !hasAssetTag {
-			nameStr := fmt.Sprintf("%v", name)
-			// Generate a simple asset tag based on name
-			assetTag := strings.ToUpper(strings.ReplaceAll(nameStr, " ", "-"))
-			if len(assetTag) > 20 {
-				assetTag = assetTag[:20] // Truncate if too long
-			}
-			
-			result.CompletedProperties["asset_tag"] = assetTag
-			result.AppliedDefaults = append(result.AppliedDefaults, DefaultApplication{
-				Field:      "asset_tag",
-				Value:      assetTag,
-				Reason:     "Generated asset tag from name",
-				Confidence: "low",
-			})
+	applied, err := defaultSet.Apply(ctx, defaults.Env{
+		API:       ov.client,
+		Sequences: sequences,
+		Choices:   choices,
+	}, objectTypeID, result.CompletedProperties)
+	if err != nil {
+		return fmt.Errorf("failed to apply defaults: %w", err)
+	}
+
+	for _, a := range applied {
+		confidence := "high"
+		if a.Source == defaults.SourceFirstOption {
+			confidence = "medium"
 		}
+		result.AppliedDefaults = append(result.AppliedDefaults, DefaultApplication{
+			Field:      a.Field,
+			Value:      a.Value,
+			Reason:     a.Detail,
+			Confidence: confidence,
+			Source:     string(a.Source),
+			File:       a.File,
+		})
 	}
+
+	return nil
 }
 
 // generateCompletionSuggestions creates suggestions for missing important fields
@@ -552,5 +503,8 @@ func (ov *ObjectValidator) GetCompletionSummary(result *CompletionResult) string
 	if len(result.MissingCritical) > 0 {
 		summary += fmt.Sprintf(" (missing %d critical fields)", len(result.MissingCritical))
 	}
+	if len(result.Errors) > 0 {
+		summary += fmt.Sprintf(" (%d validation rule errors)", len(result.Errors))
+	}
 	return summary
 }
\ No newline at end of file